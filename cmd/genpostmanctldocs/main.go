@@ -20,11 +20,12 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/kevinswiber/postmanctl/internal/runtime/cmd"
+	"github.com/kevinswiber/postmanctl/pkg/cmd"
+	"github.com/spf13/cobra/doc"
 )
 
 func main() {
-	err := cmd.GenMarkdownTree("./doc")
+	err := doc.GenMarkdownTree(cmd.NewRootCommand(cmd.IOStreams{}, nil), "./doc")
 	if err != nil {
 		fmt.Fprint(os.Stderr, err.Error())
 	}
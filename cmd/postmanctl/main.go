@@ -16,8 +16,12 @@ limitations under the License.
 
 package main
 
-import "github.com/kevinswiber/postmanctl/internal/runtime/cmd"
+import (
+	"os"
+
+	"github.com/kevinswiber/postmanctl/pkg/cmd"
+)
 
 func main() {
-	cmd.Execute()
+	os.Exit(cmd.Execute(cmd.NewRootCommand(cmd.IOStreams{}, nil, cmd.WithPlugins())))
 }
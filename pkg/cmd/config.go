@@ -17,8 +17,11 @@ limitations under the License.
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/kevinswiber/postmanctl/internal/runtime/config"
 	"github.com/kevinswiber/postmanctl/pkg/sdk/printers"
@@ -28,9 +31,9 @@ import (
 	"golang.org/x/crypto/ssh/terminal"
 )
 
-var setContextAPIRoot string
+func newConfigCommand(o *rootOptions) *cobra.Command {
+	var apiRoot string
 
-func init() {
 	var cmd = &cobra.Command{
 		Use:   "config",
 		Short: "Configure access to the Postman API.",
@@ -40,20 +43,21 @@ func init() {
 		Use:   "set-context",
 		Short: "Create a context for accessing the Postman API.",
 		Args:  cobra.MinimumNArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Print("Postman API Key: ")
-			apiKey, err := terminal.ReadPassword(int(os.Stdin.Fd()))
-			fmt.Printf("\n")
+		Annotations: map[string]string{
+			configOptionalAnnotation: "true",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Fprint(o.Out, "Postman API Key: ")
+			apiKey, err := o.readAPIKey()
+			fmt.Fprintf(o.Out, "\n")
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "error: %s\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			cfg = &config.Config{}
-			if configFileFound {
-				if err := viper.Unmarshal(cfg); err != nil {
-					fmt.Fprintf(os.Stderr, "error: %s\n", err)
-					os.Exit(1)
+			cfg := &config.Config{}
+			if o.configFileFound {
+				if err := o.viper.Unmarshal(cfg); err != nil {
+					return err
 				}
 			}
 
@@ -62,35 +66,37 @@ func init() {
 			}
 
 			newContext := config.Context{}
-			if setContextAPIRoot != "" {
-				newContext.APIRoot = setContextAPIRoot
+			if apiRoot != "" {
+				newContext.APIRoot = apiRoot
 			}
 
-			newContext.APIKey = string(apiKey)
+			newContext.APIKey = apiKey
 
 			cfg.Contexts[args[0]] = newContext
 			cfg.CurrentContext = args[0]
 
-			mergeConfig(cfg)
+			return o.mergeConfig(cfg)
 		},
 	}
 
-	setContextCmd.Flags().StringVar(&setContextAPIRoot, "api-root", "https://api.postman.com", "API root URL for accessing the Postman API.")
+	setContextCmd.Flags().StringVar(&apiRoot, "api-root", "https://api.postman.com", "API root URL for accessing the Postman API.")
 
 	useContextCmd := &cobra.Command{
 		Use:   "use-context",
 		Short: "Use an existing context for postmanctl commands.",
 		Args:  cobra.MinimumNArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			cfg = &config.Config{}
-			if err := viper.Unmarshal(cfg); err != nil {
-				fmt.Fprintf(os.Stderr, "error: %s\n", err)
-				os.Exit(1)
+		Annotations: map[string]string{
+			configOptionalAnnotation: "true",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := &config.Config{}
+			if err := o.viper.Unmarshal(cfg); err != nil {
+				return err
 			}
 
 			cfg.CurrentContext = args[0]
 
-			mergeConfig(cfg)
+			return o.mergeConfig(cfg)
 		},
 	}
 
@@ -98,7 +104,7 @@ func init() {
 		Use:   "current-context",
 		Short: "Get the currently set context for postmanctl commands.",
 		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Println(cfg.CurrentContext)
+			fmt.Fprintln(o.Out, o.cfg.CurrentContext)
 		},
 	}
 
@@ -106,11 +112,11 @@ func init() {
 		Use:   "get-contexts",
 		Short: "Shows the list of configured contexts.",
 		Run: func(cmd *cobra.Command, args []string) {
-			w := printers.GetNewTabWriter(os.Stdout)
+			w := printers.GetNewTabWriter(o.Out)
 			fmt.Fprintln(w, "CURRENT\tNAME\tAPIROOT")
-			for k, v := range cfg.Contexts {
+			for k, v := range o.cfg.Contexts {
 				cur := ""
-				if k == cfg.CurrentContext {
+				if k == o.cfg.CurrentContext {
 					cur = "*"
 				}
 
@@ -122,53 +128,67 @@ func init() {
 	}
 
 	cmd.AddCommand(setContextCmd, useContextCmd, currentContextCmd, getContextsCmd)
-	rootCmd.AddCommand(cmd)
+
+	return cmd
 }
 
-func prepareConfig(cfg *config.Config, result *map[string]interface{}) {
+// readAPIKey reads an API key without echoing it from a terminal, or reads
+// a line of other input.
+func (o *rootOptions) readAPIKey() (string, error) {
+	if isTerminal(o.In) {
+		b, err := terminal.ReadPassword(int(o.In.(*os.File).Fd()))
+		return string(b), err
+	}
+
+	line, err := bufio.NewReader(o.In).ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+
+	return strings.TrimSpace(line), nil
+}
+
+func prepareConfig(cfg *config.Config, result *map[string]interface{}) error {
 	if err := mapstructure.Decode(cfg, &result); err != nil {
-		fmt.Fprintf(os.Stderr, "error: %s\n", err)
-		os.Exit(1)
+		return err
 	}
 
 	contexts := make(map[string]interface{})
 	for k, v := range cfg.Contexts {
 		var vi map[string]interface{}
 		if err := mapstructure.Decode(v, &vi); err != nil {
-			fmt.Fprintf(os.Stderr, "error: %s\n", err)
-			os.Exit(1)
+			return err
 		}
 		contexts[k] = vi
 	}
 
 	(*result)["contexts"] = contexts
+
+	return nil
 }
 
-func mergeConfig(cfg *config.Config) {
+func (o *rootOptions) mergeConfig(cfg *config.Config) error {
 	var result map[string]interface{}
-	prepareConfig(cfg, &result)
+	if err := prepareConfig(cfg, &result); err != nil {
+		return err
+	}
 
-	if err := viper.MergeConfigMap(result); err != nil {
-		fmt.Fprintf(os.Stderr, "error: %s\n", err)
-		os.Exit(1)
+	if err := o.viper.MergeConfigMap(result); err != nil {
+		return err
 	}
 
-	viper.SetConfigType("yaml")
+	o.viper.SetConfigType("yaml")
 
-	if err := viper.WriteConfig(); err != nil {
-		if err != nil {
-			switch err.(type) {
-			case viper.ConfigFileNotFoundError:
-				if err := viper.SafeWriteConfig(); err != nil {
-					fmt.Fprintf(os.Stderr, "error: %s\n", err)
-					os.Exit(1)
-				}
-			default:
-				fmt.Fprintf(os.Stderr, "error: %s\n", err)
-				os.Exit(1)
-			}
+	if err := o.viper.WriteConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return err
+		}
+		if err := o.viper.SafeWriteConfig(); err != nil {
+			return err
 		}
 	}
 
-	fmt.Println("config file written to $HOME/.postmanctl.yaml")
+	fmt.Fprintln(o.Out, "config file written to $HOME/.postmanctl.yaml")
+
+	return nil
 }
@@ -0,0 +1,437 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// convertOptions are the options of the convert command.
+type convertOptions struct {
+	*rootOptions
+	to      string
+	output  string
+	groupBy string
+	name    string
+
+	threads      int
+	duration     time.Duration
+	rampUp       time.Duration
+	environments []string
+	dataFile     string
+}
+
+func newConvertCommand(root *rootOptions) *cobra.Command {
+	o := &convertOptions{rootOptions: root}
+
+	convertCmd := &cobra.Command{
+		Use:   "convert",
+		Short: "Convert Postman resources to and from other formats.",
+		Annotations: map[string]string{
+			configOptionalAnnotation: "true",
+		},
+	}
+
+	convertCollectionCmd := &cobra.Command{
+		Use:     "collection <id|name|file>",
+		Aliases: []string{"co"},
+		Short:   "Convert a collection (values for --to: openapi3, har, jmeter, k6, v2.1)",
+		Long: `Convert a collection to another format.
+
+Files in Collection Format v1 are read as v2.1. Use --to v2.1 to upgrade
+them, keeping the order of folders and requests, saved responses, scripts
+and auth.
+
+The jmeter and k6 targets produce load tests. Saved examples become status
+code assertions. Environments given with --environment are written to a CSV
+data set, with one row per environment, which the test cycles through.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := o.loadCollection(args[0])
+			if err != nil {
+				return err
+			}
+
+			var out interface{}
+			switch o.to {
+			case "openapi3":
+				out, err = convert.CollectionToOpenAPI3(c)
+			case "har":
+				out, err = toHAR(convert.CollectionToHAR(c))
+			case "jmeter", "k6":
+				return o.convertLoadTest(c)
+			case "v2.1":
+				out = c.Collection
+			default:
+				return fmt.Errorf("unsupported conversion target: %s", o.to)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			return o.printConverted(out)
+		},
+	}
+
+	convertCollectionCmd.Flags().StringVar(&o.to, "to", "", "the target format (required)")
+	convertCollectionCmd.MarkFlagRequired("to")
+	convertCollectionCmd.Flags().IntVar(&o.threads, "threads", 1, "the number of JMeter threads or k6 virtual users")
+	convertCollectionCmd.Flags().DurationVar(&o.duration, "duration", 0, "how long the load test runs (default is one iteration per thread)")
+	convertCollectionCmd.Flags().DurationVar(&o.rampUp, "ramp-up", 0, "the time taken to start all threads")
+	convertCollectionCmd.Flags().StringSliceVar(&o.environments, "environment", nil, "environment IDs, names or files for the load test data set")
+	convertCollectionCmd.Flags().StringVar(&o.dataFile, "data-file", "data.csv", "the CSV data set written for --environment")
+
+	convertOpenAPICmd := &cobra.Command{
+		Use:     "openapi <file|schema-id>",
+		Aliases: []string{"oas", "swagger"},
+		Short:   "Convert an OpenAPI 3 or Swagger 2.0 definition (values for --to: collection)",
+		Long: `Convert an OpenAPI 3 or Swagger 2.0 definition in JSON or YAML.
+
+Reads a local file, or stdin when the file is "-". With --for-api and
+--for-api-version, the argument is a schema ID in the Postman API.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			doc, err := o.loadOpenAPI(args[0])
+			if err != nil {
+				return err
+			}
+
+			var out interface{}
+			switch o.to {
+			case "collection":
+				out, err = convert.OpenAPIToCollection(doc)
+			default:
+				return fmt.Errorf("unsupported conversion target: %s", o.to)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			return o.printConverted(out)
+		},
+	}
+
+	convertOpenAPICmd.Flags().StringVar(&o.to, "to", "", "the target format (required)")
+	convertOpenAPICmd.MarkFlagRequired("to")
+	convertOpenAPICmd.Flags().StringVar(&o.forAPI, "for-api", "", "the associated API ID")
+	convertOpenAPICmd.Flags().StringVar(&o.forAPIVersion, "for-api-version", "", "the associated API Version ID")
+
+	convertHARCmd := &cobra.Command{
+		Use:   "har <file>",
+		Short: "Convert an HTTP Archive (values for --to: collection)",
+		Long: `Convert an HTTP Archive (HAR) captured by a browser or proxy.
+
+Reads stdin when the file is "-". Recorded responses are saved as examples.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var h *convert.HAR
+			err := o.readFileArg(args[0], func(r io.Reader) (err error) {
+				h, err = convert.ReadHAR(r)
+				return
+			})
+			if err != nil {
+				return err
+			}
+
+			var out interface{}
+			switch o.to {
+			case "collection":
+				switch g := convert.HARGrouping(o.groupBy); g {
+				case convert.GroupByNone, convert.GroupByHost, convert.GroupByPath:
+					out, err = convert.HARToCollection(h, o.name, g)
+				default:
+					return fmt.Errorf("group-by must be host or path")
+				}
+			default:
+				return fmt.Errorf("unsupported conversion target: %s", o.to)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			return o.printConverted(out)
+		},
+	}
+
+	convertHARCmd.Flags().StringVar(&o.to, "to", "", "the target format (required)")
+	convertHARCmd.MarkFlagRequired("to")
+	convertHARCmd.Flags().StringVar(&o.groupBy, "group-by", "", "group requests into folders (host, path)")
+	convertHARCmd.Flags().StringVar(&o.name, "name", "", "the name of the collection")
+
+	convertMonitorRunCmd := &cobra.Command{
+		Use:   "monitor-run <file>",
+		Short: "Convert the result of a monitor run (values for --to: har)",
+		Long: `Convert the result of "postmanctl run monitor".
+
+Reads stdin when the file is "-".`,
+		Example: "  postmanctl run monitor <id> | postmanctl convert monitor-run - --to har",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var b []byte
+			err := o.readFileArg(args[0], func(r io.Reader) (err error) {
+				b, err = ioutil.ReadAll(r)
+				return
+			})
+			if err != nil {
+				return err
+			}
+
+			switch o.to {
+			case "har":
+				h, err := toHAR(convert.MonitorRunToHAR(b))
+				if err != nil {
+					return err
+				}
+				return o.printConverted(h)
+			}
+
+			return fmt.Errorf("unsupported conversion target: %s", o.to)
+		},
+	}
+
+	convertMonitorRunCmd.Flags().StringVar(&o.to, "to", "", "the target format (required)")
+	convertMonitorRunCmd.MarkFlagRequired("to")
+
+	convertCmd.PersistentFlags().StringVarP(&o.output, "output", "o", "json", "output format (json, yaml)")
+	convertCmd.AddCommand(convertCollectionCmd, convertOpenAPICmd, convertHARCmd, convertMonitorRunCmd)
+
+	return convertCmd
+}
+
+// loadCollection reads a collection from a local file, or from the Postman
+// API when no such file exists. "-" reads from stdin.
+func (o *rootOptions) loadCollection(arg string) (*resources.Collection, error) {
+	if arg == "-" {
+		return convert.ReadCollection(o.In)
+	}
+
+	if f, err := os.Open(arg); err == nil {
+		defer f.Close()
+		return convert.ReadCollection(f)
+	}
+
+	if err := o.checkConfig(); err != nil {
+		return nil, err
+	}
+
+	id, err := o.resolveType(resources.CollectionType, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.service.Collection(context.Background(), id)
+}
+
+func (o *convertOptions) convertLoadTest(c *resources.Collection) error {
+	opts := convert.LoadTestOptions{
+		Threads:  o.threads,
+		Duration: o.duration,
+		RampUp:   o.rampUp,
+	}
+
+	if len(o.environments) > 0 {
+		envs := make([]*resources.Environment, len(o.environments))
+		for i, arg := range o.environments {
+			env, err := o.loadEnvironment(arg)
+			if err != nil {
+				return err
+			}
+			envs[i] = env
+		}
+
+		data := convert.EnvironmentsDataSet(envs)
+		f, err := os.Create(o.dataFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		if err := data.WriteCSV(f); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.ErrOut, "data set written to %s\n", o.dataFile)
+
+		opts.DataFile = o.dataFile
+		opts.DataColumns = data.Columns
+	}
+
+	var (
+		b   []byte
+		err error
+	)
+	if o.to == "jmeter" {
+		b, err = convert.CollectionToJMeter(c, opts)
+	} else {
+		b, err = convert.CollectionToK6(c, opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = o.Out.Write(b)
+
+	return err
+}
+
+// loadEnvironment reads an environment from a local file, or from the
+// Postman API when no such file exists.
+func (o *rootOptions) loadEnvironment(arg string) (*resources.Environment, error) {
+	if b, err := ioutil.ReadFile(arg); err == nil {
+		var envelope struct {
+			Environment *resources.Environment `json:"environment"`
+		}
+		if err := json.Unmarshal(b, &envelope); err != nil {
+			return nil, err
+		}
+		if envelope.Environment != nil {
+			return envelope.Environment, nil
+		}
+
+		var env resources.Environment
+		if err := json.Unmarshal(b, &env); err != nil {
+			return nil, err
+		}
+		return &env, nil
+	}
+
+	if err := o.checkConfig(); err != nil {
+		return nil, err
+	}
+
+	id, err := o.resolveType(resources.EnvironmentType, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.service.Environment(context.Background(), id)
+}
+
+// loadOpenAPI reads an API definition from a local file, or from a schema
+// in the Postman API when --for-api is set. "-" reads from stdin.
+func (o *rootOptions) loadOpenAPI(arg string) (*convert.OpenAPI, error) {
+	if o.forAPI == "" {
+		var doc *convert.OpenAPI
+		err := o.readFileArg(arg, func(r io.Reader) (err error) {
+			doc, err = convert.ReadOpenAPI(r)
+			return
+		})
+		return doc, err
+	}
+
+	if o.forAPIVersion == "" {
+		return nil, errors.New("flag \"for-api-version\" is required with \"for-api\"")
+	}
+
+	if err := o.checkConfig(); err != nil {
+		return nil, err
+	}
+	if err := o.resolveParentFlags(); err != nil {
+		return nil, err
+	}
+
+	s, err := o.service.Schema(context.Background(), o.forAPI, o.forAPIVersion, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return convert.ParseOpenAPI([]byte(s.Schema))
+}
+
+// readFileArg calls fn with the named file, or stdin when the name is "-".
+func (o *rootOptions) readFileArg(name string, fn func(io.Reader) error) error {
+	if name == "-" {
+		return fn(o.In)
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return fn(f)
+}
+
+// toHAR stamps the creator version on a converted archive.
+func toHAR(h *convert.HAR, err error) (*convert.HAR, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	h.Log.Creator.Version = version
+
+	return h, nil
+}
+
+func (o *convertOptions) printConverted(v interface{}) error {
+	var (
+		b   []byte
+		err error
+	)
+
+	// Generated collection types write null for unset fields, which the
+	// collection schema does not allow.
+	if c, ok := v.(*gen.Collection); ok {
+		if v, err = toMap(c); err != nil {
+			return err
+		}
+	}
+
+	switch o.output {
+	case "json":
+		// Keep Postman's <type> example placeholders readable.
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(v)
+		b = bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+	case "yaml":
+		// Keys and omitted fields follow the JSON tags of converted types.
+		var m map[string]interface{}
+		if m, err = toMap(v); err == nil {
+			b, err = yaml.Marshal(m)
+		}
+	default:
+		return fmt.Errorf("output format must be json or yaml")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(o.Out, string(b))
+
+	return nil
+}
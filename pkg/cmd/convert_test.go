@@ -0,0 +1,64 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConvertHARYAML(t *testing.T) {
+	dir, err := ioutil.TempDir("", "postmanctl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfgFile := writeConfig(t, dir)
+
+	path := filepath.Join(dir, "collection.json")
+	collection := `{
+		"info": {"name": "Test", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"item": [{
+			"name": "Get user",
+			"request": {"method": "GET", "url": "https://api.example.com/users?expand=true"},
+			"response": [{"name": "OK", "code": 200, "status": "OK", "body": "{}",
+				"header": [{"key": "Content-Type", "value": "application/json"}]}]
+		}]
+	}`
+	if err := ioutil.WriteFile(path, []byte(collection), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, out, errOut := run(nil, "--config", cfgFile, "convert", "collection", path, "--to", "har", "-o", "yaml")
+	if code != 0 {
+		t.Fatalf("exit code is incorrect, have: %d, want: 0: %s", code, errOut)
+	}
+
+	for _, key := range []string{"startedDateTime:", "httpVersion:", "queryString:", "headersSize:", "mimeType:", "redirectURL:"} {
+		if !strings.Contains(out, key) {
+			t.Errorf("output is missing %s, have:\n%s", key, out)
+		}
+	}
+	for _, key := range []string{"starteddatetime:", "postdata:", "encoding:"} {
+		if strings.Contains(out, key) {
+			t.Errorf("output should not contain %s, have:\n%s", key, out)
+		}
+	}
+}
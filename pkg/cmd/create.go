@@ -0,0 +1,308 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// createOptions are the options of the create command.
+type createOptions struct {
+	*rootOptions
+	inputFile   string
+	inputReader io.Reader
+	validate    bool
+
+	fromDotenv      string
+	environmentName string
+	secretKeys      []string
+	skipDisabled    bool
+
+	fromCurl    string
+	collection  string
+	folder      string
+	requestName string
+}
+
+func newCreateCommand(root *rootOptions) *cobra.Command {
+	o := &createOptions{rootOptions: root}
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create new Postman resources.",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if o.fromDotenv != "" {
+				// The resource is built from the dotenv file instead.
+				return o.preRun(cmd)
+			}
+
+			if !isTerminal(o.In) {
+				o.inputReader = o.In
+			} else {
+				if o.inputFile == "" {
+					return errors.New("flag \"filename\" not set, use \"--filename\" or stdin")
+				}
+			}
+
+			return o.preRun(cmd)
+		},
+	}
+	createCmd.PersistentFlags().StringVarP(&o.inputFile, "filename", "f", "", "the filename used to create the resource (required when not using data from stdin)")
+	createCmd.PersistentFlags().BoolVar(&o.validate, "validate", true, "validate collections against the collection schema before sending them")
+
+	requestCmd := &cobra.Command{
+		Use:     "request",
+		Aliases: []string{"req"},
+		Short:   "Add a request to a collection",
+		Long: `Add a request to a collection from a curl command line.
+
+Folders given with --folder are separated by slashes and created when missing.`,
+		Example: `  postmanctl create request --collection "Orders API" --folder "Orders/Admin" \
+    --from-curl 'curl -X POST https://api.example.com/orders -d "{\"id\": 1}"'`,
+		// Replaces the stdin and --filename check of the create command.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.checkConfig()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.createRequestFromCurl()
+		},
+	}
+	requestCmd.Flags().StringVar(&o.fromCurl, "from-curl", "", "the curl command line to create the request from (required)")
+	requestCmd.MarkFlagRequired("from-curl")
+	requestCmd.Flags().StringVar(&o.collection, "collection", "", "the collection ID or name (required)")
+	requestCmd.MarkFlagRequired("collection")
+	requestCmd.Flags().StringVar(&o.folder, "folder", "", "the folder path to add the request to")
+	requestCmd.Flags().StringVar(&o.requestName, "name", "", "the name of the request (default is the method and path)")
+
+	createCmd.AddCommand(requestCmd)
+	for _, info := range registeredWith(resources.VerbCreate) {
+		cmd := o.generateCreateSubcommand(info)
+		if info.Type == resources.EnvironmentType {
+			cmd.Example = `  postmanctl create environment -f staging.json
+  postmanctl create environment --from-dotenv .env --name staging --secret '*_TOKEN' --secret DB_PASSWORD`
+			cmd.Flags().StringVar(&o.fromDotenv, "from-dotenv", "", "create the environment from a dotenv file, or - for stdin")
+			cmd.Flags().StringVar(&o.environmentName, "name", "", "the name of the environment (required with --from-dotenv)")
+			cmd.Flags().StringSliceVar(&o.secretKeys, "secret", []string{}, "keys or patterns, such as '*_TOKEN', of values to mark as secret")
+			cmd.Flags().BoolVar(&o.skipDisabled, "skip-disabled", false, "skip commented out assignments instead of creating disabled values")
+		}
+		createCmd.AddCommand(cmd)
+	}
+
+	return createCmd
+}
+
+func (o *createOptions) generateCreateSubcommand(info *resources.ResourceInfo) *cobra.Command {
+	cmd := cobra.Command{
+		Use:     info.Name,
+		Aliases: info.Aliases,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.createResource(info)
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.usingWorkspace, "workspace", "w", "", "workspace for create operation")
+	o.addParentFlags(&cmd, info)
+
+	return &cmd
+}
+
+func (o *createOptions) createResource(info *resources.ResourceInfo) error {
+	if info.Type == resources.EnvironmentType && o.fromDotenv != "" {
+		r, err := o.readDotenvEnvironment()
+		if err != nil {
+			return err
+		}
+
+		o.inputReader = r
+	}
+
+	if o.inputReader == nil {
+		r, err := os.Open(o.inputFile)
+
+		if err != nil {
+			return err
+		}
+
+		defer r.Close()
+
+		o.inputReader = r
+	}
+
+	var queryParams map[string]string
+	if o.usingWorkspace != "" {
+		queryParams = map[string]string{"workspace": o.usingWorkspace}
+	}
+
+	o.service.SkipCollectionValidation = !o.validate
+
+	id, err := o.service.CreateFromReader(context.Background(), info.Type, o.inputReader, queryParams, o.parentParams(info))
+	if err != nil {
+		return err
+	}
+
+	o.forgetNames(info.Type)
+
+	fmt.Fprintln(o.Out, id)
+
+	return nil
+}
+
+// readDotenvEnvironment builds an environment from --from-dotenv.
+func (o *createOptions) readDotenvEnvironment() (io.Reader, error) {
+	if o.environmentName == "" {
+		return nil, errors.New("flag \"name\" not set, use \"--name\" with \"--from-dotenv\"")
+	}
+
+	r := o.In
+	if o.fromDotenv != "-" {
+		f, err := os.Open(o.fromDotenv)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		r = f
+	}
+
+	env, err := convert.DotenvToEnvironment(r, o.environmentName, convert.DotenvOptions{
+		SecretKeys:   o.secretKeys,
+		SkipDisabled: o.skipDisabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(struct {
+		Name   string                   `json:"name"`
+		Values []resources.KeyValuePair `json:"values"`
+	}{env.Name, env.Values})
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(b), nil
+}
+
+func (o *createOptions) createRequestFromCurl() error {
+	item, err := convert.CurlToItem(o.fromCurl, o.requestName)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	id, err := o.resolveType(resources.CollectionType, o.collection)
+	if err != nil {
+		return err
+	}
+
+	// The collection is changed as raw JSON, keeping the fields that
+	// resources.Collection does not model.
+	raw, err := o.service.GetRaw(ctx, resources.CollectionType, nil, id)
+	if err != nil {
+		return err
+	}
+
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	var collection map[string]interface{}
+	if err := d.Decode(&collection); err != nil {
+		return err
+	}
+
+	itemMap, err := toMap(item)
+	if err != nil {
+		return err
+	}
+
+	addItemToFolder(collection, o.folder, itemMap)
+
+	b, err := json.Marshal(collection)
+	if err != nil {
+		return err
+	}
+
+	o.service.SkipCollectionValidation = !o.validate
+
+	uid, err := o.service.ReplaceCollectionFromReader(ctx, bytes.NewReader(b), id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(o.Out, uid)
+
+	return nil
+}
+
+// toMap converts v to a generic map without null values.
+func toMap(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	return util.ReformatMap(m, true, nil), nil
+}
+
+// addItemToFolder appends item to the folder at path in a collection,
+// creating folders that do not exist.
+func addItemToFolder(collection map[string]interface{}, path string, item map[string]interface{}) {
+	parent := collection
+	for _, name := range strings.Split(path, "/") {
+		if name == "" {
+			continue
+		}
+
+		items, _ := parent["item"].([]interface{})
+
+		var folder map[string]interface{}
+		for _, i := range items {
+			m, ok := i.(map[string]interface{})
+			if _, isGroup := m["item"]; ok && isGroup && m["name"] == name {
+				folder = m
+				break
+			}
+		}
+
+		if folder == nil {
+			folder = map[string]interface{}{"name": name, "item": []interface{}{}}
+			parent["item"] = append(items, folder)
+		}
+
+		parent = folder
+	}
+
+	items, _ := parent["item"].([]interface{})
+	parent["item"] = append(items, item)
+}
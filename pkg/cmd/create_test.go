@@ -0,0 +1,131 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCreateRequestKeepsCollectionFields(t *testing.T) {
+	var body string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/collections", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"collections":[]}`)
+	})
+	mux.HandleFunc("/collections/c1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"collection":{
+				"info":{"name":"Orders","schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+				"protocolProfileBehavior":{"disableBodyPruning":true},
+				"x-custom":{"large":12345678901234567890},
+				"item":[{"name":"Admin","item":[]}]
+			}}`)
+		case http.MethodPut:
+			b, _ := ioutil.ReadAll(r.Body)
+			body = string(b)
+			fmt.Fprint(w, `{"collection":{"uid":"u-c1"}}`)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "postmanctl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfgFile := writeConfig(t, dir)
+
+	code, out, errOut := run(serverFactory(server), "--config", cfgFile, "--cache-ttl", "0",
+		"create", "request", "--collection", "c1", "--folder", "Admin", "--from-curl", "curl https://api.example.com/orders")
+	if code != 0 || out != "u-c1\n" {
+		t.Fatalf("result is incorrect, have: %d, %q, %q", code, out, errOut)
+	}
+
+	for _, want := range []string{`"protocolProfileBehavior":{"disableBodyPruning":true}`, `"x-custom":{"large":12345678901234567890}`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Replaced collection is missing %s, have: %s", want, body)
+		}
+	}
+
+	var replaced struct {
+		Collection struct {
+			Item []struct {
+				Name string
+				Item []struct{ Name string }
+			}
+		}
+	}
+	if err := json.Unmarshal([]byte(body), &replaced); err != nil {
+		t.Fatal(err)
+	}
+	items := replaced.Collection.Item
+	if len(items) != 1 || len(items[0].Item) != 1 || items[0].Item[0].Name != "GET /orders" {
+		t.Errorf("Request is not added to the folder, have: %s", body)
+	}
+}
+
+func TestCreateRequestValidatesCollection(t *testing.T) {
+	replaced := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/collections", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"collections":[]}`)
+	})
+	mux.HandleFunc("/collections/c1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"collection":{
+				"info":{"name":"Orders","schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+				"variable":"invalid",
+				"item":[]
+			}}`)
+		case http.MethodPut:
+			replaced = true
+			fmt.Fprint(w, `{"collection":{"uid":"u-c1"}}`)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "postmanctl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfgFile := writeConfig(t, dir)
+
+	args := []string{"--config", cfgFile, "--cache-ttl", "0",
+		"create", "request", "--collection", "c1", "--from-curl", "curl https://api.example.com/orders"}
+
+	code, _, errOut := run(serverFactory(server), args...)
+	if code != 7 || replaced {
+		t.Errorf("invalid collection is not rejected, have: %d, %q", code, errOut)
+	}
+
+	code, _, errOut = run(serverFactory(server), append(args, "--validate=false")...)
+	if code != 0 || !replaced {
+		t.Errorf("validation is not skipped, have: %d, %q", code, errOut)
+	}
+}
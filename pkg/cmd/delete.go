@@ -0,0 +1,395 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/names"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/trash"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+)
+
+// deleteOptions are the options of the delete command.
+type deleteOptions struct {
+	*rootOptions
+	yes      bool
+	forksOf  string
+	fromFile string
+	selector string
+}
+
+// deleteTarget is a resource to delete.
+type deleteTarget struct {
+	info   *resources.ResourceInfo
+	id     string
+	name   string
+	params map[string]string
+}
+
+func newDeleteCommand(root *rootOptions) *cobra.Command {
+	o := &deleteOptions{rootOptions: root}
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete [type/name...]",
+		Short: "Delete existing Postman resources.",
+		Long: `Delete existing Postman resources.
+
+Deletes by --selector, --all-forks-of or --filename, and deletes of more than
+one resource, are listed for confirmation, unless --yes is given. Each
+resource is saved to the trash in $HOME/.postmanctl/trash before it is
+deleted, and can be created again with: postmanctl restore <trash-id>`,
+		Example: `  postmanctl delete co/"Orders API" env/staging
+  postmanctl delete co,env --selector 'team=payments,env!=prod'
+  postmanctl delete collections --all-forks-of "Orders API" --yes
+  postmanctl delete -f unused.txt`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && o.fromFile == "" {
+				return cmd.Help()
+			}
+
+			var refs []resources.Ref
+			if len(args) > 0 {
+				var err error
+				if refs, err = parseRefs(args, resources.VerbDelete); err != nil {
+					return err
+				}
+			}
+
+			lines, err := o.readDeleteFile()
+			if err != nil {
+				return err
+			}
+			for _, l := range lines {
+				r, err := parseRefs([]string{l}, resources.VerbDelete)
+				if err != nil {
+					return fmt.Errorf("%s: %s", o.fromFile, err)
+				}
+				refs = append(refs, r...)
+			}
+
+			if o.selector != "" {
+				for _, r := range refs {
+					if r.Name != "" {
+						return &usageError{err: errors.New("names cannot be combined with --selector"), command: cmd.CommandPath()}
+					}
+				}
+			}
+
+			var targets []deleteTarget
+			for _, r := range refs {
+				if !r.Info.Supports(resources.VerbDelete) {
+					return fmt.Errorf("%s cannot be deleted", r.Info.Plural)
+				}
+
+				var t []deleteTarget
+				if r.Name == "" {
+					if o.selector == "" {
+						return fmt.Errorf("a name or --selector is required to delete %s", r.Info.Plural)
+					}
+					t, err = o.selectedTargets(r.Info, "")
+				} else {
+					t, err = o.namedTargets(r.Info, []string{r.Name})
+				}
+				if err != nil {
+					return err
+				}
+				targets = append(targets, t...)
+			}
+
+			return o.deleteTargets(targets)
+		},
+	}
+
+	for _, info := range registeredWith(resources.VerbDelete) {
+		deleteCmd.AddCommand(o.generateDeleteSubcommand(info))
+	}
+
+	deleteCmd.PersistentFlags().StringVarP(&o.selector, "selector", "l", "", "delete the resources matching a selector, such as 'team=payments,env!=prod'")
+	deleteCmd.PersistentFlags().StringVarP(&o.fromFile, "filename", "f", "", "a file listing the resources to delete, one per line")
+	deleteCmd.PersistentFlags().BoolVarP(&o.yes, "yes", "y", false, "delete without asking for confirmation")
+
+	return deleteCmd
+}
+
+func (o *deleteOptions) generateDeleteSubcommand(info *resources.ResourceInfo) *cobra.Command {
+	cmd := cobra.Command{
+		Use:     info.Name,
+		Aliases: append([]string{info.Plural}, info.Aliases...),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lines, err := o.readDeleteFile()
+			if err != nil {
+				return err
+			}
+
+			names := append(args, lines...)
+			if o.selector != "" && len(names) > 0 {
+				return &usageError{err: errors.New("names cannot be combined with --selector"), command: cmd.CommandPath()}
+			}
+
+			targets, err := o.namedTargets(info, names)
+			if err != nil {
+				return err
+			}
+
+			if o.selector != "" || o.forksOf != "" {
+				t, err := o.selectedTargets(info, o.forksOf)
+				if err != nil {
+					return err
+				}
+				targets = append(targets, t...)
+			} else if len(targets) == 0 {
+				return errors.New("names, --filename or --selector are required")
+			}
+
+			return o.deleteTargets(targets)
+		},
+	}
+
+	o.addParentFlags(&cmd, info)
+	if info.Type == resources.CollectionType {
+		cmd.Flags().StringVar(&o.forksOf, "all-forks-of", "", "delete the forks of a collection, given by ID or name")
+	}
+
+	return &cmd
+}
+
+// readDeleteFile returns the lines of --filename, without blank lines and
+// # comments.
+func (o *deleteOptions) readDeleteFile() ([]string, error) {
+	if o.fromFile == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(o.fromFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		l := strings.TrimSpace(scanner.Text())
+		if l != "" && !strings.HasPrefix(l, "#") {
+			lines = append(lines, l)
+		}
+	}
+
+	return lines, scanner.Err()
+}
+
+// namedTargets returns the resources with IDs or names.
+func (o *deleteOptions) namedTargets(info *resources.ResourceInfo, args []string) ([]deleteTarget, error) {
+	targets := make([]deleteTarget, len(args))
+	for i, name := range args {
+		id, err := o.resolveName(info, name)
+		if err != nil {
+			return nil, err
+		}
+
+		targets[i] = deleteTarget{info: info, id: id, name: name, params: o.parentParams(info)}
+	}
+
+	return targets, nil
+}
+
+// selectedTargets returns the resources matching --selector and, when
+// forksOf is set, forked from that collection.
+func (o *deleteOptions) selectedTargets(info *resources.ResourceInfo, forksOf string) ([]deleteTarget, error) {
+	r, err := o.service.List(context.Background(), info.Type, o.listParams(info), o.parentParams(info))
+	if err != nil {
+		return nil, err
+	}
+
+	if forksOf != "" {
+		source, err := o.resolveName(info, forksOf)
+		if err != nil {
+			return nil, err
+		}
+
+		forks := resources.CollectionListItems{}
+		for _, c := range *r.(*resources.CollectionListItems) {
+			// The source is a UID, which is the ID prefixed with the owner.
+			if c.Fork != nil && (c.Fork.From == source || strings.HasSuffix(source, "-"+c.Fork.From)) {
+				forks = append(forks, c)
+			}
+		}
+		r = &forks
+	}
+
+	if r, err = o.selectResources(info, o.selector, r); err != nil {
+		return nil, err
+	}
+
+	ix, err := names.NewIndex(r)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]deleteTarget, len(ix.Entries))
+	for i, e := range ix.Entries {
+		targets[i] = deleteTarget{info: info, id: e.ID, name: e.Name, params: o.parentParams(info)}
+	}
+
+	return targets, nil
+}
+
+// deleteTargets asks for confirmation of bulk deletes, then saves each
+// resource to the trash and deletes it.
+func (o *deleteOptions) deleteTargets(targets []deleteTarget) error {
+	seen := make(map[string]bool)
+	unique := targets[:0]
+	for _, t := range targets {
+		if key := t.info.Name + "/" + t.id; !seen[key] {
+			seen[key] = true
+			unique = append(unique, t)
+		}
+	}
+	targets = unique
+
+	if len(targets) == 0 {
+		fmt.Fprintln(o.ErrOut, "no resources to delete")
+		return nil
+	}
+
+	if !o.yes && o.isBulk(targets) {
+		ok, err := o.confirmDelete(targets)
+		if err != nil || !ok {
+			return err
+		}
+	}
+
+	bin, err := o.trashBin()
+	if err != nil {
+		return err
+	}
+
+	for _, t := range targets {
+		if err := o.deleteResource(bin, t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isBulk reports whether targets were selected rather than named, or are
+// more than one resource. Those deletes are confirmed.
+func (o *deleteOptions) isBulk(targets []deleteTarget) bool {
+	return o.selector != "" || o.forksOf != "" || o.fromFile != "" || len(targets) > 1
+}
+
+// confirmDelete lists the targets with counts by type and asks whether to
+// go on.
+func (o *deleteOptions) confirmDelete(targets []deleteTarget) (bool, error) {
+	if !isTerminal(o.In) {
+		return false, errors.New("refusing to delete without confirmation from a terminal, use --yes")
+	}
+
+	var (
+		order  []*resources.ResourceInfo
+		counts = make(map[*resources.ResourceInfo]int)
+	)
+	fmt.Fprintln(o.ErrOut, "The following resources will be deleted:")
+	for _, t := range targets {
+		if counts[t.info] == 0 {
+			order = append(order, t.info)
+		}
+		counts[t.info]++
+		fmt.Fprintf(o.ErrOut, "  %s/%s (%s)\n", t.info.Name, t.name, t.id)
+	}
+
+	summary := make([]string, len(order))
+	for i, info := range order {
+		kind := info.Plural
+		if counts[info] == 1 {
+			kind = info.Name
+		}
+		summary[i] = fmt.Sprintf("%d %s", counts[info], kind)
+	}
+
+	fmt.Fprintf(o.ErrOut, "Delete %s? (y/N): ", strings.Join(summary, ", "))
+	var confirm string
+	fmt.Fscanln(o.In, &confirm)
+	if strings.ToLower(confirm) != "y" {
+		fmt.Fprintln(o.ErrOut, "nothing was deleted")
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// trashBin returns the trash of the current context.
+func (o *rootOptions) trashBin() (*trash.Bin, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	return &trash.Bin{Dir: filepath.Join(home, ".postmanctl", "trash", o.configContextKey)}, nil
+}
+
+func (o *deleteOptions) deleteResource(bin *trash.Bin, t deleteTarget) error {
+	ctx := context.Background()
+
+	raw, err := o.service.GetRaw(ctx, t.info.Type, t.params, t.id)
+	if err != nil {
+		return err
+	}
+
+	entry := &trash.Entry{Type: t.info.Name, ResourceID: t.id, Name: t.name, Params: t.params, Resource: raw}
+	if m, err := toMap(raw); err == nil {
+		if name := cast.ToString(m["name"]); name != "" {
+			entry.Name = name
+		} else if info, ok := m["info"].(map[string]interface{}); ok {
+			entry.Name = cast.ToString(info["name"])
+		}
+	}
+
+	if err := bin.Put(entry); err != nil {
+		return fmt.Errorf("unable to save %s/%s to the trash, nothing was deleted: %s", t.info.Name, t.name, err)
+	}
+
+	urlParams := make(map[string]string)
+	for k, v := range t.params {
+		urlParams[k] = v
+	}
+	urlParams["ID"] = t.id
+
+	id, err := o.service.Delete(ctx, t.info.Type, urlParams)
+	if err != nil {
+		bin.Remove(entry.ID)
+		return err
+	}
+
+	o.forgetNames(t.info.Type)
+
+	fmt.Fprintln(o.Out, id)
+	fmt.Fprintf(o.ErrOut, "saved to the trash, restore with: postmanctl restore %s\n", entry.ID)
+
+	return nil
+}
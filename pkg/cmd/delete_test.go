@@ -0,0 +1,91 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+func TestDeleteConfirmation(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		deleted []string
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/environments", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"environments":[{"id":"e1","uid":"u-e1","name":"staging"},{"id":"e2","uid":"u-e2","name":"qa"}]}`)
+	})
+	mux.HandleFunc("/environments/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/environments/")
+		if r.Method == http.MethodDelete {
+			mu.Lock()
+			deleted = append(deleted, id)
+			mu.Unlock()
+		}
+		fmt.Fprintf(w, `{"environment":{"id":%q,"name":"env-%s","values":[]}}`, id, id)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "postmanctl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfgFile := writeConfig(t, dir)
+
+	// The trash is kept in the home directory.
+	home := os.Getenv("HOME")
+	os.Setenv("HOME", dir)
+	homedir.DisableCache = true
+	defer func() {
+		os.Setenv("HOME", home)
+		homedir.DisableCache = false
+	}()
+
+	tests := []struct {
+		args    []string
+		code    int
+		deleted []string
+	}{
+		{[]string{"env/staging"}, 0, []string{"u-e1"}},
+		{[]string{"environment", "qa"}, 0, []string{"u-e2"}},
+		{[]string{"environments", "staging", "qa"}, 1, nil},
+		{[]string{"environments", "-l", "name=qa"}, 1, nil},
+		{[]string{"environments", "staging", "-l", "name=qa"}, 2, nil},
+		{[]string{"env/staging", "-l", "name=qa"}, 2, nil},
+		{[]string{"environments", "staging", "qa", "--yes"}, 0, []string{"u-e1", "u-e2"}},
+	}
+
+	for _, tt := range tests {
+		deleted = nil
+		args := append([]string{"--config", cfgFile, "--cache-ttl", "0", "delete"}, tt.args...)
+		code, _, errOut := run(serverFactory(server), args...)
+		if code != tt.code || strings.Join(deleted, ",") != strings.Join(tt.deleted, ",") {
+			t.Errorf("result of %v is incorrect, have: %d, %v, %q, want: %d, %v", tt.args, code, deleted, errOut, tt.code, tt.deleted)
+		}
+	}
+}
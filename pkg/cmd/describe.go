@@ -22,34 +22,54 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
+	"github.com/kevinswiber/postmanctl/pkg/sdk"
 	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/variables"
 	"github.com/spf13/cobra"
 	"github.com/xlab/treeprint"
 )
 
-func init() {
+// describeOptions are the options of the describe command.
+type describeOptions struct {
+	*rootOptions
+	environment string
+	concurrency int
+}
+
+func newDescribeCommand(root *rootOptions) *cobra.Command {
+	o := &describeOptions{rootOptions: root}
+
 	describeCmd := &cobra.Command{
-		Use:   "describe",
-		Short: "Describe an entity in the Postman API",
+		Use:     "describe [type/name...]",
+		Short:   "Describe an entity in the Postman API",
+		Example: `  postmanctl describe co/"Orders API" env/staging`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return cmd.Help()
+			}
+
+			return o.describeRefs(args)
+		},
 	}
 
 	userCmd := &cobra.Command{
 		Use: "user",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			resource, err := service.User(context.Background())
+			resource, err := o.service.User(context.Background())
 
 			if err != nil {
-				return handleResponseError(err)
+				return err
 			}
 
 			out, err := describeUser(resource)
 			if err != nil {
 				return err
 			}
-			fmt.Println(out)
+			fmt.Fprintln(o.Out, out)
 
 			return nil
 		},
@@ -59,239 +79,324 @@ func init() {
 		Use:     "api-versions",
 		Aliases: []string{"api-version"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return fetchAPIVersions(args)
+			return o.fetchAPIVersions(args)
 		},
 	}
 
-	apiVersionsCmd.Flags().StringVar(&forAPI, "for-api", "", "the associated API ID (required)")
+	apiVersionsCmd.Flags().StringVar(&o.forAPI, "for-api", "", "the associated API ID (required)")
 	apiVersionsCmd.MarkFlagRequired("for-api")
 
 	apiRelationsCmd := &cobra.Command{
 		Use: "api-relations",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return fetchAPIRelations(args)
+			return o.fetchAPIRelations(args)
 		},
 	}
 
-	apiRelationsCmd.Flags().StringVar(&forAPI, "for-api", "", "the associated API ID (required)")
+	apiRelationsCmd.Flags().StringVar(&o.forAPI, "for-api", "", "the associated API ID (required)")
 	apiRelationsCmd.MarkFlagRequired("for-api")
 
-	apiRelationsCmd.Flags().StringVar(&forAPIVersion, "for-api-version", "", "the associated API Version ID (required)")
+	apiRelationsCmd.Flags().StringVar(&o.forAPIVersion, "for-api-version", "", "the associated API Version ID (required)")
 	apiRelationsCmd.MarkFlagRequired("for-api-version")
 
 	schemaCmd := &cobra.Command{
 		Use: "schema",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return fetchSchema(args)
+			return o.fetchSchema(args)
 		},
 	}
 
-	schemaCmd.Flags().StringVar(&forAPI, "for-api", "", "the associated API ID (required)")
+	schemaCmd.Flags().StringVar(&o.forAPI, "for-api", "", "the associated API ID (required)")
 	schemaCmd.MarkFlagRequired("for-api")
 
-	schemaCmd.Flags().StringVar(&forAPIVersion, "for-api-version", "", "the associated API Version ID (required)")
+	schemaCmd.Flags().StringVar(&o.forAPIVersion, "for-api-version", "", "the associated API Version ID (required)")
 	schemaCmd.MarkFlagRequired("for-api-version")
 
+	collectionsCmd := o.generateDescribeSubcommand(resources.CollectionType, "collections", []string{"collection", "co"}, o.fetchCollections)
+	collectionsCmd.Flags().StringVar(&o.environment, "environment", "", "the environment ID or name used to report unresolved variables")
+
 	describeCmd.AddCommand(
-		generateDescribeSubcommand("collections", []string{"collection", "co"}, fetchCollections),
-		generateDescribeSubcommand("environments", []string{"environment", "env"}, fetchEnvironments),
-		generateDescribeSubcommand("monitors", []string{"monitor", "mon"}, fetchMonitors),
-		generateDescribeSubcommand("mocks", []string{"mock"}, fetchMocks),
-		generateDescribeSubcommand("workspaces", []string{"workspace", "ws"}, fetchWorkspaces),
+		collectionsCmd,
+		o.generateDescribeSubcommand(resources.EnvironmentType, "environments", []string{"environment", "env"}, o.fetchEnvironments),
+		o.generateDescribeSubcommand(resources.MonitorType, "monitors", []string{"monitor", "mon"}, o.fetchMonitors),
+		o.generateDescribeSubcommand(resources.MockType, "mocks", []string{"mock"}, o.fetchMocks),
+		o.generateDescribeSubcommand(resources.WorkspaceType, "workspaces", []string{"workspace", "ws"}, o.fetchWorkspaces),
 		userCmd,
-		generateDescribeSubcommand("apis", []string{"api"}, fetchAPIs),
+		o.generateDescribeSubcommand(resources.APIType, "apis", []string{"api"}, o.fetchAPIs),
 		apiVersionsCmd,
 		apiRelationsCmd,
 		schemaCmd,
 	)
-	rootCmd.AddCommand(describeCmd)
+	describeCmd.PersistentFlags().IntVar(&o.concurrency, "concurrency", 4, "the number of resources fetched at the same time")
+
+	return describeCmd
 }
 
-func generateDescribeSubcommand(use string, aliases []string, fn func(args []string) error) *cobra.Command {
+// generateDescribeSubcommand returns a command describing resources of a
+// type given by ID or name. fn is called with their IDs.
+func (o *describeOptions) generateDescribeSubcommand(t resources.ResourceType, use string, aliases []string, fn func(ids []string) error) *cobra.Command {
 	return &cobra.Command{
 		Use:     use,
 		Aliases: aliases,
 		Args:    cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return fn(args)
+			info, _ := resources.Info(t)
+			ids, err := o.resolveNames(info, args)
+			if err != nil {
+				return err
+			}
+
+			return fn(ids)
 		},
 	}
 }
 
-func fetchCollections(args []string) error {
-	r := make(resources.CollectionSlice, len(args))
-	for i, id := range args {
-		resource, err := service.Collection(context.Background(), id)
+// fetchers returns the functions fetching and describing resources
+// addressed as type/name. The resources that could not be fetched are
+// returned as an sdk.BatchError.
+func (o *describeOptions) fetchers() map[resources.ResourceType]func(args []string) error {
+	return map[resources.ResourceType]func(args []string) error{
+		resources.CollectionType:  o.fetchCollections,
+		resources.EnvironmentType: o.fetchEnvironments,
+		resources.MockType:        o.fetchMocks,
+		resources.MonitorType:     o.fetchMonitors,
+		resources.WorkspaceType:   o.fetchWorkspaces,
+		resources.APIType:         o.fetchAPIs,
+	}
+}
+
+// batchOptions returns the options used to fetch several resources, as set
+// by --concurrency.
+func (o *describeOptions) batchOptions() *sdk.BatchOptions {
+	return &sdk.BatchOptions{Concurrency: o.concurrency}
+}
 
-		if err != nil {
-			return handleResponseError(err)
-		}
+func (o *describeOptions) describeRefs(args []string) error {
+	refs, err := parseRefs(args, resources.VerbGet)
+	if err != nil {
+		return err
+	}
 
-		r[i] = resource
+	fetchers := o.fetchers()
+	for _, r := range refs {
+		if r.Name == "" {
+			return fmt.Errorf("a name is required to describe %s", r.Info.Plural)
+		}
+		if _, ok := fetchers[r.Info.Type]; !ok {
+			return fmt.Errorf("%s cannot be described", r.Info.Plural)
+		}
 	}
 
-	out, err := describeCollections(r)
+	ids, err := o.refIDs(refs)
 	if err != nil {
 		return err
 	}
 
-	fmt.Println(out)
+	var (
+		order  []resources.ResourceType
+		byType = make(map[resources.ResourceType][]string)
+	)
+	for i, id := range ids {
+		t := refs[i].Info.Type
+		if byType[t] == nil {
+			order = append(order, t)
+		}
+		byType[t] = append(byType[t], id)
+	}
+
+	var errs sdk.BatchError
+	for _, t := range order {
+		err := fetchers[t](byType[t])
+		if batchErr, ok := err.(sdk.BatchError); ok {
+			errs = append(errs, batchErr...)
+		} else if err != nil {
+			return err
+		}
+	}
+	if errs != nil {
+		return errs
+	}
 
 	return nil
 }
 
-func fetchEnvironments(args []string) error {
-	r := make(resources.EnvironmentSlice, len(args))
-	for i, id := range args {
-		resource, err := service.Environment(context.Background(), id)
+func (o *describeOptions) fetchCollections(args []string) error {
+	all, batchErr := o.service.CollectionsByID(context.Background(), args, o.batchOptions())
+	r := all[:0]
+	for _, resource := range all {
+		if resource != nil {
+			r = append(r, resource)
+		}
+	}
 
+	var env *resources.Environment
+	if o.environment != "" {
+		envID, err := o.resolveType(resources.EnvironmentType, o.environment)
 		if err != nil {
-			return handleResponseError(err)
+			return err
 		}
 
-		r[i] = resource
+		if env, err = o.service.Environment(context.Background(), envID); err != nil {
+			return err
+		}
 	}
 
-	out, err := describeEnvironments(r)
+	out, err := describeCollections(r, env)
 	if err != nil {
 		return err
 	}
 
-	fmt.Println(out)
+	if len(r) > 0 {
+		fmt.Fprintln(o.Out, out)
+	}
 
-	return nil
+	return batchErr
 }
 
-func fetchMocks(args []string) error {
-	r := make(resources.MockSlice, len(args))
-	for i, id := range args {
-		resource, err := service.Mock(context.Background(), id)
-
-		if err != nil {
-			return handleResponseError(err)
+func (o *describeOptions) fetchEnvironments(args []string) error {
+	all, batchErr := o.service.EnvironmentsByID(context.Background(), args, o.batchOptions())
+	r := all[:0]
+	for _, resource := range all {
+		if resource != nil {
+			r = append(r, resource)
 		}
+	}
 
-		r[i] = resource
+	out, err := describeEnvironments(r)
+	if err != nil {
+		return err
+	}
+
+	if len(r) > 0 {
+		fmt.Fprintln(o.Out, out)
+	}
+
+	return batchErr
+}
+
+func (o *describeOptions) fetchMocks(args []string) error {
+	all, batchErr := o.service.MocksByID(context.Background(), args, o.batchOptions())
+	r := all[:0]
+	for _, resource := range all {
+		if resource != nil {
+			r = append(r, resource)
+		}
 	}
 
 	out, err := describeMocks(r)
 	if err != nil {
 		return err
 	}
-	fmt.Println(out)
+	if len(r) > 0 {
+		fmt.Fprintln(o.Out, out)
+	}
 
-	return nil
+	return batchErr
 }
 
-func fetchMonitors(args []string) error {
-	r := make(resources.MonitorSlice, len(args))
-	for i, id := range args {
-		resource, err := service.Monitor(context.Background(), id)
-
-		if err != nil {
-			return handleResponseError(err)
+func (o *describeOptions) fetchMonitors(args []string) error {
+	all, batchErr := o.service.MonitorsByID(context.Background(), args, o.batchOptions())
+	r := all[:0]
+	for _, resource := range all {
+		if resource != nil {
+			r = append(r, resource)
 		}
-
-		r[i] = resource
 	}
 
 	out, err := describeMonitors(r)
 	if err != nil {
 		return err
 	}
-	fmt.Println(out)
+	if len(r) > 0 {
+		fmt.Fprintln(o.Out, out)
+	}
 
-	return nil
+	return batchErr
 }
 
-func fetchWorkspaces(args []string) error {
-	r := make(resources.WorkspaceSlice, len(args))
-	for i, id := range args {
-		resource, err := service.Workspace(context.Background(), id)
-
-		if err != nil {
-			return handleResponseError(err)
+func (o *describeOptions) fetchWorkspaces(args []string) error {
+	all, batchErr := o.service.WorkspacesByID(context.Background(), args, o.batchOptions())
+	r := all[:0]
+	for _, resource := range all {
+		if resource != nil {
+			r = append(r, resource)
 		}
-
-		r[i] = resource
 	}
 
 	out, err := describeWorkspaces(r)
 	if err != nil {
 		return err
 	}
-	fmt.Println(out)
+	if len(r) > 0 {
+		fmt.Fprintln(o.Out, out)
+	}
 
-	return nil
+	return batchErr
 }
 
-func fetchAPIs(args []string) error {
-	r := make(resources.APISlice, len(args))
-	for i, id := range args {
-		resource, err := service.API(context.Background(), id)
-
-		if err != nil {
-			return handleResponseError(err)
+func (o *describeOptions) fetchAPIs(args []string) error {
+	all, batchErr := o.service.APIsByID(context.Background(), args, o.batchOptions())
+	r := all[:0]
+	for _, resource := range all {
+		if resource != nil {
+			r = append(r, resource)
 		}
-
-		r[i] = resource
 	}
 
 	out, err := describeAPIs(r)
 	if err != nil {
 		return err
 	}
-	fmt.Println(out)
+	if len(r) > 0 {
+		fmt.Fprintln(o.Out, out)
+	}
 
-	return nil
+	return batchErr
 }
 
-func fetchAPIVersions(args []string) error {
-	ids := args[0:]
-
-	r := make(resources.APIVersionSlice, len(ids))
-	for i, id := range ids {
-		resource, err := service.APIVersion(context.Background(), forAPI, id)
-
-		if err != nil {
-			return handleResponseError(err)
+func (o *describeOptions) fetchAPIVersions(args []string) error {
+	all, batchErr := o.service.APIVersionsByID(context.Background(), o.forAPI, args, o.batchOptions())
+	r := all[:0]
+	for _, resource := range all {
+		if resource != nil {
+			r = append(r, resource)
 		}
-
-		r[i] = resource
 	}
 
 	out, err := describeAPIVersions(r)
 	if err != nil {
 		return err
 	}
-	fmt.Println(out)
+	if len(r) > 0 {
+		fmt.Fprintln(o.Out, out)
+	}
 
-	return nil
+	return batchErr
 }
 
-func fetchAPIRelations(args []string) error {
-	resource, err := service.APIRelations(context.Background(), forAPI, forAPIVersion)
+func (o *describeOptions) fetchAPIRelations(args []string) error {
+	resource, err := o.service.APIRelations(context.Background(), o.forAPI, o.forAPIVersion)
 
 	if err != nil {
-		return handleResponseError(err)
+		return err
 	}
 
 	out, err := describeAPIRelations(resource)
 	if err != nil {
 		return err
 	}
-	fmt.Println(out)
+	fmt.Fprintln(o.Out, out)
 
 	return nil
 }
 
-func fetchSchema(args []string) error {
+func (o *describeOptions) fetchSchema(args []string) error {
 	var id string
 	if len(args) == 0 {
-		version, err := service.APIVersion(context.Background(), forAPI, forAPIVersion)
+		version, err := o.service.APIVersion(context.Background(), o.forAPI, o.forAPIVersion)
 
 		if err != nil {
-			return handleResponseError(err)
+			return err
 		}
 
 		if len(version.Schema) > 0 {
@@ -301,22 +406,22 @@ func fetchSchema(args []string) error {
 		id = args[0]
 	}
 
-	resource, err := service.Schema(context.Background(), forAPI, forAPIVersion, id)
+	resource, err := o.service.Schema(context.Background(), o.forAPI, o.forAPIVersion, id)
 
 	if err != nil {
-		return handleResponseError(err)
+		return err
 	}
 
 	out, err := describeSchema(resource)
 	if err != nil {
 		return err
 	}
-	fmt.Println(out)
+	fmt.Fprintln(o.Out, out)
 
 	return nil
 }
 
-func describeCollections(r resources.CollectionSlice) (string, error) {
+func describeCollections(r resources.CollectionSlice, env *resources.Environment) (string, error) {
 	return tabbedString(func(out io.Writer) error {
 		for _, c := range r {
 			buf := new(bytes.Buffer)
@@ -343,12 +448,20 @@ func describeCollections(r resources.CollectionSlice) (string, error) {
 			buf.WriteString(fmt.Sprintf("  PreRequest:\t%t\n", hasPreRequest))
 			buf.WriteString(fmt.Sprintf("  Test:\t%t\n", hasTest))
 
-			var variables []string = make([]string, len(c.Variable))
+			var keys []string = make([]string, len(c.Variable))
 			for i, v := range c.Variable {
-				variables[i] = v.Key
+				keys[i] = v.Key
 			}
 
-			buf.WriteString(fmt.Sprintf("Variables:\t%s\n", strings.Join(variables, ", ")))
+			buf.WriteString(fmt.Sprintf("Variables:\t%s\n", strings.Join(keys, ", ")))
+
+			if env != nil {
+				resolver := variables.NewResolver()
+				resolver.SetCollection(c.Variable)
+				resolver.SetEnvironment(env)
+				unresolved := resolver.ResolveCollection(c)
+				buf.WriteString(fmt.Sprintf("Unresolved Variables:\t%s\n", strings.Join(unresolved, ", ")))
+			}
 
 			buf.WriteString(fmt.Sprintln("Items:"))
 			tree := treeprint.New()
@@ -398,7 +511,7 @@ func describeMonitors(r resources.MonitorSlice) (string, error) {
 			buf.WriteString(fmt.Sprintf("  Follow Redirects:\t%t\n", m.Options.FollowRedirects))
 			requestTimeout := "<default>"
 			if m.Options.RequestTimeout != nil {
-				requestTimeout = string(*m.Options.RequestTimeout)
+				requestTimeout = strconv.Itoa(*m.Options.RequestTimeout)
 			}
 			buf.WriteString(fmt.Sprintf("  Request Timeout:\t%s\n", requestTimeout))
 			buf.WriteString(fmt.Sprintf("  Request Delay:\t%d\n", m.Options.RequestDelay))
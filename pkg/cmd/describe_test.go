@@ -0,0 +1,66 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDescribeResolvesNames(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		fetched []string
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/environments", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"environments":[{"id":"e1","uid":"u-e1","name":"staging"}]}`)
+	})
+	mux.HandleFunc("/environments/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/environments/")
+		mu.Lock()
+		fetched = append(fetched, id)
+		mu.Unlock()
+		fmt.Fprintf(w, `{"environment":{"id":%q,"name":"staging","values":[]}}`, id)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "postmanctl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfgFile := writeConfig(t, dir)
+
+	for _, args := range [][]string{{"environment", "staging"}, {"env/staging"}} {
+		fetched = nil
+		code, _, errOut := run(serverFactory(server), append([]string{"--config", cfgFile, "--cache-ttl", "0", "describe"}, args...)...)
+		if code != 0 {
+			t.Errorf("exit code of %v is incorrect, have: %d, want: 0: %s", args, code, errOut)
+		}
+		if len(fetched) != 1 || fetched[0] != "u-e1" {
+			t.Errorf("fetched environments of %v are incorrect, have: %v, want: [u-e1]", args, fetched)
+		}
+	}
+}
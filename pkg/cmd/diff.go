@@ -0,0 +1,291 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+	"github.com/kevinswiber/postmanctl/pkg/diff"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// diffOptions are the options of the diff command.
+type diffOptions struct {
+	*rootOptions
+	from   string
+	to     string
+	output string
+}
+
+func newDiffCommand(root *rootOptions) *cobra.Command {
+	o := &diffOptions{rootOptions: root}
+
+	diffCmd := &cobra.Command{
+		Use:   "diff [type/name type/name]",
+		Short: "Compare versions of Postman resources.",
+		Long: `Compare versions of Postman resources.
+
+Two resources of the same type, given as type/name, are compared field by
+field, ignoring IDs, owners and timestamps. The command exits with status 8
+when they differ.`,
+		Example: `  postmanctl diff env/staging env/production
+  postmanctl diff co "Orders API" "Orders API (fork)"`,
+		Annotations: map[string]string{
+			configOptionalAnnotation: "true",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return cmd.Help()
+			}
+
+			return o.diffRefs(args)
+		},
+	}
+	diffCmd.Flags().StringVarP(&o.output, "output", "o", "text", "output format (text, json)")
+
+	diffSchemaCmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Report breaking changes between the schemas of two API versions",
+		Long: `Report the changes between the schemas of two API versions.
+
+Changes are classified as breaking when existing clients may stop working,
+such as removed paths and operations, new required parameters, narrowed
+types and enums, and removed response properties.
+
+With --for-api, --from and --to are API version IDs or names. Otherwise
+they are OpenAPI 3 or Swagger 2.0 files. The command exits with status 8
+when a breaking change is found.`,
+		Example: `  postmanctl diff schema --for-api <api-id> --from v1.0.0 --to v2.0.0
+  postmanctl diff schema --from ./v1.yaml --to ./v2.yaml -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.diffSchema()
+		},
+	}
+
+	diffSchemaCmd.Flags().StringVar(&o.forAPI, "for-api", "", "the associated API ID")
+	diffSchemaCmd.Flags().StringVar(&o.from, "from", "", "the earlier API version or schema file (required)")
+	diffSchemaCmd.MarkFlagRequired("from")
+	diffSchemaCmd.Flags().StringVar(&o.to, "to", "", "the later API version or schema file (required)")
+	diffSchemaCmd.MarkFlagRequired("to")
+	diffSchemaCmd.Flags().StringVarP(&o.output, "output", "o", "text", "output format (text, json)")
+
+	diffCmd.AddCommand(diffSchemaCmd)
+
+	return diffCmd
+}
+
+func (o *diffOptions) diffSchema() error {
+	from, err := o.loadVersionSchema(o.from)
+	if err != nil {
+		return err
+	}
+
+	to, err := o.loadVersionSchema(o.to)
+	if err != nil {
+		return err
+	}
+
+	changes := diff.Schemas(from, to)
+
+	switch o.output {
+	case "text":
+		if len(changes) == 0 {
+			fmt.Fprintln(o.Out, "no changes")
+			break
+		}
+
+		out, _ := tabbedString(func(out io.Writer) error {
+			fmt.Fprintln(out, "CHANGE\tLOCATION\tDESCRIPTION")
+			for _, c := range changes {
+				kind := "non-breaking"
+				if c.Breaking {
+					kind = "breaking"
+				}
+				fmt.Fprintf(out, "%s\t%s\t%s\n", kind, c.Location, c.Message)
+			}
+			return nil
+		})
+		fmt.Fprint(o.Out, out)
+	case "json":
+		v := changes
+		if v == nil {
+			v = []diff.Change{}
+		}
+
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.Out, string(b))
+	default:
+		return fmt.Errorf("unsupported output format: %s", o.output)
+	}
+
+	if diff.HasBreaking(changes) {
+		return &silentError{code: exitFindings}
+	}
+
+	return nil
+}
+
+// diffIgnoredKeys are the fields that differ between copies of a resource.
+var diffIgnoredKeys = map[string]int{
+	"id":          1,
+	"uid":         1,
+	"owner":       1,
+	"createdAt":   1,
+	"updatedAt":   1,
+	"_postman_id": 1,
+}
+
+func (o *diffOptions) diffRefs(args []string) error {
+	if err := o.checkConfig(); err != nil {
+		return err
+	}
+
+	refs, err := parseRefs(args, resources.VerbGet)
+	if err != nil {
+		return err
+	}
+
+	if len(refs) != 2 || refs[0].Name == "" || refs[1].Name == "" {
+		return errors.New("two resources are required, such as env/staging env/production")
+	}
+	if refs[0].Info != refs[1].Info {
+		return fmt.Errorf("cannot compare %s with %s", refs[0].Info.Plural, refs[1].Info.Plural)
+	}
+
+	ids, err := o.refIDs(refs)
+	if err != nil {
+		return err
+	}
+
+	var values [2]map[string]interface{}
+	for i, id := range ids {
+		r, err := o.service.Get(context.Background(), refs[i].Info.Type, nil, id)
+		if err != nil {
+			return err
+		}
+
+		b, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(b, &values[i]); err != nil {
+			return err
+		}
+		values[i] = util.ReformatMap(values[i], true, diffIgnoredKeys)
+	}
+
+	changes := util.CompareMap("", values[0], values[1])
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	for i := range changes {
+		changes[i].Path = strings.TrimPrefix(changes[i].Path, ".")
+	}
+
+	switch o.output {
+	case "text":
+		if len(changes) == 0 {
+			fmt.Fprintln(o.Out, "no changes")
+			break
+		}
+
+		out, _ := tabbedString(func(out io.Writer) error {
+			fmt.Fprintln(out, "PATH\tOLD\tNEW")
+			for _, c := range changes {
+				fmt.Fprintf(out, "%s\t%s\t%s\n", c.Path, diffValue(c.Old), diffValue(c.New))
+			}
+			return nil
+		})
+		fmt.Fprint(o.Out, out)
+	case "json":
+		b, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.Out, string(b))
+	default:
+		return fmt.Errorf("unsupported output format: %s", o.output)
+	}
+
+	if len(changes) > 0 {
+		return &silentError{code: exitFindings}
+	}
+
+	return nil
+}
+
+// diffValue formats a value of a diff table, with "-" for missing values.
+func diffValue(v interface{}) string {
+	if v == nil {
+		return "-"
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// loadVersionSchema reads the schema of an API version, given by ID or
+// name, when --for-api is set. Otherwise it reads a schema file.
+func (o *diffOptions) loadVersionSchema(arg string) (*convert.OpenAPI, error) {
+	if o.forAPI == "" {
+		return o.loadOpenAPI(arg)
+	}
+
+	if err := o.checkConfig(); err != nil {
+		return nil, err
+	}
+	if err := o.resolveParentFlags(); err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+
+	versionID, err := o.resolveType(resources.APIVersionType, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := o.service.APIVersion(ctx, o.forAPI, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(version.Schema) == 0 {
+		return nil, fmt.Errorf("API version %s has no schema", arg)
+	}
+
+	s, err := o.service.Schema(ctx, o.forAPI, versionID, version.Schema[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return convert.ParseOpenAPI([]byte(s.Schema))
+}
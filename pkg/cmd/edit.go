@@ -0,0 +1,322 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/util"
+	"github.com/kevinswiber/postmanctl/pkg/validate"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// editOptions are the options of the edit command.
+type editOptions struct {
+	*rootOptions
+	output   string
+	yes      bool
+	validate bool
+}
+
+const editHeader = `# Edit the %s below and close the editor to save it.
+# Lines beginning with '#' are ignored, and an empty file aborts the edit.
+
+`
+
+func newEditCommand(root *rootOptions) *cobra.Command {
+	o := &editOptions{rootOptions: root}
+
+	editCmd := &cobra.Command{
+		Use:   "edit [type/name]",
+		Short: "Edit Postman resources in your editor.",
+		Long: `Edit a Postman resource in your editor.
+
+The resource is fetched, its read-only fields are removed and it is opened in
+$EDITOR as YAML or JSON. When the editor is closed, the changes are validated,
+listed for confirmation, unless --yes is given, and the resource is replaced.
+If anything fails, the edited file is kept so that no work is lost.`,
+		Example: `  postmanctl edit env/staging
+  EDITOR="code --wait" postmanctl edit collection "Orders API" -o json
+  postmanctl edit schema 7c3a21e4 --for-api "Orders API" --for-api-version 1.0.0`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return cmd.Help()
+			}
+
+			refs, err := parseRefs(args, resources.VerbReplace)
+			if err != nil {
+				return err
+			}
+			if len(refs) != 1 || refs[0].Name == "" {
+				return errors.New("one resource is required, such as env/staging")
+			}
+
+			return o.editResource(refs[0].Info, refs[0].Name)
+		},
+	}
+
+	for _, info := range registeredWith(resources.VerbGet | resources.VerbReplace) {
+		editCmd.AddCommand(o.generateEditSubcommand(info))
+	}
+
+	editCmd.PersistentFlags().StringVarP(&o.output, "output", "o", "yaml", "the format to edit the resource in (yaml, json)")
+	editCmd.PersistentFlags().BoolVarP(&o.yes, "yes", "y", false, "replace the resource without asking for confirmation")
+	editCmd.PersistentFlags().BoolVar(&o.validate, "validate", true, "validate collections against the collection schema before sending them")
+
+	return editCmd
+}
+
+func (o *editOptions) generateEditSubcommand(info *resources.ResourceInfo) *cobra.Command {
+	cmd := cobra.Command{
+		Use:     info.Name,
+		Aliases: info.Aliases,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.editResource(info, args[0])
+		},
+	}
+
+	o.addParentFlags(&cmd, info)
+
+	return &cmd
+}
+
+func (o *editOptions) editResource(info *resources.ResourceInfo, name string) error {
+	if o.output != "yaml" && o.output != "json" {
+		return fmt.Errorf("unsupported output format: %s", o.output)
+	}
+
+	id, err := o.resolveName(info, name)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	urlParams := o.parentParams(info)
+
+	raw, err := o.service.GetRaw(ctx, info.Type, urlParams, id)
+	if err != nil {
+		return err
+	}
+
+	var original map[string]interface{}
+	if err := json.Unmarshal(raw, &original); err != nil {
+		return err
+	}
+	for _, k := range readOnlyKeys {
+		delete(original, k)
+	}
+
+	path, err := o.writeEditFile(info, original)
+	if err != nil {
+		return err
+	}
+
+	if err := o.runEditor(path); err != nil {
+		return o.keepEdit(path, err)
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return o.keepEdit(path, err)
+	}
+
+	if isBlankEdit(b) {
+		os.Remove(path)
+		fmt.Fprintln(o.ErrOut, "edit cancelled, the file is empty")
+		return nil
+	}
+
+	if o.output == "yaml" {
+		if b, err = yamlToJSON(b); err != nil {
+			return o.keepEdit(path, err)
+		}
+	}
+
+	var edited map[string]interface{}
+	if err := json.Unmarshal(b, &edited); err != nil {
+		return o.keepEdit(path, err)
+	}
+
+	if info.Type == resources.CollectionType && o.validate {
+		if errs := validate.Collection(b); errs != nil {
+			return o.keepEdit(path, errs)
+		}
+	}
+
+	changes := util.CompareInterface("", original, edited)
+	if len(changes) == 0 {
+		os.Remove(path)
+		fmt.Fprintln(o.ErrOut, "edit cancelled, no changes made")
+		return nil
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	out, _ := tabbedString(func(out io.Writer) error {
+		fmt.Fprintln(out, "PATH\tOLD\tNEW")
+		for _, c := range changes {
+			fmt.Fprintf(out, "%s\t%s\t%s\n", strings.TrimPrefix(c.Path, "."), diffValue(c.Old), diffValue(c.New))
+		}
+		return nil
+	})
+	fmt.Fprint(o.ErrOut, out)
+
+	if !o.yes {
+		fmt.Fprintf(o.ErrOut, "Replace %s/%s with these changes? (y/N): ", info.Name, name)
+		var confirm string
+		fmt.Fscanln(o.In, &confirm)
+		if strings.ToLower(confirm) != "y" {
+			fmt.Fprintf(o.ErrOut, "nothing was replaced, the edited file is kept in %s\n", path)
+			return nil
+		}
+	}
+
+	urlParams["ID"] = id
+	o.service.SkipCollectionValidation = true
+
+	id, err = o.service.ReplaceFromReader(ctx, info.Type, bytes.NewReader(b), urlParams)
+	if err != nil {
+		return o.keepEdit(path, err)
+	}
+
+	o.forgetNames(info.Type)
+	os.Remove(path)
+
+	fmt.Fprintln(o.Out, id)
+
+	return nil
+}
+
+// yamlToJSON converts an edited YAML file to JSON.
+func yamlToJSON(b []byte) ([]byte, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(jsonValue(v))
+}
+
+// jsonValue converts the map[interface{}]interface{} values of the YAML
+// decoder into values encoding/json can marshal.
+func jsonValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			m[fmt.Sprint(k)] = jsonValue(e)
+		}
+		return m
+	case []interface{}:
+		for i, e := range t {
+			t[i] = jsonValue(e)
+		}
+		return t
+	}
+
+	return v
+}
+
+// writeEditFile writes a resource to a temporary file in the edit format and
+// returns its path.
+func (o *editOptions) writeEditFile(info *resources.ResourceInfo, v map[string]interface{}) (string, error) {
+	var (
+		b   []byte
+		err error
+	)
+	if o.output == "yaml" {
+		if b, err = yaml.Marshal(v); err == nil {
+			b = append([]byte(fmt.Sprintf(editHeader, info.Name)), b...)
+		}
+	} else {
+		b, err = json.MarshalIndent(v, "", "  ")
+	}
+	if err != nil {
+		return "", err
+	}
+
+	f, err := ioutil.TempFile("", "postmanctl-edit-"+info.Name+"-*."+o.output)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.Write(b); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// runEditor opens a file in $EDITOR, which may include arguments, such as
+// "code --wait".
+func (o *editOptions) runEditor(path string) error {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+		if runtime.GOOS == "windows" {
+			editor = []string{"notepad"}
+		}
+	}
+
+	c := exec.Command(editor[0], append(editor[1:], path)...)
+	// Input that is not a terminal is left for the confirmation.
+	if isTerminal(o.In) {
+		c.Stdin = o.In
+	}
+	c.Stdout = o.Out
+	c.Stderr = o.ErrOut
+
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("editor %s failed: %s", editor[0], err)
+	}
+
+	return nil
+}
+
+// isBlankEdit reports whether an edited file has only comments and blank
+// lines.
+func isBlankEdit(b []byte) bool {
+	for _, l := range strings.Split(string(b), "\n") {
+		l = strings.TrimSpace(l)
+		if l != "" && !strings.HasPrefix(l, "#") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// keepEdit tells where the edited file is kept after a failure.
+func (o *editOptions) keepEdit(path string, err error) error {
+	fmt.Fprintf(o.ErrOut, "the edited file is kept in %s\n", path)
+	return err
+}
@@ -0,0 +1,190 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/cmd"
+)
+
+// runInput runs a root command reading stdin from in.
+func runInput(factory cmd.ServiceFactory, in string, args ...string) (int, string, string) {
+	var out, errOut bytes.Buffer
+	root := cmd.NewRootCommand(cmd.IOStreams{In: strings.NewReader(in), Out: &out, ErrOut: &errOut}, factory)
+	root.SetArgs(args)
+
+	return cmd.Execute(root), out.String(), errOut.String()
+}
+
+func TestEdit(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("editors are shell scripts")
+	}
+
+	var (
+		put     string
+		failPut bool
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/environments", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"environments":[]}`)
+	})
+	mux.HandleFunc("/environments/e1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"environment":{"id":"e1","uid":"u-e1","owner":"1","createdAt":"2020-11-02T15:14:05.000Z",`+
+				`"name":"staging","values":[{"key":"port","value":"8080","enabled":true}]}}`)
+		case http.MethodPut:
+			if failPut {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error":{"name":"invalidParamsError","message":"bad environment"}}`)
+				return
+			}
+			b, _ := ioutil.ReadAll(r.Body)
+			put = string(b)
+			fmt.Fprint(w, `{"environment":{"id":"e1","uid":"u-e1"}}`)
+		}
+	})
+	mux.HandleFunc("/collections", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"collections":[]}`)
+	})
+	mux.HandleFunc("/collections/c1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"collection":{"info":{"name":"Orders",`+
+				`"schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},"item":[]}}`)
+		case http.MethodPut:
+			b, _ := ioutil.ReadAll(r.Body)
+			put = string(b)
+			fmt.Fprint(w, `{"collection":{"uid":"u-c1"}}`)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "postmanctl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfgFile := writeConfig(t, dir)
+
+	// Edited files are written to TMPDIR, where kept files are looked for.
+	tmp := filepath.Join(dir, "tmp")
+	tmpDir := os.Getenv("TMPDIR")
+	os.Setenv("TMPDIR", tmp)
+	defer os.Setenv("TMPDIR", tmpDir)
+
+	editor := filepath.Join(dir, "editor")
+	seen := filepath.Join(dir, "seen")
+	editorVar := os.Getenv("EDITOR")
+	os.Setenv("EDITOR", editor)
+	defer os.Setenv("EDITOR", editorVar)
+
+	yamlEdit := `cp "$1" ` + seen + `
+cat > "$1" <<EOF
+name: staging
+values:
+- key: port
+  value: "9090"
+  enabled: true
+EOF`
+	jsonEdit := `cp "$1" ` + seen + `
+echo '{"name": "staging", "values": [{"key": "port", "value": "9090", "enabled": true}]}' > "$1"`
+
+	tests := []struct {
+		name    string
+		args    []string
+		script  string
+		in      string
+		failPut bool
+		code    int
+		put     string
+		kept    bool
+		errOut  string
+	}{
+		{"yaml", []string{"env/e1", "--yes"}, yamlEdit, "", false, 0, `"value":"9090"`, false, `[{"enabled":true,"key":"port","value":"9090"}]`},
+		{"json", []string{"environment", "e1", "-o", "json"}, jsonEdit, "y\n", false, 0, `"value":"9090"`, false, "Replace environment/e1"},
+		{"declined", []string{"env/e1"}, yamlEdit, "n\n", false, 0, "", true, "nothing was replaced"},
+		{"unchanged", []string{"env/e1"}, `cp "$1" ` + seen, "", false, 0, "", false, "no changes made"},
+		{"blank", []string{"env/e1"}, `: > "$1"`, "", false, 0, "", false, "the file is empty"},
+		{"editor fails", []string{"env/e1"}, "exit 3", "", false, 1, "", true, "editor " + editor + " failed"},
+		{"replace fails", []string{"env/e1", "--yes"}, yamlEdit, "", true, 7, "", true, "bad environment"},
+		{"invalid", []string{"co/c1", "--yes"}, `printf 'info:\n  name: Orders\n' > "$1"`, "", false, 7, "", true, "missing required property"},
+		{"not validated", []string{"co/c1", "--yes", "--validate=false"}, `printf 'info:\n  name: Orders\n' > "$1"`, "", false, 0, `{"collection":{"info":{"name":"Orders"}}}`, false, ""},
+	}
+
+	for _, tt := range tests {
+		put, failPut = "", tt.failPut
+		os.RemoveAll(tmp)
+		os.Remove(seen)
+		if err := os.MkdirAll(tmp, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(editor, []byte("#!/bin/sh\n"+tt.script+"\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		args := append([]string{"--config", cfgFile, "--cache-ttl", "0", "edit"}, tt.args...)
+		code, _, errOut := runInput(serverFactory(server), tt.in, args...)
+		if code != tt.code {
+			t.Errorf("%s: exit code is incorrect, have: %d, want: %d: %s", tt.name, code, tt.code, errOut)
+		}
+		if tt.put == "" && put != "" || !strings.Contains(put, tt.put) {
+			t.Errorf("%s: replaced resource is incorrect, have: %q, want: %q", tt.name, put, tt.put)
+		}
+		if !strings.Contains(errOut, tt.errOut) {
+			t.Errorf("%s: error output is incorrect, have: %q, want: %q", tt.name, errOut, tt.errOut)
+		}
+
+		files, _ := ioutil.ReadDir(tmp)
+		if kept := len(files) == 1; kept != tt.kept || len(files) > 1 {
+			t.Errorf("%s: %d edited files are kept, want kept: %t", tt.name, len(files), tt.kept)
+		} else if kept && !strings.Contains(errOut, "kept in "+filepath.Join(tmp, files[0].Name())) {
+			t.Errorf("%s: kept file is not reported, have: %q", tt.name, errOut)
+		}
+	}
+
+	// Read-only fields are not given to the editor.
+	os.Setenv("EDITOR", editor)
+	if err := ioutil.WriteFile(editor, []byte("#!/bin/sh\ncp \"$1\" "+seen+"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	runInput(serverFactory(server), "", "--config", cfgFile, "--cache-ttl", "0", "edit", "env/e1")
+	b, err := ioutil.ReadFile(seen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(b), "# Edit the environment below") || !strings.Contains(string(b), "name: staging") {
+		t.Errorf("edited file is incorrect, have:\n%s", b)
+	}
+	for _, k := range []string{"id:", "uid:", "owner:", "createdAt:"} {
+		if strings.Contains(string(b), "\n"+k) {
+			t.Errorf("edited file contains the read-only field %s, have:\n%s", k, b)
+		}
+	}
+}
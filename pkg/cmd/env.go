@@ -0,0 +1,139 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/spf13/cobra"
+)
+
+// envOptions are the options of the env command.
+type envOptions struct {
+	*rootOptions
+	disabled bool
+	secret   bool
+	retries  int
+}
+
+func newEnvCommand(root *rootOptions) *cobra.Command {
+	o := &envOptions{rootOptions: root}
+
+	envCmd := &cobra.Command{
+		Use:   "env",
+		Short: "Change the values of Postman environments.",
+		Long: `Change the values of Postman environments in place.
+
+The environment is fetched, changed and replaced in one step. It is fetched
+again just before writing; when someone else changed it in the meantime,
+the change is applied to the new content, up to --retries times, before
+giving up without writing.`,
+	}
+
+	envSetCmd := &cobra.Command{
+		Use:   "set <environment> KEY=VALUE...",
+		Short: "Set values of an environment",
+		Example: `  postmanctl env set staging baseUrl=https://staging.example.com
+  postmanctl env set staging apiKey=abc123 --secret`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.setEnvironmentValues(args[0], args[1:])
+		},
+	}
+	envSetCmd.Flags().BoolVar(&o.disabled, "disabled", false, "set the values as disabled")
+	envSetCmd.Flags().BoolVar(&o.secret, "secret", false, "set the values as secrets")
+
+	envUnsetCmd := &cobra.Command{
+		Use:     "unset <environment> KEY...",
+		Short:   "Remove values from an environment",
+		Example: `  postmanctl env unset staging debug verbose`,
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.unsetEnvironmentValues(args[0], args[1:])
+		},
+	}
+
+	envCmd.PersistentFlags().IntVar(&o.retries, "retries", 3, "times to retry when the environment changes while updating it, 0 to abort")
+
+	envCmd.AddCommand(envSetCmd, envUnsetCmd)
+
+	return envCmd
+}
+
+func (o *envOptions) setEnvironmentValues(environment string, assignments []string) error {
+	values := make([]resources.KeyValuePair, len(assignments))
+	for i, a := range assignments {
+		eq := strings.IndexByte(a, '=')
+		if eq <= 0 {
+			return fmt.Errorf("expected KEY=VALUE, have: %s", a)
+		}
+
+		values[i] = resources.KeyValuePair{Key: a[:eq], Value: a[eq+1:], Enabled: !o.disabled}
+	}
+
+	return o.updateEnvironment(environment, func(env *resources.Environment) error {
+		for _, v := range values {
+			v.Type = "default"
+			if o.secret {
+				v.Type = convert.SecretType
+			} else if existing, ok := env.Value(v.Key); ok && existing.Type != "" {
+				v.Type = existing.Type
+			}
+
+			env.Set(v)
+		}
+
+		return nil
+	})
+}
+
+func (o *envOptions) unsetEnvironmentValues(environment string, keys []string) error {
+	return o.updateEnvironment(environment, func(env *resources.Environment) error {
+		for _, k := range keys {
+			if !env.Unset(k) {
+				return fmt.Errorf("key %q not found in environment %s", k, env.Name)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (o *envOptions) updateEnvironment(environment string, fn func(*resources.Environment) error) error {
+	id, err := o.resolveType(resources.EnvironmentType, environment)
+	if err != nil {
+		return err
+	}
+
+	uid, err := o.service.UpdateEnvironment(context.Background(), id, o.retries, fn)
+	if errors.Is(err, client.ErrConflict) {
+		return fmt.Errorf("environment %s, nothing was written: %w", environment, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(o.Out, uid)
+
+	return nil
+}
@@ -0,0 +1,197 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+)
+
+// Exit codes of postmanctl. They are listed in the README.
+const (
+	exitOK           = 0
+	exitError        = 1
+	exitUsage        = 2
+	exitNotFound     = 3
+	exitUnauthorized = 4
+	exitRateLimited  = 5
+	exitConflict     = 6
+	exitValidation   = 7
+	// exitFindings ends diff, lint and scan when they find a problem.
+	exitFindings = 8
+)
+
+// errorKinds are the kinds of errors with their own exit codes.
+var errorKinds = []struct {
+	err  error
+	name string
+	code int
+}{
+	{client.ErrNotFound, "notFound", exitNotFound},
+	{client.ErrUnauthorized, "unauthorized", exitUnauthorized},
+	{client.ErrRateLimited, "rateLimited", exitRateLimited},
+	{client.ErrConflict, "conflict", exitConflict},
+	{client.ErrValidation, "validation", exitValidation},
+}
+
+// usageError is an error in the flags or arguments of a command.
+type usageError struct {
+	err     error
+	command string
+}
+
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *usageError) Unwrap() error {
+	return e.err
+}
+
+// silentError ends a command with an exit code after the command has
+// reported the problem itself, such as the differences found by diff.
+type silentError struct {
+	code int
+}
+
+func (e *silentError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+// usageErrorPrefixes start the messages of the usage errors returned by
+// cobra, such as those of argument validators.
+var usageErrorPrefixes = []string{"unknown command", "unknown flag", "required flag", "accepts ", "requires at least"}
+
+// asUsageError returns err as a usage error of a command when it is one.
+func asUsageError(err error, command string) error {
+	for _, p := range usageErrorPrefixes {
+		if strings.HasPrefix(err.Error(), p) {
+			return &usageError{err: err, command: command}
+		}
+	}
+
+	return err
+}
+
+// errorKind returns the name and exit code of the kind of an error.
+func errorKind(err error) (string, int) {
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		return "usage", exitUsage
+	}
+
+	for _, k := range errorKinds {
+		if errors.Is(err, k.err) {
+			return k.name, k.code
+		}
+	}
+
+	return "error", exitError
+}
+
+// exitCode returns the exit code of the process for an error returned by a
+// command.
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+
+	var silentErr *silentError
+	if errors.As(err, &silentErr) {
+		return silentErr.code
+	}
+
+	_, code := errorKind(err)
+	return code
+}
+
+// errorReport is the JSON form of an error, printed with --error-format json.
+type errorReport struct {
+	Kind       string                 `json:"kind"`
+	Message    string                 `json:"message"`
+	ExitCode   int                    `json:"exitCode,omitempty"`
+	ID         string                 `json:"id,omitempty"`
+	StatusCode int                    `json:"statusCode,omitempty"`
+	Name       string                 `json:"name,omitempty"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	Items      []*errorReport         `json:"items,omitempty"`
+}
+
+func newErrorReport(err error) *errorReport {
+	r := &errorReport{Message: err.Error()}
+	r.Kind, _ = errorKind(err)
+
+	var reqErr *client.RequestError
+	if errors.As(err, &reqErr) {
+		r.StatusCode = reqErr.StatusCode
+		r.Name = reqErr.Name
+		r.Details = reqErr.Details
+		if reqErr.Message != "" {
+			r.Message = reqErr.Message
+		}
+	}
+
+	var batchErr sdk.BatchError
+	if errors.As(err, &batchErr) {
+		for _, e := range batchErr {
+			item := newErrorReport(e.Err)
+			item.ID = e.ID
+			r.Items = append(r.Items, item)
+		}
+	}
+
+	return r
+}
+
+// printError prints an error returned by a command in the format given by
+// --error-format.
+func printError(w io.Writer, err error, format string) {
+	var silentErr *silentError
+	if errors.As(err, &silentErr) {
+		return
+	}
+
+	if format == "json" {
+		r := newErrorReport(err)
+		r.ExitCode = exitCode(err)
+
+		b, _ := json.MarshalIndent(map[string]interface{}{"error": r}, "", "  ")
+		fmt.Fprintln(w, string(b))
+		return
+	}
+
+	var batchErr sdk.BatchError
+	if errors.As(err, &batchErr) {
+		for _, e := range batchErr {
+			fmt.Fprintf(w, "error: %s\n", e)
+		}
+		return
+	}
+
+	fmt.Fprintf(w, "error: %s\n", err)
+
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		fmt.Fprintf(w, "Run '%s --help' for usage.\n", usageErr.command)
+	}
+}
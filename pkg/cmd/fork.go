@@ -19,12 +19,14 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
 
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
 	"github.com/spf13/cobra"
 )
 
-func init() {
+func newForkCommand(o *rootOptions) *cobra.Command {
+	var label string
+
 	var cmd = &cobra.Command{
 		Use:   "fork",
 		Short: "Create a fork of a Postman resource.",
@@ -34,21 +36,25 @@ func init() {
 		Use:     "collection",
 		Aliases: []string{"co"},
 		Args:    cobra.MinimumNArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			id, err := service.ForkCollection(context.Background(), args[0], usingWorkspace, forkLabel)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := o.service.ForkCollection(context.Background(), args[0], o.usingWorkspace, label)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "error: %s\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			fmt.Println(id)
+			o.forgetNames(resources.CollectionType)
+
+			fmt.Fprintln(o.Out, id)
+
+			return nil
 		},
 	}
 
-	forkCollectionCmd.Flags().StringVarP(&usingWorkspace, "workspace", "w", "", "workspace for fork operation")
-	forkCollectionCmd.Flags().StringVarP(&forkLabel, "label", "l", "", "label to associate with the forked collection (required)")
+	forkCollectionCmd.Flags().StringVarP(&o.usingWorkspace, "workspace", "w", "", "workspace for fork operation")
+	forkCollectionCmd.Flags().StringVarP(&label, "label", "l", "", "label to associate with the forked collection (required)")
 	forkCollectionCmd.MarkFlagRequired("label")
 
 	cmd.AddCommand(forkCollectionCmd)
-	rootCmd.AddCommand(cmd)
+
+	return cmd
 }
@@ -0,0 +1,588 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/kevinswiber/postmanctl/pkg/util"
+	"io/ioutil"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+	"github.com/kevinswiber/postmanctl/pkg/sdk"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/variables"
+	"github.com/spf13/cobra"
+)
+
+type defaultValue struct {
+	value string
+}
+
+func (o *defaultValue) String() string {
+	return o.value
+}
+
+// Set creates the flag value.
+func (o *defaultValue) Set(v string) error {
+	o.value = v
+	return nil
+}
+
+// Type returns the type of this value.
+func (o *defaultValue) Type() string {
+	return "string"
+}
+
+// OutputFormatValue is a custom Value for the output flag that validates.
+type OutputFormatValue struct {
+	value string
+}
+
+// String returns a string representation of this flag.
+func (o *OutputFormatValue) String() string {
+	return o.value
+}
+
+// Set creates the flag value.
+func (o *OutputFormatValue) Set(v string) error {
+	if v == "json" || v == "curl" || isEnvironmentFormat(v) ||
+		strings.HasPrefix(v, "jsonpath=") || strings.HasPrefix(v, "go-template-file=") {
+		o.value = v
+		return nil
+	}
+
+	return errors.New("output format must be json, jsonpath, go-template-file, curl, dotenv, k8s-secret, or k8s-configmap")
+}
+
+// Type returns the type of this value.
+func (o *OutputFormatValue) Type() string {
+	return "string"
+}
+
+// getOptions are the options of the get command.
+type getOptions struct {
+	*rootOptions
+	output      OutputFormatValue
+	file        defaultValue
+	ignoreKey   defaultValue
+	selector    string
+	concurrency int
+	environment string
+}
+
+func newGetCommand(root *rootOptions) *cobra.Command {
+	o := &getOptions{rootOptions: root}
+
+	getCmd := &cobra.Command{
+		Use:   "get [type/name...]",
+		Short: "Retrieve Postman resources.",
+		Long: `Retrieve Postman resources.
+
+Resources of several types can be retrieved at once, either as type/name
+pairs or as a comma separated list of types. Types are given by name or
+alias, such as collection, co, env and mon.`,
+		Example: `  postmanctl get co/"Orders API" env/staging mock/abc
+  postmanctl get co,env,mon`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return cmd.Help()
+			}
+
+			return o.getRefs(args)
+		},
+	}
+
+	schemaCmd := &cobra.Command{
+		Use: "schema",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := []string{o.forAPI, o.forAPIVersion}
+			if len(args) == 0 {
+				version, err := o.service.APIVersion(context.Background(), o.forAPI, o.forAPIVersion)
+
+				if err != nil {
+					return err
+				}
+
+				if len(version.Schema) > 0 {
+					params = append(params, version.Schema[0])
+				} else {
+					return fmt.Errorf("no schema has been associated with this API version: %w", client.ErrNotFound)
+				}
+			}
+			params = append(params, args...)
+			return o.getIndividualSchema(params)
+		},
+	}
+
+	schemaCmd.Flags().StringVar(&o.forAPI, "for-api", "", "the associated API ID (required)")
+	schemaCmd.MarkFlagRequired("for-api")
+
+	schemaCmd.Flags().StringVar(&o.forAPIVersion, "for-api-version", "", "the associated API Version ID (required)")
+	schemaCmd.MarkFlagRequired("for-api-version")
+
+	apiRelationsCmd := &cobra.Command{
+		Use: "api-relations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if o.output.value == "" {
+				return o.getFormattedAPIRelations(o.forAPI, o.forAPIVersion)
+			}
+			return o.getAPIRelations(o.forAPI, o.forAPIVersion)
+		},
+	}
+
+	apiRelationsCmd.Flags().StringVar(&o.forAPI, "for-api", "", "the associated API ID (required)")
+	apiRelationsCmd.MarkFlagRequired("for-api")
+
+	apiRelationsCmd.Flags().StringVar(&o.forAPIVersion, "for-api-version", "", "the associated API Version ID (required)")
+	apiRelationsCmd.MarkFlagRequired("for-api-version")
+
+	userCmd := &cobra.Command{
+		Use: "user",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.getIndividualUser(args)
+		},
+	}
+
+	requestCmd := &cobra.Command{
+		Use:     "request <collection> <path>",
+		Aliases: []string{"req"},
+		Short:   "Retrieve a request from a collection",
+		Long: `Retrieve a request from a collection.
+
+The path is made of folder names and the request name separated by slashes,
+e.g. "Folder/Sub/Request name". Use "-o curl" to render the request as a curl
+command, with variables resolved from the collection and --environment. With
+--environment, the variables are resolved in every output format, including
+go-template and jsonpath.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.getRequest(args[0], args[1])
+		},
+	}
+	requestCmd.Flags().StringVar(&o.environment, "environment", "", "the environment ID or name used to resolve variables")
+
+	individual := map[resources.ResourceType]func(info *resources.ResourceInfo, args []string) error{
+		resources.CollectionType:  o.getReformattedResources,
+		resources.EnvironmentType: o.getIndividualEnvironments,
+		resources.MonitorType:     o.getReformattedResources,
+		resources.MockType:        o.getReformattedResources,
+		resources.WorkspaceType:   o.getReformattedResources,
+		resources.APIType:         o.getReformattedResources,
+	}
+
+	for _, info := range registeredWith(resources.VerbList | resources.VerbGet) {
+		fn, ok := individual[info.Type]
+		if !ok {
+			fn = o.getIndividualResources
+		}
+		getCmd.AddCommand(o.generateGetSubcommand(info, fn))
+	}
+
+	getCmd.AddCommand(
+		userCmd,
+		requestCmd,
+		apiRelationsCmd,
+		schemaCmd,
+	)
+
+	getCmd.PersistentFlags().VarP(&o.output, "output", "o", "output format (json, jsonpath, go-template-file, curl, dotenv, k8s-secret, k8s-configmap)")
+	getCmd.PersistentFlags().VarP(&o.file, "file", "f", "output file")
+	getCmd.PersistentFlags().VarP(&o.ignoreKey, "ignore-key", "i", "ignore json key in response")
+	getCmd.PersistentFlags().StringVarP(&o.selector, "selector", "l", "", "filter lists by name, owner, workspace, fork status or tags, such as 'team=payments,env!=prod'")
+	getCmd.PersistentFlags().IntVar(&o.concurrency, "concurrency", 4, "the number of resources fetched at the same time")
+
+	return getCmd
+}
+
+func (o *getOptions) generateGetSubcommand(info *resources.ResourceInfo, fn func(info *resources.ResourceInfo, args []string) error) *cobra.Command {
+	cmd := cobra.Command{
+		Use:     info.Plural,
+		Aliases: append([]string{info.Name}, info.Aliases...),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				if o.selector != "" {
+					return errors.New("names cannot be combined with --selector")
+				}
+				return fn(info, args)
+			}
+
+			return o.getAllResources(info)
+		},
+	}
+
+	o.addParentFlags(&cmd, info)
+	if info.WorkspaceFilter {
+		cmd.Flags().StringVar(&o.usingWorkspace, "workspace", "", "the associated workspace ID")
+	}
+
+	return &cmd
+}
+
+func (o *getOptions) getAllResources(info *resources.ResourceInfo) error {
+	resource, err := o.service.List(context.Background(), info.Type, o.listParams(info), o.parentParams(info))
+	if err != nil {
+		return err
+	}
+
+	if resource, err = o.selectResources(info, o.selector, resource); err != nil {
+		return err
+	}
+
+	return o.printGetOutput(resource)
+}
+
+// getRefs prints resources of several types. The table output has a TYPE
+// column.
+func (o *getOptions) getRefs(args []string) error {
+	refs, err := parseRefs(args, resources.VerbGet)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	ids, err := o.refIDs(refs)
+	if err != nil {
+		return err
+	}
+
+	var (
+		rows  resources.TypedRows
+		items []interface{}
+	)
+	for i, ref := range refs {
+		var r interface{}
+		if ref.Name == "" {
+			r, err = o.service.List(ctx, ref.Info.Type, o.listParams(ref.Info), nil)
+		} else if o.selector != "" {
+			return errors.New("names cannot be combined with --selector")
+		} else {
+			r, err = o.service.Get(ctx, ref.Info.Type, nil, ids[i])
+		}
+		if err != nil {
+			return err
+		}
+
+		if r, err = o.selectResources(ref.Info, o.selector, r); err != nil {
+			return err
+		}
+
+		if o.output.value == "" {
+			f, ok := r.(resources.Formatter)
+			if !ok {
+				return fmt.Errorf("%s cannot be printed as a table", ref.Info.Plural)
+			}
+			if rows, err = rows.Append(ref.Info, f); err != nil {
+				return err
+			}
+		}
+		items = append(items, r)
+	}
+
+	if o.output.value == "" {
+		o.printTable(rows)
+		return nil
+	}
+
+	return o.printGetOutput(items)
+}
+
+// getIndividualResources prints resources given by ID or name. They are
+// fetched --concurrency at a time, and the resources that could not be
+// fetched are reported after the others are printed.
+func (o *getOptions) getIndividualResources(info *resources.ResourceInfo, args []string) error {
+	ids, err := o.resolveNames(info, args)
+	if err != nil {
+		return err
+	}
+
+	items, batchErr := o.service.GetByIDs(context.Background(), info.Type, o.parentParams(info), ids, o.batchOptions())
+
+	var r []interface{}
+	for _, item := range items {
+		if item != nil {
+			r = append(r, item)
+		}
+	}
+
+	if len(r) > 0 {
+		if err := o.printGetOutput(r); err != nil {
+			return err
+		}
+	}
+
+	return batchErr
+}
+
+// getReformattedResources prints resources like getIndividualResources,
+// without null values and the keys given by --ignore-key. Tables are
+// printed from the resources themselves.
+func (o *getOptions) getReformattedResources(info *resources.ResourceInfo, args []string) error {
+	if o.output.value == "" {
+		return o.getIndividualResources(info, args)
+	}
+
+	ids, err := o.resolveNames(info, args)
+	if err != nil {
+		return err
+	}
+
+	items, batchErr := o.service.GetByIDs(context.Background(), info.Type, o.parentParams(info), ids, o.batchOptions())
+
+	keymap := make(map[string]int)
+	for _, v := range strings.Split(o.ignoreKey.value, ",") {
+		keymap[v] = 1
+	}
+
+	var r []map[string]interface{}
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+
+		m, err := toMap(item)
+		if err != nil {
+			return err
+		}
+		r = append(r, util.ReformatMap(m, true, keymap))
+	}
+
+	if len(r) > 0 {
+		if err := o.printGetOutput(r); err != nil {
+			return err
+		}
+	}
+
+	return batchErr
+}
+
+// resolveNames resolves the names of resources of a type to IDs.
+func (o *rootOptions) resolveNames(info *resources.ResourceInfo, names []string) ([]string, error) {
+	ids := make([]string, len(names))
+	for i, name := range names {
+		id, err := o.resolveName(info, name)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+// batchOptions returns the options used to fetch several resources, as set
+// by --concurrency.
+func (o *getOptions) batchOptions() *sdk.BatchOptions {
+	return &sdk.BatchOptions{Concurrency: o.concurrency}
+}
+
+func (o *getOptions) getRequest(collection, path string) error {
+	ctx := context.Background()
+
+	id, err := o.resolveType(resources.CollectionType, collection)
+	if err != nil {
+		return err
+	}
+
+	c, err := o.service.Collection(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	vars := make(map[string]string)
+	if o.environment != "" {
+		envID, err := o.resolveType(resources.EnvironmentType, o.environment)
+		if err != nil {
+			return err
+		}
+
+		env, err := o.service.Environment(ctx, envID)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range env.Values {
+			if v.Enabled {
+				vars[v.Key] = v.Value
+			}
+		}
+	}
+
+	if o.output.value != "curl" {
+		item, err := convert.FindItem(c, path)
+		if err != nil {
+			return err
+		}
+
+		if o.environment != "" {
+			if item, err = resolveItem(c, item, vars); err != nil {
+				return err
+			}
+		}
+
+		if o.output.value == "" {
+			o.output.value = "json"
+		}
+		return o.printGetOutput(item)
+	}
+
+	curl, err := convert.CurlCommand(c, path, vars)
+	if err != nil {
+		return err
+	}
+
+	if len(o.file.value) > 0 {
+		fmt.Fprintf(o.Out, "write to file %s\n", o.file.value)
+		return ioutil.WriteFile(o.file.value, []byte(curl+"\n"), 0644)
+	}
+
+	fmt.Fprintln(o.Out, curl)
+
+	return nil
+}
+
+// resolveItem returns a copy of an item with the variables of its request
+// resolved from the collection variables and vars.
+func resolveItem(c *resources.Collection, item *gen.Item, vars map[string]string) (*gen.Item, error) {
+	req, err := resources.DecodeRequest(item.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := variables.NewResolver()
+	resolver.SetCollection(c.Variable)
+	for k, v := range vars {
+		resolver.Set(variables.Environment, k, v)
+	}
+
+	out := *item
+	out.Request, _ = resolver.ResolveRequest(req)
+
+	return &out, nil
+}
+
+func (o *getOptions) getIndividualEnvironments(info *resources.ResourceInfo, args []string) error {
+	if isEnvironmentFormat(o.output.value) {
+		return o.getFormattedEnvironments(info, args)
+	}
+
+	return o.getReformattedResources(info, args)
+}
+
+// isEnvironmentFormat reports whether an output format is only supported
+// for environments.
+func isEnvironmentFormat(v string) bool {
+	return v == "dotenv" || v == "k8s-secret" || v == "k8s-configmap"
+}
+
+// getFormattedEnvironments prints environments as a dotenv file or as
+// Kubernetes manifests.
+func (o *getOptions) getFormattedEnvironments(info *resources.ResourceInfo, args []string) error {
+	if o.output.value == "dotenv" && len(args) > 1 {
+		return errors.New("dotenv output supports a single environment")
+	}
+
+	ids, err := o.resolveNames(info, args)
+	if err != nil {
+		return err
+	}
+
+	envs, batchErr := o.service.EnvironmentsByID(context.Background(), ids, o.batchOptions())
+
+	var docs [][]byte
+	for _, env := range envs {
+		if env == nil {
+			continue
+		}
+
+		var doc []byte
+		switch o.output.value {
+		case "dotenv":
+			doc, err = convert.EnvironmentToDotenv(env)
+		case "k8s-secret":
+			doc, err = convert.EnvironmentToSecret(env)
+		case "k8s-configmap":
+			doc, err = convert.EnvironmentToConfigMap(env)
+		}
+
+		if err != nil {
+			return err
+		}
+		docs = append(docs, doc)
+	}
+
+	out := bytes.Join(docs, []byte("---\n"))
+	if len(o.file.value) > 0 {
+		fmt.Fprintf(o.Out, "write to file %s\n", o.file.value)
+		if err := ioutil.WriteFile(o.file.value, out, 0644); err != nil {
+			return err
+		}
+	} else if _, err := o.Out.Write(out); err != nil {
+		return err
+	}
+
+	return batchErr
+}
+
+func (o *getOptions) getIndividualUser(args []string) error {
+	resource, err := o.service.User(context.Background())
+
+	if err != nil {
+		return err
+	}
+
+	return o.printGetOutput(resource)
+}
+
+func (o *getOptions) getIndividualSchema(args []string) error {
+	apiID := args[0]
+	apiVersionID := args[1]
+	id := args[2]
+
+	resource, err := o.service.Schema(context.Background(), apiID, apiVersionID, id)
+
+	if err != nil {
+		return err
+	}
+
+	return o.printGetOutput(resource)
+}
+
+func (o *getOptions) getAPIRelations(apiID, apiVersionID string) error {
+	resource, err := o.service.APIRelations(context.Background(), apiID, apiVersionID)
+
+	if err != nil {
+		return err
+	}
+
+	return o.printGetOutput(resource)
+}
+
+func (o *getOptions) getFormattedAPIRelations(apiID, apiVersionID string) error {
+	resource, err := o.service.FormattedAPIRelationItems(context.Background(), apiID, apiVersionID)
+
+	if err != nil {
+		return err
+	}
+
+	return o.printGetOutput(resource)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGetTable(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/environments", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"environments":[{"id":"e1","uid":"u-e1","name":"staging"},{"id":"e2","uid":"u-e2","name":"qa"}]}`)
+	})
+	mux.HandleFunc("/environments/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/environments/")
+		fmt.Fprintf(w, `{"environment":{"id":%q,"name":"env-%s","values":[]}}`, id, id)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "postmanctl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfgFile := writeConfig(t, dir)
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"environment", "staging"}, []string{"env-u-e1"}},
+		{[]string{"environments", "staging", "qa"}, []string{"env-u-e1", "env-u-e2"}},
+		{[]string{"env/staging"}, []string{"environment   u-e1"}},
+		{[]string{"env"}, []string{"staging", "qa"}},
+	}
+
+	for _, tt := range tests {
+		args := append([]string{"--config", cfgFile, "--cache-ttl", "0", "get"}, tt.args...)
+		code, out, errOut := run(serverFactory(server), args...)
+		if code != 0 {
+			t.Errorf("exit code of %v is incorrect, have: %d, want: 0: %s", tt.args, code, errOut)
+			continue
+		}
+		if strings.Count(out, "\n") != len(tt.want)+1 {
+			t.Errorf("output of %v is incorrect, have: %q", tt.args, out)
+		}
+		for _, w := range tt.want {
+			if !strings.Contains(out, w) {
+				t.Errorf("output of %v is incorrect, have: %q, want: %s", tt.args, out, w)
+			}
+		}
+	}
+}
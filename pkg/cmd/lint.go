@@ -0,0 +1,343 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kevinswiber/postmanctl/pkg/lint"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/variables"
+	"github.com/spf13/cobra"
+)
+
+// lintOptions are the options of the lint command.
+type lintOptions struct {
+	*rootOptions
+	environments []string
+	ruleset      string
+	output       string
+}
+
+func newLintCommand(root *rootOptions) *cobra.Command {
+	o := &lintOptions{rootOptions: root}
+
+	lintCmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check Postman resources for common problems.",
+		Annotations: map[string]string{
+			configOptionalAnnotation: "true",
+		},
+	}
+
+	lintVariablesCmd := &cobra.Command{
+		Use:     "variables <collection>",
+		Aliases: []string{"vars"},
+		Short:   "Report undefined variables and unused environment keys",
+		Long: `Report undefined variables and unused environment keys.
+
+Lists every {{variable}} used by the collection that neither the collection,
+the environment nor a script defines, and every environment key that the
+collection does not use. Each environment given with --environment, or every
+environment in the workspace given with --workspace, is checked in turn.
+
+The collection and environments may be IDs, names or files. The command
+exits with status 8 when a problem is found.`,
+		Example: `  postmanctl lint variables "Orders API" --environment staging
+  postmanctl lint variables ./orders.json --environment ./staging.json
+  postmanctl lint variables "Orders API" --workspace <workspace-id>`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.lintVariables(args[0])
+		},
+	}
+
+	lintVariablesCmd.Flags().StringSliceVar(&o.environments, "environment", nil, "environment IDs, names or files to check against")
+	lintVariablesCmd.Flags().StringVarP(&o.usingWorkspace, "workspace", "w", "", "check against every environment in this workspace")
+
+	lintCollectionCmd := &cobra.Command{
+		Use:     "collection <id|name|file>",
+		Aliases: []string{"co"},
+		Short:   "Check a collection against the lint rules",
+		Long: `Check a collection against the lint rules.
+
+Rules:
+  request-tests          requests without a test script (warning)
+  hardcoded-host         request URLs with a hardcoded host (warning)
+  missing-description    collection, folders or requests without a description (info)
+  duplicate-name         requests with the same name in a folder (error)
+  plaintext-credentials  credentials in headers or auth not using variables (error)
+  ssl-verification       SSL certificate verification disabled (warning)
+
+Severities can be changed, or rules turned off, with a YAML ruleset:
+
+  rules:
+    request-tests: error
+    missing-description: off
+
+The command exits with status 8 when a finding has the error severity.`,
+		Example: `  postmanctl lint collection "Orders API"
+  postmanctl lint collection ./orders.json --ruleset .postmanctl-lint.yaml -o sarif > lint.sarif`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.lintCollection(args[0])
+		},
+	}
+
+	lintCollectionCmd.Flags().StringVar(&o.ruleset, "ruleset", "", "a YAML file setting the severity of rules")
+	lintCollectionCmd.Flags().StringVarP(&o.output, "output", "o", "text", "output format (text, json, sarif)")
+
+	lintSchemaCmd := &cobra.Command{
+		Use:   "schema [file|schema-id]",
+		Short: "Check an OpenAPI schema against the lint rules",
+		Long: `Check an OpenAPI 3 or Swagger 2.0 schema against the lint rules.
+
+The schema of an API version is read with --for-api and --for-api-version,
+or a schema file is given as an argument.
+
+Rules:
+  info-description            the API has no description (info)
+  api-servers                 no servers are defined (warning)
+  path-trailing-slash         paths ending with a slash (warning)
+  path-parameters             path templates and path parameters that do not match (error)
+  operation-id                operations without an operationId (warning)
+  operation-id-unique         operationIds used more than once (error)
+  operation-description       operations without a summary or description (info)
+  operation-tags              operations without tags (warning)
+  operation-success-response  operations without a 2xx or 3xx response (warning)
+
+Rulesets, output formats and the exit status are the same as for
+"lint collection".`,
+		Example: `  postmanctl lint schema --for-api <api-id> --for-api-version <api-version-id>
+  postmanctl lint schema ./openapi.yaml -o sarif`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.lintSchema(args)
+		},
+	}
+
+	lintSchemaCmd.Flags().StringVar(&o.forAPI, "for-api", "", "the associated API ID")
+	lintSchemaCmd.Flags().StringVar(&o.forAPIVersion, "for-api-version", "", "the associated API Version ID")
+	lintSchemaCmd.Flags().StringVar(&o.ruleset, "ruleset", "", "a YAML file setting the severity of rules")
+	lintSchemaCmd.Flags().StringVarP(&o.output, "output", "o", "text", "output format (text, json, sarif)")
+
+	lintCmd.AddCommand(lintCollectionCmd, lintSchemaCmd, lintVariablesCmd)
+
+	return lintCmd
+}
+
+func (o *lintOptions) lintVariables(arg string) error {
+	c, err := o.loadCollection(arg)
+	if err != nil {
+		return err
+	}
+
+	var envs []*resources.Environment
+	for _, arg := range o.environments {
+		env, err := o.loadEnvironment(arg)
+		if err != nil {
+			return err
+		}
+		envs = append(envs, env)
+	}
+
+	if o.usingWorkspace != "" {
+		if err := o.checkConfig(); err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		ws, err := o.service.Workspace(ctx, o.usingWorkspace)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range ws.Environments {
+			env, err := o.service.Environment(ctx, e.UID)
+			if err != nil {
+				return err
+			}
+			envs = append(envs, env)
+		}
+	}
+
+	if len(envs) == 0 {
+		envs = append(envs, nil)
+	}
+
+	var found bool
+	out, err := tabbedString(func(out io.Writer) error {
+		fmt.Fprintln(out, "ENVIRONMENT\tPROBLEM\tVARIABLE\tLOCATION")
+		for _, env := range envs {
+			name := "-"
+			if env != nil {
+				name = env.Name
+			}
+
+			r := variables.Check(c, env)
+			for _, ref := range r.Undefined {
+				fmt.Fprintf(out, "%s\tundefined\t%s\t%s\n", name, ref.Name, ref.Location)
+			}
+			for _, key := range r.Unused {
+				fmt.Fprintf(out, "%s\tunused\t%s\t\n", name, key)
+			}
+			found = found || len(r.Undefined) > 0 || len(r.Unused) > 0
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		fmt.Fprintln(o.Out, "no problems found")
+		return nil
+	}
+
+	fmt.Fprint(o.Out, out)
+
+	return &silentError{code: exitFindings}
+}
+
+func (o *lintOptions) lintCollection(arg string) error {
+	cfg, err := o.readLintRuleset()
+	if err != nil {
+		return err
+	}
+
+	c, err := o.loadCollection(arg)
+	if err != nil {
+		return err
+	}
+
+	uri := ""
+	if _, err := os.Stat(arg); err == nil {
+		uri = arg
+	}
+
+	return o.printFindings(arg, uri, lint.Lint(c, cfg), cfg)
+}
+
+func (o *lintOptions) lintSchema(args []string) error {
+	cfg, err := o.readLintRuleset()
+	if err != nil {
+		return err
+	}
+
+	var name, uri, id string
+	if len(args) > 0 {
+		name, id = args[0], args[0]
+		if o.forAPI == "" {
+			uri = args[0]
+		}
+	} else if o.forAPI == "" {
+		return errors.New("a schema file or the \"for-api\" and \"for-api-version\" flags are required")
+	}
+
+	if o.forAPI != "" && o.forAPIVersion == "" {
+		return errors.New("flag \"for-api-version\" is required with \"for-api\"")
+	}
+
+	if o.forAPI != "" && id == "" {
+		if err := o.checkConfig(); err != nil {
+			return err
+		}
+		if err := o.resolveParentFlags(); err != nil {
+			return err
+		}
+
+		version, err := o.service.APIVersion(context.Background(), o.forAPI, o.forAPIVersion)
+		if err != nil {
+			return err
+		}
+		if len(version.Schema) == 0 {
+			return fmt.Errorf("API version %s has no schema", o.forAPIVersion)
+		}
+		id = version.Schema[0]
+		name = version.Name
+	}
+
+	doc, err := o.loadOpenAPI(id)
+	if err != nil {
+		return err
+	}
+
+	return o.printFindings(name, uri, lint.LintSchema(doc, cfg), cfg)
+}
+
+func (o *lintOptions) readLintRuleset() (*lint.Config, error) {
+	if o.ruleset == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(o.ruleset)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	cfg, err := lint.ReadConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", o.ruleset, err)
+	}
+
+	return cfg, nil
+}
+
+// printFindings writes findings in the format given by --output. uri is
+// the linted file, if any. It exits with status 8 when a finding has the
+// error severity.
+func (o *lintOptions) printFindings(name, uri string, findings []lint.Finding, cfg *lint.Config) error {
+	switch o.output {
+	case "text":
+		for _, f := range findings {
+			path := f.Path
+			if path == "" {
+				path = "(root)"
+			}
+			fmt.Fprintf(o.Out, "%s: %s: %s: %s [%s]\n", name, path, f.Severity, f.Message, f.Rule)
+		}
+	case "json", "sarif":
+		var v interface{} = findings
+		if o.output == "sarif" {
+			v = lint.SARIF(findings, cfg, uri)
+		} else if findings == nil {
+			v = []lint.Finding{}
+		}
+
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.Out, string(b))
+	default:
+		return fmt.Errorf("unsupported output format: %s", o.output)
+	}
+
+	for _, f := range findings {
+		if f.Severity == lint.Error {
+			return &silentError{code: exitFindings}
+		}
+	}
+
+	return nil
+}
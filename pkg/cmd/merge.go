@@ -19,12 +19,14 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
 
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
 	"github.com/spf13/cobra"
 )
 
-func init() {
+func newMergeCommand(o *rootOptions) *cobra.Command {
+	var destination, strategy string
+
 	var cmd = &cobra.Command{
 		Use:   "merge",
 		Short: "Merge a fork of a Postman resource.",
@@ -34,22 +36,26 @@ func init() {
 		Use:     "collection",
 		Aliases: []string{"co"},
 		Args:    cobra.MinimumNArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			id, err := service.MergeCollection(context.Background(), args[0], mergeCollection, mergeStrategy)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := o.service.MergeCollection(context.Background(), args[0], destination, strategy)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "error: %s\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			fmt.Println(id)
+			o.forgetNames(resources.CollectionType)
+
+			fmt.Fprintln(o.Out, id)
+
+			return nil
 		},
 	}
 
-	mergeCollectionCmd.Flags().StringVar(&mergeCollection, "to", "", "the destination collection to receive the merged changes")
+	mergeCollectionCmd.Flags().StringVar(&destination, "to", "", "the destination collection to receive the merged changes")
 	mergeCollectionCmd.MarkFlagRequired("to")
 
-	mergeCollectionCmd.Flags().StringVarP(&mergeStrategy, "strategy", "s", "", "strategy for merging fork (optional, values: deleteSource, updateSourceWithDestination)")
+	mergeCollectionCmd.Flags().StringVarP(&strategy, "strategy", "s", "", "strategy for merging fork (optional, values: deleteSource, updateSourceWithDestination)")
 
 	cmd.AddCommand(mergeCollectionCmd)
-	rootCmd.AddCommand(cmd)
+
+	return cmd
 }
@@ -0,0 +1,202 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/names"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+// nameCache returns the on-disk cache of the current context.
+func (o *rootOptions) nameCache() *names.Cache {
+	c := &names.Cache{TTL: o.cacheTTL}
+
+	home, err := homedir.Dir()
+	if err != nil || o.configContextKey == "" {
+		c.TTL = 0
+		return c
+	}
+
+	c.Dir = filepath.Join(home, ".postmanctl", "cache", o.configContextKey)
+
+	return c
+}
+
+// nameKey returns the cache key of a resource list, such as "apis" or
+// "api-versions/<api ID>".
+func (o *rootOptions) nameKey(info *resources.ResourceInfo) string {
+	key := []string{info.Plural}
+	for _, p := range info.Parents {
+		key = append(key, *o.parentValue(p.Type))
+	}
+	if q := o.listParams(info); q != nil {
+		key = append(key, "workspace="+q["workspace"])
+	}
+
+	return strings.Join(key, "/")
+}
+
+// nameIndex returns the names of resources of a type, from the cache unless
+// it is stale, --refresh is set or fresh is set.
+func (o *rootOptions) nameIndex(info *resources.ResourceInfo, fresh bool) (*names.Index, bool, error) {
+	key := o.nameKey(info)
+	cache := o.nameCache()
+
+	if ix, ok := o.nameIndexes[key]; ok && !fresh {
+		return ix, false, nil
+	}
+	if !fresh && !o.refreshNames {
+		if ix, ok := cache.Load(key); ok {
+			o.nameIndexes[key] = ix
+			return ix, false, nil
+		}
+	}
+
+	r, err := o.service.List(context.Background(), info.Type, o.listParams(info), o.parentParams(info))
+	if err != nil {
+		return nil, false, err
+	}
+
+	ix, err := names.NewIndex(r)
+	if err != nil {
+		return nil, false, err
+	}
+
+	o.nameIndexes[key] = ix
+	if err := cache.Store(key, ix); err != nil {
+		fmt.Fprintf(o.ErrOut, "warning: unable to cache %s names: %s\n", info.Title, err)
+	}
+
+	return ix, true, nil
+}
+
+// resolveName returns the ID of the resource with a name. Names that match
+// no resource are taken as IDs. A cached list missing the name is fetched
+// again, in case the resource is new.
+func (o *rootOptions) resolveName(info *resources.ResourceInfo, name string) (string, error) {
+	if !info.Supports(resources.VerbList) {
+		return name, nil
+	}
+
+	ix, fresh, err := o.nameIndex(info, false)
+	if err != nil {
+		// The name is taken as an ID when resources cannot be listed.
+		return name, nil
+	}
+
+	id, found, err := ix.Lookup(name)
+	if err == nil && !found && !fresh {
+		if ix, _, err = o.nameIndex(info, true); err != nil {
+			return name, nil
+		}
+		id, found, err = ix.Lookup(name)
+	}
+
+	if amb, ok := err.(*names.AmbiguousError); ok {
+		return "", ambiguousNameError(info, amb)
+	}
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return name, nil
+	}
+
+	return id, nil
+}
+
+// resolveType is resolveName for a type.
+func (o *rootOptions) resolveType(t resources.ResourceType, name string) (string, error) {
+	info, ok := resources.Info(t)
+	if !ok {
+		return name, nil
+	}
+
+	return o.resolveName(info, name)
+}
+
+func ambiguousNameError(info *resources.ResourceInfo, err *names.AmbiguousError) error {
+	candidates := append([]names.Entry(nil), err.Candidates...)
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+
+	out, _ := tabbedString(func(out io.Writer) error {
+		for _, c := range candidates {
+			fmt.Fprintf(out, "  %s\t%s\n", c.ID, c.Name)
+		}
+		return nil
+	})
+
+	return fmt.Errorf("%d %ss are named %q, use an ID instead:\n%s", len(candidates), info.Title, err.Name, strings.TrimRight(out, "\n"))
+}
+
+// resolveParentFlags replaces names given to --workspace, --for-api and
+// --for-api-version with IDs. APIs are looked up in the workspace and API
+// versions in the API.
+func (o *rootOptions) resolveParentFlags() error {
+	flags := []struct {
+		t     resources.ResourceType
+		value *string
+	}{
+		{resources.WorkspaceType, &o.usingWorkspace},
+		{resources.APIType, &o.forAPI},
+		{resources.APIVersionType, &o.forAPIVersion},
+	}
+
+	for _, f := range flags {
+		if *f.value == "" {
+			continue
+		}
+		if f.t == resources.APIVersionType && o.forAPI == "" {
+			continue
+		}
+
+		id, err := o.resolveType(f.t, *f.value)
+		if err != nil {
+			return err
+		}
+		*f.value = id
+	}
+
+	return nil
+}
+
+// forgetNames removes the cached names of a type after resources are
+// created, renamed or deleted.
+func (o *rootOptions) forgetNames(t resources.ResourceType) {
+	info, ok := resources.Info(t)
+	if !ok {
+		return
+	}
+
+	for key := range o.nameIndexes {
+		if key == info.Plural || strings.HasPrefix(key, info.Plural+"/") {
+			delete(o.nameIndexes, key)
+		}
+	}
+
+	if err := o.nameCache().Forget(info.Plural); err != nil {
+		fmt.Fprintf(o.ErrOut, "warning: unable to clear cached %s names: %s\n", info.Title, err)
+	}
+}
@@ -0,0 +1,257 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/printers"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// pluginPrefix starts the names of plugin executables. postmanctl-orphans
+// is run as postmanctl orphans.
+const pluginPrefix = "postmanctl-"
+
+// pluginAnnotation marks the commands running plugins with their path.
+const pluginAnnotation = "postmanctl/plugin"
+
+// plugin is an executable found on PATH.
+type plugin struct {
+	name string
+	path string
+}
+
+// findPlugins returns the plugins in the directories of a PATH list, in
+// order. A name can be found more than once, the first one is run.
+func findPlugins(pathList string) []plugin {
+	var plugins []plugin
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, f := range files {
+			if f.IsDir() || !strings.HasPrefix(f.Name(), pluginPrefix) {
+				continue
+			}
+
+			name := strings.TrimPrefix(f.Name(), pluginPrefix)
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			} else if f.Mode()&0111 == 0 {
+				continue
+			}
+
+			if name != "" {
+				plugins = append(plugins, plugin{name: name, path: filepath.Join(dir, f.Name())})
+			}
+		}
+	}
+
+	return plugins
+}
+
+// builtinCommand returns the command of the root that a plugin name would
+// collide with, if any.
+func builtinCommand(rootCmd *cobra.Command, name string) *cobra.Command {
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// addPluginCommands adds a hidden command for each plugin on PATH that
+// doesn't collide with a built-in command.
+func (o *rootOptions) addPluginCommands(rootCmd *cobra.Command) {
+	seen := map[string]bool{"help": true}
+	for _, p := range findPlugins(os.Getenv("PATH")) {
+		if seen[p.name] || builtinCommand(rootCmd, p.name) != nil {
+			continue
+		}
+		seen[p.name] = true
+
+		p := p
+		var pluginArgs []string
+		rootCmd.AddCommand(&cobra.Command{
+			Use:   p.name,
+			Short: fmt.Sprintf("Runs the %s plugin.", p.path),
+			Annotations: map[string]string{
+				configOptionalAnnotation: "true",
+				pluginAnnotation:         p.path,
+			},
+			// Arguments are passed to the plugin, except the postmanctl flags
+			// that come first.
+			DisableFlagParsing: true,
+			Hidden:             true,
+			PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+				var err error
+				pluginArgs, err = parseLeadingFlags(cmd.Root().PersistentFlags(), args)
+				if err != nil {
+					return &usageError{err: err, command: cmd.CommandPath()}
+				}
+
+				return o.preRun(cmd)
+			},
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return o.runPlugin(p, pluginArgs)
+			},
+		})
+	}
+}
+
+// parseLeadingFlags sets the flags that args start with and returns the
+// arguments that follow them, or those following "--". Flags are only
+// recognized in their long form.
+func parseLeadingFlags(flags *pflag.FlagSet, args []string) ([]string, error) {
+	for len(args) > 0 && strings.HasPrefix(args[0], "--") {
+		if args[0] == "--" {
+			return args[1:], nil
+		}
+
+		name := strings.TrimPrefix(args[0], "--")
+		value := ""
+		hasValue := false
+		if i := strings.Index(name, "="); i >= 0 {
+			name, value, hasValue = name[:i], name[i+1:], true
+		}
+
+		f := flags.Lookup(name)
+		if f == nil {
+			break
+		}
+
+		n := 1
+		if !hasValue {
+			if f.NoOptDefVal != "" {
+				value = f.NoOptDefVal
+			} else if len(args) > 1 {
+				value = args[1]
+				n = 2
+			} else {
+				return nil, fmt.Errorf("flag needs an argument: --%s", name)
+			}
+		}
+
+		if err := flags.Set(name, value); err != nil {
+			return nil, fmt.Errorf("invalid argument %q for --%s: %v", value, name, err)
+		}
+		args = args[n:]
+	}
+
+	return args, nil
+}
+
+// runPlugin runs a plugin with the streams of the command. The current
+// context is passed in POSTMANCTL_CONTEXT, POSTMANCTL_API_ROOT and
+// POSTMANCTL_API_KEY. Without a config file, the plugin runs without them.
+func (o *rootOptions) runPlugin(p plugin, args []string) error {
+	c := exec.Command(p.path, args...)
+	c.Stdin = o.In
+	c.Stdout = o.Out
+	c.Stderr = o.ErrOut
+	c.Env = os.Environ()
+	if err := o.checkConfig(); err != nil {
+		if o.configErr != nil || o.configFileFound {
+			return err
+		}
+	} else {
+		c.Env = append(c.Env,
+			"POSTMANCTL_CONTEXT="+o.configContextKey,
+			"POSTMANCTL_API_ROOT="+o.configContext.APIRoot,
+			"POSTMANCTL_API_KEY="+o.configContext.APIKey,
+		)
+	}
+
+	err := c.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+		// The plugin has reported its own errors.
+		return &silentError{code: exitErr.ExitCode()}
+	}
+
+	return err
+}
+
+func newPluginCommand(o *rootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "Work with postmanctl plugins.",
+		Long: `Work with postmanctl plugins.
+
+Plugins are executables on PATH named postmanctl-<name>, run as
+postmanctl <name>. Arguments are passed to the plugin, except postmanctl
+flags such as --context that come first, up to an optional "--". The
+context is passed in the POSTMANCTL_CONTEXT, POSTMANCTL_API_ROOT and
+POSTMANCTL_API_KEY environment variables.`,
+		Annotations: map[string]string{
+			configOptionalAnnotation: "true",
+		},
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the plugins found on PATH.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plugins := findPlugins(os.Getenv("PATH"))
+			if len(plugins) == 0 {
+				return errors.New("no plugins found on PATH")
+			}
+
+			rootCmd := cmd.Root()
+			found := make(map[string]plugin)
+			w := printers.GetNewTabWriter(o.Out)
+			fmt.Fprintln(w, "NAME\tPATH")
+			for _, p := range plugins {
+				if c := builtinCommand(rootCmd, p.name); c != nil && c.Annotations[pluginAnnotation] == "" {
+					fmt.Fprintf(o.ErrOut, "warning: %s is overshadowed by the built-in %s command\n", p.path, p.name)
+					continue
+				}
+				if first, ok := found[p.name]; ok {
+					fmt.Fprintf(o.ErrOut, "warning: %s is overshadowed by %s\n", p.path, first.path)
+					continue
+				}
+				found[p.name] = p
+
+				fmt.Fprintf(w, "%s\t%s\n", p.name, p.path)
+			}
+
+			return w.Flush()
+		},
+	}
+
+	cmd.AddCommand(listCmd)
+
+	return cmd
+}
@@ -0,0 +1,145 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/cmd"
+)
+
+func setupPlugins(t *testing.T, scripts map[string]string) (string, func()) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+
+	dir, err := ioutil.TempDir("", "postmanctl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, script := range scripts {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := os.Getenv("PATH")
+	os.Setenv("PATH", dir+string(os.PathListSeparator)+path)
+
+	return dir, func() {
+		os.Setenv("PATH", path)
+		os.RemoveAll(dir)
+	}
+}
+
+// runPlugins runs a root command with plugins enabled.
+func runPlugins(args ...string) (int, string, string) {
+	var out, errOut bytes.Buffer
+	root := cmd.NewRootCommand(cmd.IOStreams{In: strings.NewReader(""), Out: &out, ErrOut: &errOut}, nil, cmd.WithPlugins())
+	root.SetArgs(args)
+
+	return cmd.Execute(root), out.String(), errOut.String()
+}
+
+func TestPlugin(t *testing.T) {
+	dir, teardown := setupPlugins(t, map[string]string{
+		"postmanctl-orphans": `echo "$@" "$POSTMANCTL_CONTEXT" "$POSTMANCTL_API_KEY"; echo failed >&2; exit 6`,
+	})
+	defer teardown()
+	cfgFile := writeConfig(t, dir)
+
+	code, out, errOut := runPlugins("--config", cfgFile, "orphans", "--all", "-o", "json")
+	if code != 6 {
+		t.Errorf("exit code is incorrect, have: %d, want: 6", code)
+	}
+	if out != "--all -o json test key\n" {
+		t.Errorf("output is incorrect, have: %q", out)
+	}
+	if errOut != "failed\n" {
+		t.Errorf("error output is incorrect, have: %q", errOut)
+	}
+}
+
+func TestPluginFlags(t *testing.T) {
+	dir, teardown := setupPlugins(t, map[string]string{
+		"postmanctl-orphans": `echo "$@" "$POSTMANCTL_CONTEXT"`,
+	})
+	defer teardown()
+	cfgFile := writeConfig(t, dir)
+
+	tests := []struct {
+		args []string
+		code int
+		out  string
+	}{
+		{[]string{"orphans", "--config", cfgFile, "--all"}, 0, "--all test\n"},
+		{[]string{"--config=" + cfgFile, "orphans", "--", "--context", "other"}, 0, "--context other test\n"},
+		{[]string{"--config", cfgFile, "orphans", "--context", "other"}, 1, ""},
+		{[]string{"orphans", "--config"}, 2, ""},
+	}
+
+	for _, tt := range tests {
+		code, out, errOut := runPlugins(tt.args...)
+		if code != tt.code || out != tt.out {
+			t.Errorf("result of %v is incorrect, have: %d, %q, %q, want: %d, %q", tt.args, code, out, errOut, tt.code, tt.out)
+		}
+	}
+}
+
+func TestPluginList(t *testing.T) {
+	dir, teardown := setupPlugins(t, map[string]string{
+		"postmanctl-orphans": "exit 0",
+		"postmanctl-get":     "exit 0",
+		"postmanctl-noexec":  "exit 0",
+	})
+	defer teardown()
+	if err := os.Chmod(filepath.Join(dir, "postmanctl-noexec"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, out, errOut := runPlugins("plugin", "list")
+	if code != 0 {
+		t.Fatalf("exit code is incorrect, have: %d, want: 0: %s", code, errOut)
+	}
+	if !strings.Contains(out, "orphans") || strings.Contains(out, "noexec") || strings.Contains(out, "postmanctl-get") {
+		t.Errorf("output is incorrect, have: %q", out)
+	}
+	if !strings.Contains(errOut, "postmanctl-get is overshadowed by the built-in get command") {
+		t.Errorf("error output is incorrect, have: %q", errOut)
+	}
+}
+
+func TestPluginsDisabled(t *testing.T) {
+	dir, teardown := setupPlugins(t, map[string]string{
+		"postmanctl-orphans": "echo run",
+	})
+	defer teardown()
+	cfgFile := writeConfig(t, dir)
+
+	code, out, _ := run(nil, "--config", cfgFile, "orphans")
+	if code != 2 || out != "" {
+		t.Errorf("result is incorrect, have: %d, %q, want: 2, \"\"", code, out)
+	}
+}
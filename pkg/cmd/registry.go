@@ -0,0 +1,131 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/spf13/cobra"
+)
+
+type parentFlag struct {
+	name  string
+	usage string
+}
+
+// parentFlags are the flags giving the IDs of parent resources.
+var parentFlags = map[resources.ResourceType]parentFlag{
+	resources.APIType:        {"for-api", "the associated API ID (required)"},
+	resources.APIVersionType: {"for-api-version", "the associated API Version ID (required)"},
+}
+
+// parentValue returns the value of the flag giving the ID of a parent type.
+func (o *rootOptions) parentValue(t resources.ResourceType) *string {
+	switch t {
+	case resources.APIType:
+		return &o.forAPI
+	case resources.APIVersionType:
+		return &o.forAPIVersion
+	}
+
+	return nil
+}
+
+// addParentFlags registers the required flags for the parents of a type.
+func (o *rootOptions) addParentFlags(cmd *cobra.Command, info *resources.ResourceInfo) {
+	for _, p := range info.Parents {
+		f := parentFlags[p.Type]
+		cmd.Flags().StringVar(o.parentValue(p.Type), f.name, "", f.usage)
+		cmd.MarkFlagRequired(f.name)
+	}
+}
+
+// parentParams returns the URL parameters of the parent IDs given by flags.
+func (o *rootOptions) parentParams(info *resources.ResourceInfo) map[string]string {
+	params := make(map[string]string)
+	for _, p := range info.Parents {
+		params[p.Param] = *o.parentValue(p.Type)
+	}
+
+	return params
+}
+
+// listParams returns the query parameters used to list resources.
+func (o *rootOptions) listParams(info *resources.ResourceInfo) map[string]string {
+	if !info.WorkspaceFilter || o.usingWorkspace == "" {
+		return nil
+	}
+
+	return map[string]string{"workspace": o.usingWorkspace}
+}
+
+// registeredWith returns the registered types supporting a verb.
+func registeredWith(v resources.Verb) []*resources.ResourceInfo {
+	var infos []*resources.ResourceInfo
+	for _, info := range resources.Registered() {
+		if info.Supports(v) {
+			infos = append(infos, info)
+		}
+	}
+
+	return infos
+}
+
+// parseRefs parses type/name arguments for a verb. Types scoped by a
+// parent resource are addressed with their own subcommands instead.
+func parseRefs(args []string, v resources.Verb) ([]resources.Ref, error) {
+	refs, err := resources.ParseRefs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range refs {
+		if len(r.Info.Parents) > 0 {
+			return nil, fmt.Errorf("%s cannot be addressed as type/name, use the %s subcommand", r.Info.Plural, r.Info.Name)
+		}
+
+		verb := v
+		if r.Name == "" {
+			verb = resources.VerbList
+		}
+		if !r.Info.Supports(verb) {
+			return nil, fmt.Errorf("%s is not supported here", r)
+		}
+	}
+
+	return refs, nil
+}
+
+// refIDs resolves the names of refs to IDs. Names that match no resource
+// are taken as IDs.
+func (o *rootOptions) refIDs(refs []resources.Ref) ([]string, error) {
+	ids := make([]string, len(refs))
+	for i, r := range refs {
+		if r.Name == "" {
+			continue
+		}
+
+		id, err := o.resolveName(r.Info, r.Name)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,245 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/kevinswiber/postmanctl/pkg/util"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/spf13/cobra"
+)
+
+// replaceOptions are the options of the replace command.
+type replaceOptions struct {
+	*rootOptions
+	inputFile   string
+	inputReader io.Reader
+	validate    bool
+	mode        string
+	ignoreKey   defaultValue
+	diffFile    defaultValue
+}
+
+func newReplaceCommand(root *rootOptions) *cobra.Command {
+	o := &replaceOptions{rootOptions: root}
+
+	replaceCmd := &cobra.Command{
+		Use:   "replace",
+		Short: "Replace existing Postman resources.",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			switch o.mode {
+			case "force":
+			case "compare":
+				if o.inputFile == "" {
+					return &usageError{err: errors.New("compare mode only works with --filename"), command: cmd.CommandPath()}
+				}
+			default:
+				return &usageError{err: fmt.Errorf("wrong mode %s, mode must be force or compare", o.mode), command: cmd.CommandPath()}
+			}
+
+			if !isTerminal(o.In) {
+				o.inputReader = o.In
+			} else {
+				if o.inputFile == "" {
+					return errors.New("flag \"filename\" not set, use \"--filename\" or stdin")
+				}
+			}
+
+			return o.preRun(cmd)
+		},
+	}
+	replaceCmd.PersistentFlags().StringVarP(&o.inputFile, "filename", "f", "", "the filename used to replace the resource (required when not using data from stdin)")
+	replaceCmd.PersistentFlags().BoolVar(&o.validate, "validate", true, "validate collections against the collection schema before sending them")
+	replaceCmd.PersistentFlags().StringVarP(&o.mode, "mode", "m", "force", "force/compare -> force replace or compare before replace")
+	//replaceCmd.PersistentFlags().StringVarP(&diffFile, "diff", "df", "", "the file diff report. Default diff report will print to console")
+	replaceCmd.PersistentFlags().VarP(&o.ignoreKey, "ignore-key", "i", "ignore json key in response")
+	replaceCmd.PersistentFlags().VarP(&o.diffFile, "diff-file", "d", "ignore json key in response")
+
+	for _, info := range registeredWith(resources.VerbReplace) {
+		replaceCmd.AddCommand(o.generateReplaceSubcommand(info))
+	}
+
+	return replaceCmd
+}
+
+func (o *replaceOptions) generateReplaceSubcommand(info *resources.ResourceInfo) *cobra.Command {
+	cmd := cobra.Command{
+		Use:     info.Name,
+		Aliases: info.Aliases,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.replaceResource(info, args[0])
+		},
+	}
+
+	o.addParentFlags(&cmd, info)
+
+	return &cmd
+}
+
+func (o *replaceOptions) replaceResource(info *resources.ResourceInfo, resourceID string) error {
+	if o.inputReader == nil {
+		r, err := os.Open(o.inputFile)
+
+		if err != nil {
+			return err
+		}
+
+		defer r.Close()
+
+		o.inputReader = r
+	}
+
+	resourceID, err := o.resolveName(info, resourceID)
+	if err != nil {
+		return err
+	}
+
+	if o.mode == "compare" && !o.doCompare(o.inputFile, resourceID, info.Type) {
+		return nil
+	}
+
+	o.service.SkipCollectionValidation = !o.validate
+
+	urlParams := o.parentParams(info)
+	urlParams["ID"] = resourceID
+
+	id, err := o.service.ReplaceFromReader(context.Background(), info.Type, o.inputReader, urlParams)
+	if err != nil {
+		return err
+	}
+
+	o.forgetNames(info.Type)
+
+	fmt.Fprintln(o.Out, id)
+
+	return nil
+}
+
+func (o *replaceOptions) doCompare(file string, resourceID string, t resources.ResourceType) bool {
+	r, err := os.Open(file)
+
+	if err != nil {
+		fmt.Fprintln(o.ErrOut, err.Error())
+		return false
+	}
+	defer r.Close()
+	var data interface{}
+	ctx := context.Background()
+	switch t {
+	case resources.CollectionType:
+		data, err = o.service.Collection(ctx, resourceID)
+	case resources.EnvironmentType:
+		data, err = o.service.Environment(ctx, resourceID)
+	default:
+		fmt.Fprintln(o.ErrOut, "no supported type")
+		return true
+	}
+	btmp, err := json.Marshal(data)
+	if err != nil {
+		return false
+	}
+	var tmp map[string]interface{}
+	err = json.Unmarshal(btmp, &tmp)
+	if err != nil {
+		return false
+	}
+	keymap := make(map[string]int)
+	for _, v := range strings.Split(o.ignoreKey.value, ",") {
+		keymap[v] = 1
+	}
+	tmp = util.ReformatMap(tmp, true, keymap)
+	b, err := ioutil.ReadAll(r)
+
+	if err != nil {
+		return false
+	}
+
+	var v map[string]interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return false
+	}
+	v = util.ReformatMap(v, true, keymap)
+	var tt []byte
+	switch t {
+	case resources.CollectionType:
+		var old, new *resources.Collection
+		ob, _ := json.Marshal(tmp)
+		nb, _ := json.Marshal(v)
+		json.Unmarshal(ob, &old)
+		json.Unmarshal(nb, &new)
+		tt = compareCollection(old, new)
+	case resources.EnvironmentType:
+		data, err = o.service.Environment(ctx, resourceID)
+	}
+	if len(o.diffFile.value) > 0 {
+		fmt.Fprintf(o.Out, "Write diff report to file %s\n", o.diffFile.value)
+		ioutil.WriteFile(o.diffFile.value, tt, 0644)
+	} else {
+		fmt.Fprintln(o.Out, "Diff report:")
+		fmt.Fprintln(o.Out, string(tt))
+	}
+	fmt.Fprintln(o.Out, "Please check carefully before confirm replace.")
+	var confirm string
+	fmt.Fprintf(o.Out, "Are you sure to merge(Y/N):")
+	fmt.Fscanln(o.In, &confirm)
+	if strings.ToLower(confirm) == "y" {
+		return true
+	}
+	fmt.Fprintf(o.Out, "Cancel!\n")
+	return false
+}
+
+func compareCollection(old *resources.Collection, new *resources.Collection) []byte {
+	buff := strings.Builder{}
+	if !reflect.DeepEqual(old.Info, new.Info) {
+		buff.WriteString("Diff in info:\n")
+		tmp, _ := json.MarshalIndent(old.Info, "", " \t")
+		buff.Write(tmp)
+		buff.WriteString("\n")
+		tmp, _ = json.MarshalIndent(new.Info, "", " \t")
+		buff.Write(tmp)
+		buff.WriteString("\n")
+	}
+	mapOld := make(map[string]bool)
+	for _, v := range old.Item {
+		s := v.(map[string]interface{})
+		if _, ok := s["name"]; ok {
+			mapOld[s["name"].(string)] = true
+		}
+	}
+	mapNew := make(map[string]bool)
+	for _, v := range new.Item {
+		s := v.(map[string]interface{})
+		if _, ok := s["name"]; ok {
+			mapNew[s["name"].(string)] = true
+		}
+	}
+
+	//for k,
+
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/trash"
+	"github.com/spf13/cobra"
+)
+
+// readOnlyKeys are the fields set by the API when a resource is
+// created.
+var readOnlyKeys = []string{"id", "uid", "owner", "createdAt", "updatedAt"}
+
+func newRestoreCommand(o *rootOptions) *cobra.Command {
+	restoreCmd := &cobra.Command{
+		Use:   "restore [trash-id...]",
+		Short: "Recreate Postman resources deleted with postmanctl.",
+		Long: `Recreate Postman resources deleted with postmanctl from the trash.
+
+With no arguments, the trash of the current context is listed. Restored
+resources are created again, so they get new IDs, and are removed from the
+trash.`,
+		Example: `  postmanctl restore
+  postmanctl restore 20201102-151405-3fa9c2 --workspace Payments`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bin, err := o.trashBin()
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 0 {
+				entries, err := bin.List()
+				if err != nil {
+					return err
+				}
+
+				o.printTable(entries)
+				return nil
+			}
+
+			for _, id := range args {
+				if err := o.restoreEntry(bin, id); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	restoreCmd.Flags().StringVar(&o.usingWorkspace, "workspace", "", "the workspace to restore the resources to")
+
+	return restoreCmd
+}
+
+func (o *rootOptions) restoreEntry(bin *trash.Bin, id string) error {
+	e, err := bin.Get(id)
+	if err != nil {
+		return err
+	}
+
+	info, ok := resources.Lookup(e.Type)
+	if !ok || !info.Supports(resources.VerbCreate) {
+		return fmt.Errorf("%s resources cannot be restored", e.Type)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(e.Resource, &m); err != nil {
+		return fmt.Errorf("trash entry %s: %s", id, err)
+	}
+	for _, k := range readOnlyKeys {
+		delete(m, k)
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	var queryParams map[string]string
+	if o.usingWorkspace != "" {
+		queryParams = map[string]string{"workspace": o.usingWorkspace}
+	}
+
+	newID, err := o.service.CreateFromReader(context.Background(), info.Type, bytes.NewReader(b), queryParams, e.Params)
+	if err != nil {
+		return err
+	}
+
+	o.forgetNames(info.Type)
+	if err := bin.Remove(id); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(o.Out, newID)
+
+	return nil
+}
@@ -0,0 +1,333 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cmd implements the postmanctl commands. NewRootCommand builds the
+// command tree, which can be run as is with Execute, or changed to add,
+// remove or wrap commands before running it.
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kevinswiber/postmanctl/internal/runtime/config"
+	"github.com/kevinswiber/postmanctl/pkg/names"
+	"github.com/kevinswiber/postmanctl/pkg/sdk"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+	"github.com/spf13/cobra"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/viper"
+)
+
+// IOStreams are the streams commands read input from and write output and
+// errors to.
+type IOStreams struct {
+	In     io.Reader
+	Out    io.Writer
+	ErrOut io.Writer
+}
+
+// ServiceFactory creates the service used to reach the Postman API with the
+// API root and key of the current context.
+type ServiceFactory func(apiRoot *url.URL, apiKey string) (*sdk.Service, error)
+
+// DefaultServiceFactory creates services using http.DefaultClient, within
+// the rate limit of the Postman API.
+func DefaultServiceFactory(apiRoot *url.URL, apiKey string) (*sdk.Service, error) {
+	options := client.NewOptions(apiRoot, apiKey, http.DefaultClient)
+	// The Postman API allows 300 requests per minute for each API key.
+	options.Limiter = client.NewRateLimiter(300, time.Minute)
+
+	return sdk.NewService(options), nil
+}
+
+// rootOptions is the state shared by the commands of a root command: its
+// streams, configuration and persistent flags.
+type rootOptions struct {
+	IOStreams
+	factory ServiceFactory
+	viper   *viper.Viper
+
+	cfgFile          string
+	configContextKey string
+	refreshNames     bool
+	cacheTTL         time.Duration
+	errorFormat      string
+	plugins          bool
+
+	// The parent resources and workspace of the running command, given by
+	// ID or name and replaced by IDs before it runs.
+	forAPI         string
+	forAPIVersion  string
+	usingWorkspace string
+
+	loaded             bool
+	cfg                *config.Config
+	configContext      config.Context
+	configErr          error
+	configFileFound    bool
+	configContextFound bool
+	configContextSet   bool
+	service            *sdk.Service
+
+	// nameIndexes holds the indexes loaded by this command, by cache key.
+	nameIndexes map[string]*names.Index
+}
+
+// configOptionalAnnotation marks commands that can run without a configured
+// context, such as those working on local files. They call checkConfig
+// before talking to the Postman API.
+const configOptionalAnnotation = "postmanctl/config-optional"
+
+// RootOption changes a command built by NewRootCommand.
+type RootOption func(o *rootOptions)
+
+// WithPlugins adds the plugin command and runs the executables named
+// postmanctl-<name> on PATH as commands. PATH is searched when the command
+// is built.
+func WithPlugins() RootOption {
+	return func(o *rootOptions) {
+		o.plugins = true
+	}
+}
+
+// NewRootCommand returns the postmanctl command with all of its subcommands.
+// They read from and write to streams, using the standard streams for those
+// that are nil, and reach the Postman API with a service created by
+// factory, DefaultServiceFactory when it is nil. Plugins are only run with
+// WithPlugins.
+//
+// Root commands share no state, so several can run at once, but each runs a
+// single command at a time.
+func NewRootCommand(streams IOStreams, factory ServiceFactory, opts ...RootOption) *cobra.Command {
+	if streams.In == nil {
+		streams.In = os.Stdin
+	}
+	if streams.Out == nil {
+		streams.Out = os.Stdout
+	}
+	if streams.ErrOut == nil {
+		streams.ErrOut = os.Stderr
+	}
+	if factory == nil {
+		factory = DefaultServiceFactory
+	}
+
+	o := &rootOptions{
+		IOStreams:   streams,
+		factory:     factory,
+		viper:       viper.New(),
+		nameIndexes: make(map[string]*names.Index),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	rootCmd := &cobra.Command{
+		Use:   "postmanctl",
+		Short: "Controls the Postman API",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.preRun(cmd)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	rootCmd.SetIn(streams.In)
+	rootCmd.SetOut(streams.Out)
+	rootCmd.SetErr(streams.ErrOut)
+
+	rootCmd.PersistentFlags().StringVar(&o.cfgFile, "config", "", "config file (default is $HOME/.postmanctl.yaml)")
+	rootCmd.PersistentFlags().StringVar(&o.configContextKey, "context", "", "context to use, overrides the current context in the config file")
+	rootCmd.PersistentFlags().BoolVar(&o.refreshNames, "refresh", false, "refresh the cached resource names used to find IDs")
+	rootCmd.PersistentFlags().DurationVar(&o.cacheTTL, "cache-ttl", 5*time.Minute, "how long resource names are cached in $HOME/.postmanctl/cache, 0 to disable")
+	rootCmd.PersistentFlags().StringVar(&o.errorFormat, "error-format", "text", "the format of errors printed to stderr (text, json)")
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &usageError{err: err, command: cmd.CommandPath()}
+	})
+
+	rootCmd.AddCommand(
+		newConfigCommand(o),
+		newConvertCommand(o),
+		newCreateCommand(o),
+		newDeleteCommand(o),
+		newDescribeCommand(o),
+		newDiffCommand(o),
+		newEditCommand(o),
+		newEnvCommand(o),
+		newForkCommand(o),
+		newGetCommand(o),
+		newLintCommand(o),
+		newMergeCommand(o),
+		newReplaceCommand(o),
+		newRestoreCommand(o),
+		newRunCommand(o),
+		newScanCommand(o),
+		newValidateCommand(o),
+		newVersionCommand(o),
+	)
+	if o.plugins {
+		rootCmd.AddCommand(newPluginCommand(o))
+		o.addPluginCommands(rootCmd)
+	}
+
+	return rootCmd
+}
+
+// Execute runs a command built by NewRootCommand. It prints the error of
+// the command, if any, and returns the exit code of the process.
+func Execute(rootCmd *cobra.Command) int {
+	cmd, err := rootCmd.ExecuteC()
+	if err == nil {
+		return exitOK
+	}
+
+	format, _ := rootCmd.PersistentFlags().GetString("error-format")
+	err = asUsageError(err, cmd.CommandPath())
+	printError(rootCmd.ErrOrStderr(), err, format)
+
+	return exitCode(err)
+}
+
+// preRun checks the configuration and resolves the names given to parent
+// flags. Commands replacing the PersistentPreRunE of the root call it too.
+func (o *rootOptions) preRun(cmd *cobra.Command) error {
+	if o.errorFormat != "text" && o.errorFormat != "json" {
+		return &usageError{err: fmt.Errorf("unsupported error format: %s", o.errorFormat), command: cmd.CommandPath()}
+	}
+
+	o.loadConfig()
+	if o.configErr != nil {
+		return o.configErr
+	}
+
+	for c := cmd; c != nil; c = c.Parent() {
+		if _, ok := c.Annotations[configOptionalAnnotation]; ok {
+			return nil
+		}
+	}
+
+	if err := o.checkConfig(); err != nil {
+		return err
+	}
+
+	return o.resolveParentFlags()
+}
+
+// checkConfig returns an error when no usable context has been configured.
+func (o *rootOptions) checkConfig() error {
+	o.loadConfig()
+
+	if o.configErr != nil {
+		return o.configErr
+	} else if !o.configContextSet {
+		return errors.New("context is not set, run: postmanctl config use-context --help")
+	} else if !o.configContextFound {
+		return fmt.Errorf("context '%s' is not configured, run: postmanctl config set-context --help", o.configContextKey)
+	} else if !o.configFileFound {
+		return errors.New("config file not found at $HOME/.postmanctl.yaml, run: postmanctl config set-context --help")
+	}
+
+	return nil
+}
+
+// loadConfig reads the config file and creates the service of the current
+// context, once. Errors are kept for checkConfig.
+func (o *rootOptions) loadConfig() {
+	if o.loaded {
+		return
+	}
+	o.loaded = true
+	o.configFileFound = true
+	o.configContextFound = true
+	o.configContextSet = true
+
+	if err := o.readConfig(); err != nil {
+		o.configErr = err
+		return
+	}
+
+	u, err := url.Parse(o.configContext.APIRoot)
+	if err != nil {
+		o.configErr = err
+		return
+	}
+
+	o.service, o.configErr = o.factory(u, o.configContext.APIKey)
+}
+
+// readConfig reads in config file and ENV variables if set.
+func (o *rootOptions) readConfig() error {
+	if o.cfgFile != "" {
+		// Use config file from the flag.
+		o.viper.SetConfigFile(o.cfgFile)
+	} else {
+		// Find home directory.
+		home, err := homedir.Dir()
+		if err != nil {
+			return err
+		}
+
+		// Search config in home directory with name ".postmanctl" (without extension).
+		o.viper.AddConfigPath(home)
+		o.viper.SetConfigName(".postmanctl")
+	}
+
+	o.viper.SetEnvPrefix("POSTMANCTL_")
+	o.viper.AutomaticEnv() // read in environment variables that match
+
+	// If a config file is found, read it in.
+	if err := o.viper.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return err
+		}
+		o.configFileFound = false
+	}
+
+	o.cfg = &config.Config{}
+	if err := o.viper.Unmarshal(o.cfg); err != nil {
+		return err
+	}
+
+	if !o.configFileFound {
+		return nil
+	}
+
+	if o.configContextKey == "" {
+		o.configContextKey = o.cfg.CurrentContext
+	}
+
+	// viper keys are case-insensitive
+	if val, ok := o.cfg.Contexts[strings.ToLower(o.configContextKey)]; ok {
+		o.configContext = val
+		if len(o.configContext.APIRoot) == 0 {
+			o.configContext.APIRoot = "https://api.postman.com"
+		}
+	} else {
+		o.configContextFound = false
+		if o.cfg.CurrentContext == "" {
+			o.configContextSet = false
+		}
+	}
+
+	return nil
+}
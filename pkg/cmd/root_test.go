@@ -0,0 +1,151 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/cmd"
+	"github.com/kevinswiber/postmanctl/pkg/sdk"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+)
+
+func writeConfig(t *testing.T, dir string) string {
+	path := filepath.Join(dir, "postmanctl.yaml")
+	config := "currentContext: test\ncontexts:\n  test:\n    apiKey: key\n"
+	if err := ioutil.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+// serverFactory returns a factory of services reaching a test server.
+func serverFactory(server *httptest.Server) cmd.ServiceFactory {
+	return func(apiRoot *url.URL, apiKey string) (*sdk.Service, error) {
+		u, _ := url.Parse(server.URL)
+
+		return sdk.NewService(client.NewOptions(u, apiKey, http.DefaultClient)), nil
+	}
+}
+
+func run(factory cmd.ServiceFactory, args ...string) (int, string, string) {
+	var out, errOut bytes.Buffer
+	root := cmd.NewRootCommand(cmd.IOStreams{In: strings.NewReader(""), Out: &out, ErrOut: &errOut}, factory)
+	root.SetArgs(args)
+
+	return cmd.Execute(root), out.String(), errOut.String()
+}
+
+func TestVersion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "postmanctl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfgFile := writeConfig(t, dir)
+
+	code, out, _ := run(nil, "--config", cfgFile, "version", "-o", "json")
+	if code != 0 {
+		t.Fatalf("exit code is incorrect, have: %d, want: 0", code)
+	}
+
+	var v struct{ Version string }
+	if err := json.Unmarshal([]byte(out), &v); err != nil || v.Version == "" {
+		t.Errorf("version output is incorrect: %q", out)
+	}
+}
+
+func TestConcurrentRoots(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/environments", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"environments":[{"id":"e1","uid":"u-e1","name":"staging"},{"id":"e2","uid":"u-e2","name":"qa"}]}`)
+	})
+	mux.HandleFunc("/environments/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/environments/")
+		fmt.Fprintf(w, `{"environment":{"id":%q,"name":"env-%s","values":[]}}`, id, id)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "postmanctl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfgFile := writeConfig(t, dir)
+
+	// The API root of the config is replaced by the test server.
+	factory := func(apiRoot *url.URL, apiKey string) (*sdk.Service, error) {
+		if apiKey != "key" {
+			t.Errorf("API key is incorrect, have: %s, want: key", apiKey)
+		}
+
+		return serverFactory(server)(apiRoot, apiKey)
+	}
+
+	// Names are resolved to UIDs, other arguments are taken as IDs.
+	tests := map[string]string{"staging": "u-e1", "qa": "u-e2", "e3": "e3"}
+
+	var wg sync.WaitGroup
+	for name, id := range tests {
+		wg.Add(1)
+		go func(name, id string) {
+			defer wg.Done()
+
+			code, out, errOut := run(factory, "--config", cfgFile, "--cache-ttl", "0", "get", "environment", name, "-o", "json")
+			if code != 0 {
+				t.Errorf("exit code of %s is incorrect, have: %d, want: 0: %s", name, code, errOut)
+				return
+			}
+
+			var env struct{ ID string }
+			if err := json.Unmarshal([]byte(out), &env); err != nil || env.ID != id {
+				t.Errorf("output of %s is incorrect, have: %q, want ID: %s", name, out, id)
+			}
+		}(name, id)
+	}
+	wg.Wait()
+}
+
+func TestMissingContext(t *testing.T) {
+	dir, err := ioutil.TempDir("", "postmanctl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfgFile := writeConfig(t, dir)
+
+	code, out, errOut := run(nil, "--config", cfgFile, "--context", "other", "get", "environments")
+	if code != 1 {
+		t.Errorf("exit code is incorrect, have: %d, want: 1", code)
+	}
+	if out != "" || !strings.Contains(errOut, "context 'other' is not configured") {
+		t.Errorf("output is incorrect, have: %q, %q", out, errOut)
+	}
+}
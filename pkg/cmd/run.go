@@ -20,12 +20,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 
 	"github.com/spf13/cobra"
 )
 
-func init() {
+func newRunCommand(o *rootOptions) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "run",
 		Short: "Execute runnable Postman resources.",
@@ -35,23 +34,24 @@ func init() {
 		Use:     "monitor",
 		Aliases: []string{"mon"},
 		Args:    cobra.MinimumNArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			msg, err := service.RunMonitor(context.Background(), args[0])
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg, err := o.service.RunMonitor(context.Background(), args[0])
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "error: %s\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			b, err := json.MarshalIndent(&msg, "", " \t")
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "error: %s\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			fmt.Println(string(b))
+			fmt.Fprintln(o.Out, string(b))
+
+			return nil
 		},
 	}
 
 	cmd.AddCommand(runMonitorCmd)
-	rootCmd.AddCommand(cmd)
+
+	return cmd
 }
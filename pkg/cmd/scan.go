@@ -0,0 +1,250 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+	"github.com/kevinswiber/postmanctl/pkg/scan"
+	"github.com/spf13/cobra"
+)
+
+// scanOptions are the options of the scan command.
+type scanOptions struct {
+	*rootOptions
+	allowlist string
+	output    string
+}
+
+func newScanCommand(root *rootOptions) *cobra.Command {
+	o := &scanOptions{rootOptions: root}
+
+	scanCmd := &cobra.Command{
+		Use:   "scan",
+		Short: "Scan Postman resources for problems.",
+		Annotations: map[string]string{
+			configOptionalAnnotation: "true",
+		},
+	}
+
+	scanSecretsCmd := &cobra.Command{
+		Use:   "secrets [file...]",
+		Short: "Find API keys and tokens in environments and collections",
+		Long: `Find API keys and tokens in environments and collections.
+
+Scans environment values, collection variables, auth attributes, headers,
+URLs and bodies for AWS keys, GitHub and Slack tokens, JWTs, bearer tokens
+and private keys. Values stored under names such as "apiKey" or "password"
+are also reported when they look random.
+
+Every environment and collection in the Postman API is scanned, or only
+those in the workspace given with --workspace. Exported collection and
+environment files can be scanned instead by passing them as arguments.
+
+Secrets are masked in the output. Findings can be ignored with a YAML
+allowlist of fingerprints, rules and location patterns:
+
+  fingerprints:
+    - 9f86d081884c
+  rules:
+    - high-entropy
+  paths:
+    - environment/Local/*
+
+The command exits with status 8 when a secret is found.`,
+		Example: `  postmanctl scan secrets --workspace <workspace-id>
+  postmanctl scan secrets ./orders.json ./staging.json -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.scanSecrets(args)
+		},
+	}
+
+	scanSecretsCmd.Flags().StringVarP(&o.usingWorkspace, "workspace", "w", "", "only scan the resources in this workspace")
+	scanSecretsCmd.Flags().StringVar(&o.allowlist, "allowlist", "", "a YAML file of findings to ignore")
+	scanSecretsCmd.Flags().StringVarP(&o.output, "output", "o", "text", "output format (text, json)")
+
+	scanCmd.AddCommand(scanSecretsCmd)
+
+	return scanCmd
+}
+
+func (o *scanOptions) scanSecrets(args []string) error {
+	var allow *scan.Allowlist
+	if o.allowlist != "" {
+		f, err := os.Open(o.allowlist)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		if allow, err = scan.ReadAllowlist(f); err != nil {
+			return fmt.Errorf("%s: %s", o.allowlist, err)
+		}
+	}
+
+	var findings []scan.Finding
+	var err error
+	if len(args) > 0 {
+		findings, err = o.scanFiles(args)
+	} else {
+		findings, err = o.scanService()
+	}
+	if err != nil {
+		return err
+	}
+
+	findings = allow.Filter(findings)
+
+	switch o.output {
+	case "text":
+		if len(findings) == 0 {
+			fmt.Fprintln(o.Out, "no secrets found")
+			break
+		}
+
+		out, _ := tabbedString(func(out io.Writer) error {
+			fmt.Fprintln(out, "RESOURCE\tNAME\tPATH\tRULE\tVALUE\tFINGERPRINT")
+			for _, f := range findings {
+				fmt.Fprintf(out, "%s\t%s\t%s\t%s\t%s\t%s\n", f.Resource, f.Name, f.Path, f.Rule, f.Value, f.Fingerprint)
+			}
+			return nil
+		})
+		fmt.Fprint(o.Out, out)
+	case "json":
+		v := findings
+		if v == nil {
+			v = []scan.Finding{}
+		}
+
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.Out, string(b))
+	default:
+		return fmt.Errorf("unsupported output format: %s", o.output)
+	}
+
+	if len(findings) > 0 {
+		return &silentError{code: exitFindings}
+	}
+
+	return nil
+}
+
+// scanFiles scans exported collection and environment files.
+func (o *scanOptions) scanFiles(names []string) ([]scan.Finding, error) {
+	var findings []scan.Finding
+	for _, name := range names {
+		b, err := ioutil.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		var probe map[string]json.RawMessage
+		if err := json.Unmarshal(b, &probe); err != nil {
+			return nil, fmt.Errorf("%s: %s", name, err)
+		}
+
+		_, isEnvironment := probe["values"]
+		if _, ok := probe["environment"]; ok {
+			isEnvironment = true
+		}
+
+		if isEnvironment {
+			env, err := o.loadEnvironment(name)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", name, err)
+			}
+			findings = append(findings, scan.Environment(env)...)
+		} else {
+			c, err := convert.ReadCollection(bytes.NewReader(b))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", name, err)
+			}
+			findings = append(findings, scan.Collection(c)...)
+		}
+	}
+
+	return findings, nil
+}
+
+// scanService scans the environments and collections in the Postman API,
+// or in the workspace given with --workspace.
+func (o *scanOptions) scanService() ([]scan.Finding, error) {
+	if err := o.checkConfig(); err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+
+	var environments, collections []string
+	if o.usingWorkspace != "" {
+		ws, err := o.service.Workspace(ctx, o.usingWorkspace)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range ws.Environments {
+			environments = append(environments, e.UID)
+		}
+		for _, c := range ws.Collections {
+			collections = append(collections, c.UID)
+		}
+	} else {
+		envs, err := o.service.Environments(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range *envs {
+			environments = append(environments, e.UID)
+		}
+
+		cols, err := o.service.Collections(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range *cols {
+			collections = append(collections, c.UID)
+		}
+	}
+
+	var findings []scan.Finding
+	for _, id := range environments {
+		env, err := o.service.Environment(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, scan.Environment(env)...)
+	}
+
+	for _, id := range collections {
+		c, err := o.service.Collection(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, scan.Collection(c)...)
+	}
+
+	return findings, nil
+}
@@ -0,0 +1,176 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/kevinswiber/postmanctl/pkg/filter"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/spf13/cast"
+)
+
+// describedTypes have descriptions only in single resources, which are
+// fetched when a selector uses tags.
+var describedTypes = map[resources.ResourceType]bool{
+	resources.CollectionType: true,
+	resources.WorkspaceType:  true,
+}
+
+// selectResources returns the items of a list matching a selector, as a
+// list of the same type.
+func (o *rootOptions) selectResources(info *resources.ResourceInfo, selector string, list interface{}) (interface{}, error) {
+	if selector == "" {
+		return list, nil
+	}
+
+	sel, err := filter.Parse(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var members map[string][]string
+	if sel.UsesWorkspaces() {
+		if members, err = o.workspaceMembers(info); err != nil {
+			return nil, err
+		}
+	}
+
+	v := reflect.ValueOf(list).Elem()
+	selected := reflect.MakeSlice(v.Type(), 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		obj, err := o.selectorObject(info, v.Index(i).Interface(), sel.UsesTags(), members)
+		if err != nil {
+			return nil, err
+		}
+
+		if sel.Matches(obj) {
+			selected = reflect.Append(selected, v.Index(i))
+		}
+	}
+
+	r := reflect.New(v.Type())
+	r.Elem().Set(selected)
+
+	return r.Interface(), nil
+}
+
+// selectorObject returns the fields of a list item used by selectors.
+func (o *rootOptions) selectorObject(info *resources.ResourceInfo, item interface{}, withDescription bool, members map[string][]string) (filter.Object, error) {
+	m, err := toMap(item)
+	if err != nil {
+		return filter.Object{}, err
+	}
+
+	id := cast.ToString(m["uid"])
+	if id == "" {
+		id = cast.ToString(m["id"])
+	}
+
+	obj := filter.Object{
+		Name:        cast.ToString(m["name"]),
+		Owner:       cast.ToString(m["owner"]),
+		Description: description(m),
+		Fork:        m["fork"] != nil,
+		Workspaces:  members[id],
+	}
+
+	if withDescription && describedTypes[info.Type] {
+		r, err := o.service.Get(context.Background(), info.Type, o.parentParams(info), id)
+		if err != nil {
+			return obj, err
+		}
+
+		if m, err = toMap(r); err != nil {
+			return obj, err
+		}
+		obj.Description = description(m)
+	}
+
+	return obj, nil
+}
+
+// description returns the description of a resource, which is in the info
+// of collections and may have a content type.
+func description(m map[string]interface{}) string {
+	d := m["description"]
+	if info, ok := m["info"].(map[string]interface{}); ok && d == nil {
+		d = info["description"]
+	}
+
+	if content, ok := d.(map[string]interface{}); ok {
+		return cast.ToString(content["content"])
+	}
+
+	return cast.ToString(d)
+}
+
+// workspaceMembers maps the IDs of resources of a type to the IDs and names
+// of the workspaces containing them.
+func (o *rootOptions) workspaceMembers(info *resources.ResourceInfo) (map[string][]string, error) {
+	ctx := context.Background()
+
+	workspaces, err := o.service.Workspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string][]string)
+	for _, w := range *workspaces {
+		var items interface{}
+		switch {
+		case info.Type == resources.WorkspaceType:
+			items = []resources.WorkspaceListItem{w}
+		case info.WorkspaceFilter:
+			items, err = o.service.List(ctx, info.Type, map[string]string{"workspace": w.ID}, nil)
+		default:
+			var ws *resources.Workspace
+			if ws, err = o.service.Workspace(ctx, w.ID); err == nil {
+				m, _ := toMap(ws)
+				items = m[info.Plural]
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := json.Marshal(items)
+		if err != nil {
+			return nil, err
+		}
+
+		var ids []struct {
+			ID  string `json:"id"`
+			UID string `json:"uid"`
+		}
+		if err := json.Unmarshal(b, &ids); err != nil {
+			return nil, err
+		}
+
+		for _, i := range ids {
+			for _, id := range []string{i.ID, i.UID} {
+				if id != "" {
+					members[id] = append(members[id], w.ID, w.Name)
+				}
+			}
+		}
+	}
+
+	return members, nil
+}
@@ -0,0 +1,188 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/template"
+
+	sprig "github.com/Masterminds/sprig/v3"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/printers"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// printGetOutput prints resources in the format given by --output, as a
+// table by default.
+func (o *getOptions) printGetOutput(r interface{}) error {
+	if r == nil {
+		return nil
+	}
+	resource := r
+	var list []interface{}
+	b, _ := json.Marshal(r)
+	err := json.Unmarshal(b, &list)
+	if err == nil && len(list) == 1 {
+		r = list[0]
+	}
+	if o.output.value == "json" {
+		t, err := json.MarshalIndent(&r, "", " \t")
+		if err != nil {
+			return err
+		}
+		if len(o.file.value) > 0 {
+			fmt.Fprintf(o.Out, "write to file %s\n", o.file.value)
+			return ioutil.WriteFile(o.file.value, t, 0644)
+		}
+		fmt.Fprintln(o.Out, string(t))
+	} else if strings.HasPrefix(o.output.value, "jsonpath=") {
+		tmpl := o.output.value[9:]
+		j := jsonpath.New("out")
+		if err := j.Parse(tmpl); err != nil {
+			return err
+		}
+
+		queryObj, err := toQueryObject(r)
+		if err != nil {
+			return err
+		}
+
+		buf := bytes.NewBuffer(nil)
+		if err := j.Execute(buf, queryObj); err != nil {
+			return err
+		}
+
+		if len(o.file.value) > 0 {
+			fmt.Fprintf(o.Out, "write to file %s\n", o.file.value)
+			return ioutil.WriteFile(o.file.value, buf.Bytes(), 0644)
+		}
+		fmt.Fprintln(o.Out, buf)
+	} else if strings.HasPrefix(o.output.value, "go-template-file=") {
+		templateFile := o.output.value[17:]
+		tmpl, err := ioutil.ReadFile(templateFile)
+		if err != nil {
+			return err
+		}
+
+		h, err := template.New("Text Template").Funcs(sprig.TxtFuncMap()).Parse(string(tmpl))
+		if err != nil {
+			return err
+		}
+
+		queryObj, err := toQueryObject(r)
+		if err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err := h.Execute(&buf, queryObj); err != nil {
+			return err
+		}
+		if len(o.file.value) > 0 {
+			fmt.Fprintf(o.Out, "write to file %s\n", o.file.value)
+			return ioutil.WriteFile(o.file.value, buf.Bytes(), 0644)
+		}
+		fmt.Fprintln(o.Out, buf.String())
+	} else if o.output.value == "curl" {
+		return &usageError{err: errors.New("curl output is only supported for requests"), command: "postmanctl get request"}
+	} else if isEnvironmentFormat(o.output.value) {
+		return &usageError{err: fmt.Errorf("%s output is only supported for individual environments", o.output.value), command: "postmanctl get environments"}
+	} else {
+		f, err := tableFormatter(resource)
+		if err != nil {
+			return err
+		}
+		o.printTable(f)
+	}
+
+	return nil
+}
+
+// table is a table of rows with the same columns.
+type table struct {
+	cols []string
+	rows []interface{}
+}
+
+// Format returns column headers and values for the table.
+func (t table) Format() ([]string, []interface{}) {
+	return t.cols, t.rows
+}
+
+// tableFormatter returns a formatter printing a resource, or a list of
+// resources of the same type, as one table.
+func tableFormatter(r interface{}) (resources.Formatter, error) {
+	switch v := r.(type) {
+	case resources.Formatter:
+		return v, nil
+	case []interface{}:
+		var t table
+		for _, item := range v {
+			f, ok := item.(resources.Formatter)
+			if !ok {
+				return nil, fmt.Errorf("%T cannot be printed as a table", item)
+			}
+			cols, rows := f.Format()
+			t.cols = cols
+			t.rows = append(t.rows, rows...)
+		}
+		return t, nil
+	}
+
+	return nil, fmt.Errorf("%T cannot be printed as a table", r)
+}
+
+// toQueryObject converts resources to the generic values used by templates.
+func toQueryObject(r interface{}) (interface{}, error) {
+	t, err := json.Marshal(&r)
+	if err != nil {
+		return nil, err
+	}
+
+	var queryObj interface{} = map[string]interface{}{}
+	if err := json.Unmarshal(t, &queryObj); err != nil {
+		return nil, err
+	}
+
+	return queryObj, nil
+}
+
+func (o *rootOptions) printTable(f resources.Formatter) {
+	w := printers.GetNewTabWriter(o.Out)
+	printer := printers.NewTablePrinter(printers.PrintOptions{})
+	printer.PrintResource(f, w)
+}
+
+// isTerminal reports whether r is a terminal. Readers other than files,
+// such as those given to NewRootCommand by tests, are not.
+func isTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+
+	stat, err := f.Stat()
+
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,115 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/kevinswiber/postmanctl/pkg/validate"
+	"github.com/spf13/cobra"
+)
+
+// validateOptions are the options of the validate command.
+type validateOptions struct {
+	*rootOptions
+	inputFile   string
+	inputReader io.Reader
+}
+
+func newValidateCommand(root *rootOptions) *cobra.Command {
+	o := &validateOptions{rootOptions: root}
+
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate Postman resources before creating or replacing them.",
+		Annotations: map[string]string{
+			configOptionalAnnotation: "true",
+		},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if !isTerminal(o.In) {
+				o.inputReader = o.In
+			} else {
+				if o.inputFile == "" {
+					return errors.New("flag \"filename\" not set, use \"--filename\" or stdin")
+				}
+			}
+
+			return nil
+		},
+	}
+	validateCmd.PersistentFlags().StringVarP(&o.inputFile, "filename", "f", "", "the filename to validate (required when not using data from stdin)")
+
+	validateCollectionCmd := &cobra.Command{
+		Use:     "collection",
+		Aliases: []string{"co"},
+		Short:   "Validate a collection against the v2.1.0 collection schema",
+		Long: `Validate a collection against the v2.1.0 collection schema.
+
+Errors are reported with the line and column of the invalid value and its
+JSON Pointer. The same check runs before "create collection" and
+"replace collection" unless --validate=false is given.`,
+		Example: "  postmanctl validate collection -f collection.json",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.validateCollection()
+		},
+	}
+
+	validateCmd.AddCommand(validateCollectionCmd)
+
+	return validateCmd
+}
+
+func (o *validateOptions) validateCollection() error {
+	name := o.inputFile
+	if o.inputReader == nil {
+		r, err := os.Open(o.inputFile)
+		if err != nil {
+			return err
+		}
+
+		defer r.Close()
+
+		o.inputReader = r
+	} else {
+		name = "<stdin>"
+	}
+
+	b, err := ioutil.ReadAll(o.inputReader)
+	if err != nil {
+		return err
+	}
+
+	errs := validate.Collection(b)
+	if errs == nil {
+		fmt.Fprintf(o.Out, "%s: valid\n", name)
+		return nil
+	}
+
+	for _, e := range errs {
+		pointer := e.Pointer
+		if pointer == "" {
+			pointer = "(root)"
+		}
+		fmt.Fprintf(o.ErrOut, "%s:%d:%d: %s: %s\n", name, e.Line, e.Column, pointer, e.Message)
+	}
+
+	return &silentError{code: exitValidation}
+}
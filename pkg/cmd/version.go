@@ -20,7 +20,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
 
 	"github.com/spf13/cobra"
 )
@@ -37,8 +36,6 @@ type versionInfo struct {
 	Date    string `json:"date,omitempty"`
 }
 
-var versionOutputFormat VersionOutputFormatValue
-
 // VersionOutputFormatValue is a custom Value for the output flag that validates.
 type VersionOutputFormatValue struct {
 	value string
@@ -64,38 +61,41 @@ func (o *VersionOutputFormatValue) Type() string {
 	return "string"
 }
 
-func init() {
+func newVersionCommand(o *rootOptions) *cobra.Command {
+	var output VersionOutputFormatValue
+
 	var cmd = &cobra.Command{
 		Use:   "version",
 		Short: "Print version information for postmanctl.",
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			v := versionInfo{
 				Version: version,
 				Commit:  commit,
 				Date:    date,
 			}
 
-			f := versionOutputFormat.value
+			f := output.value
 			if f == "short" {
-				fmt.Printf("Version: %s\n", v.Version)
-				fmt.Printf("Commit: %s\n", v.Commit)
-				fmt.Printf("Date: %s\n", v.Date)
+				fmt.Fprintf(o.Out, "Version: %s\n", v.Version)
+				fmt.Fprintf(o.Out, "Commit: %s\n", v.Commit)
+				fmt.Fprintf(o.Out, "Date: %s\n", v.Date)
 			} else if f == "json" {
 				p, err := json.MarshalIndent(&v, "", "\t")
 
 				if err != nil {
-					fmt.Fprintf(os.Stderr, "error: %s\n", err)
-					os.Exit(1)
-					return
+					return err
 				}
 
-				fmt.Println(string(p))
+				fmt.Fprintln(o.Out, string(p))
 			} else {
-				fmt.Printf("%#v\n", v)
+				fmt.Fprintf(o.Out, "%#v\n", v)
 			}
+
+			return nil
 		},
 	}
 
-	cmd.Flags().VarP(&versionOutputFormat, "output", "o", "output format (json, short)")
-	rootCmd.AddCommand(cmd)
+	cmd.Flags().VarP(&output, "output", "o", "output format (json, short)")
+
+	return cmd
 }
@@ -0,0 +1,210 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package convert translates Postman collections to and from other API
+// description and traffic formats.
+package convert
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+)
+
+// ReadCollection reads a collection from an exported file. Both the bare
+// collection and the {"collection": ...} envelope used by the Postman API
+// are accepted. Collections in Collection Format v1 are converted to v2.1.
+func ReadCollection(reader io.Reader) (*resources.Collection, error) {
+	b, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if IsCollectionV1(b) {
+		v1, err := ReadCollectionV1(b)
+		if err != nil {
+			return nil, err
+		}
+
+		c, err := CollectionV1ToV21(v1)
+		if err != nil {
+			return nil, err
+		}
+
+		if b, err = json.Marshal(c); err != nil {
+			return nil, err
+		}
+	}
+
+	var envelope struct {
+		Collection json.RawMessage `json:"collection"`
+	}
+	if err := json.Unmarshal(b, &envelope); err != nil {
+		return nil, err
+	}
+
+	if len(envelope.Collection) > 0 {
+		b = envelope.Collection
+	}
+
+	var c resources.Collection
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// walkFunc is called for every request in a collection along with the
+// folders that enclose it, outermost first.
+type walkFunc func(item *gen.Item, folders []*gen.ItemGroup) error
+
+// walkItems visits the requests of a collection in document order.
+func walkItems(items []interface{}, folders []*gen.ItemGroup, fn walkFunc) error {
+	for _, v := range items {
+		switch t := v.(type) {
+		case *gen.Item:
+			if err := fn(t, folders); err != nil {
+				return err
+			}
+		case *gen.ItemGroup:
+			if err := walkItems(t.Item, appendFolder(folders, t), fn); err != nil {
+				return err
+			}
+		default:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+
+			var m map[string]interface{}
+			if err := json.Unmarshal(b, &m); err != nil {
+				return err
+			}
+
+			if _, ok := m["item"]; ok {
+				var ig gen.ItemGroup
+				if err := json.Unmarshal(b, &ig); err != nil {
+					return err
+				}
+
+				if err := walkItems(ig.Item, appendFolder(folders, &ig), fn); err != nil {
+					return err
+				}
+				continue
+			}
+
+			var it gen.Item
+			if err := json.Unmarshal(b, &it); err != nil {
+				return err
+			}
+
+			if err := fn(&it, folders); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func appendFolder(folders []*gen.ItemGroup, f *gen.ItemGroup) []*gen.ItemGroup {
+	r := make([]*gen.ItemGroup, len(folders), len(folders)+1)
+	copy(r, folders)
+	return append(r, f)
+}
+
+// effectiveAuth returns the auth that applies to a request, following
+// Postman's inheritance from folders and then the collection.
+func effectiveAuth(r *resources.Request, folders []*gen.ItemGroup, c *gen.Collection) *gen.Auth {
+	if r.Auth != nil {
+		return r.Auth
+	}
+
+	for i := len(folders) - 1; i >= 0; i-- {
+		if a := decodeAuth(folders[i].Auth); a != nil {
+			return a
+		}
+	}
+
+	return decodeAuth(c.Auth)
+}
+
+// authAttribute returns the value of a named auth attribute.
+func authAttribute(attrs []*gen.AuthAttribute, key string) string {
+	for _, a := range attrs {
+		if a.Key == key {
+			return valueString(a.Value)
+		}
+	}
+
+	return ""
+}
+
+// decodeAuth converts an untyped auth block of a collection or folder.
+func decodeAuth(v interface{}) *gen.Auth {
+	if v == nil {
+		return nil
+	}
+
+	if a, ok := v.(*gen.Auth); ok {
+		return a
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	var a gen.Auth
+	if err := json.Unmarshal(b, &a); err != nil {
+		return nil
+	}
+
+	return &a
+}
+
+func valueString(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	default:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+}
+
+// descriptionString returns the content of a description, which may be a
+// string or an object with a content field.
+func descriptionString(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case map[string]interface{}:
+		s, _ := t["content"].(string)
+		return s
+	}
+
+	return ""
+}
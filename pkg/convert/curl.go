@@ -0,0 +1,519 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/variables"
+)
+
+var errItemFound = errors.New("item found")
+
+// FindItem returns the request at path in a collection, where path is made
+// of folder names and the request name separated by slashes, e.g.
+// "Folder/Sub/Request name".
+func FindItem(c *resources.Collection, path string) (*gen.Item, error) {
+	item, _, err := findItem(c, path)
+	return item, err
+}
+
+func findItem(c *resources.Collection, path string) (*gen.Item, []*gen.ItemGroup, error) {
+	if c == nil || c.Collection == nil {
+		return nil, nil, errors.New("no collection")
+	}
+
+	var (
+		found   *gen.Item
+		parents []*gen.ItemGroup
+	)
+
+	path = strings.Trim(path, "/")
+	err := walkItems(c.Item, nil, func(item *gen.Item, folders []*gen.ItemGroup) error {
+		names := make([]string, 0, len(folders)+1)
+		for _, f := range folders {
+			names = append(names, f.Name)
+		}
+		names = append(names, item.Name)
+
+		if strings.Join(names, "/") == path {
+			found, parents = item, folders
+			return errItemFound
+		}
+
+		return nil
+	})
+	if err != nil && err != errItemFound {
+		return nil, nil, err
+	}
+
+	if found == nil {
+		return nil, nil, fmt.Errorf("request %q not found in collection", path)
+	}
+
+	return found, parents, nil
+}
+
+// CurlCommand renders the request at path in a collection as a curl command.
+// Variables are resolved from the collection variables and vars, which take
+// precedence as environment variables. Nested references and dynamic
+// variables such as {{$guid}} are resolved too. Unresolved variables are left
+// in place. A dynamic variable has the same value throughout the command.
+func CurlCommand(c *resources.Collection, path string, vars map[string]string) (string, error) {
+	item, folders, err := findItem(c, path)
+	if err != nil {
+		return "", err
+	}
+
+	r, err := resources.DecodeRequest(item.Request)
+	if err != nil {
+		return "", err
+	}
+
+	resolver := variables.NewResolver()
+	resolver.SetCollection(c.Variable)
+	for k, v := range vars {
+		resolver.Set(variables.Environment, k, v)
+	}
+
+	resolve := resolver.ResolveFunc()
+
+	quote := func(s string) string {
+		return shellQuote(resolve(s))
+	}
+
+	raw := r.URL.String()
+	for _, v := range r.URL.Variable {
+		raw = strings.Replace(raw, "/:"+v.Key, "/"+valueString(v.Value), -1)
+	}
+
+	var args []string
+	if auth := effectiveAuth(r, folders, c.Collection); auth != nil {
+		switch auth.Type {
+		case "basic":
+			args = append(args, "-u", quote(authAttribute(auth.Basic, "username")+":"+authAttribute(auth.Basic, "password")))
+		case "bearer":
+			args = append(args, "-H", quote("Authorization: Bearer "+authAttribute(auth.Bearer, "token")))
+		case "apikey":
+			key, value := authAttribute(auth.Apikey, "key"), authAttribute(auth.Apikey, "value")
+			if authAttribute(auth.Apikey, "in") == "query" {
+				sep := "?"
+				if strings.Contains(raw, "?") {
+					sep = "&"
+				}
+				raw += sep + url.QueryEscape(key) + "=" + value
+			} else {
+				args = append(args, "-H", quote(key+": "+value))
+			}
+		}
+	}
+
+	for _, h := range r.Header {
+		if !h.Disabled {
+			args = append(args, "-H", quote(h.Key+": "+h.Value))
+		}
+	}
+
+	hasBody := false
+	if b := r.Body; b != nil && !b.Disabled {
+		switch b.Mode {
+		case "raw":
+			if b.Raw != "" {
+				if headerValue(r.Header, "Content-Type") == "" && b.Language() != "" {
+					args = append(args, "-H", quote("Content-Type: "+rawContentType(b.Language(), b.Raw)))
+				}
+				args = append(args, "--data-raw", quote(b.Raw))
+				hasBody = true
+			}
+		case "urlencoded":
+			for _, p := range b.URLEncoded {
+				if !p.Disabled {
+					args = append(args, "--data-urlencode", quote(p.Key+"="+p.Value))
+					hasBody = true
+				}
+			}
+		case "formdata":
+			for _, p := range b.FormData {
+				if p.Disabled {
+					continue
+				}
+				if p.Type == "file" {
+					args = append(args, "-F", quote(p.Key+"=@"+valueString(p.Src)))
+				} else {
+					args = append(args, "-F", quote(p.Key+"="+p.Value))
+				}
+				hasBody = true
+			}
+		case "file":
+			if src := valueString(b.File["src"]); src != "" {
+				args = append(args, "--data-binary", quote("@"+src))
+				hasBody = true
+			}
+		}
+	}
+
+	cmd := []string{"curl"}
+	if r.Method != "GET" || hasBody {
+		cmd = append(cmd, "-X", r.Method)
+	}
+	cmd = append(cmd, quote(raw))
+
+	var b strings.Builder
+	b.WriteString(strings.Join(cmd, " "))
+	for i := 0; i < len(args); i += 2 {
+		b.WriteString(" \\\n  " + args[i] + " " + args[i+1])
+	}
+
+	return b.String(), nil
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// CurlToItem parses a curl command line into a collection item. The item
+// is named after its method and path unless name is given.
+func CurlToItem(command, name string) (*gen.Item, error) {
+	args, err := splitCommandLine(command)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) == 0 || args[0] != "curl" {
+		return nil, errors.New("command must start with curl")
+	}
+
+	var (
+		method    string
+		rawURL    string
+		headers   []*resources.KeyValue
+		data      []string
+		urlencode []string
+		form      []*resources.KeyValue
+		auth      *gen.Auth
+		get       bool
+	)
+
+	value := func(i *int, flag string) (string, error) {
+		*i++
+		if *i >= len(args) {
+			return "", fmt.Errorf("flag %s needs an argument", flag)
+		}
+		return args[*i], nil
+	}
+
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		flag := arg
+
+		// Short flags may carry their value, e.g. -XPOST or -H'Accept: */*'.
+		if len(arg) > 2 && arg[0] == '-' && arg[1] != '-' && strings.ContainsRune("XHdFuAbe", rune(arg[1])) {
+			flag = arg[:2]
+			args = append(args[:i+1], append([]string{arg[2:]}, args[i+1:]...)...)
+		}
+
+		var v string
+		switch flag {
+		case "-X", "--request":
+			if v, err = value(&i, flag); err != nil {
+				return nil, err
+			}
+			method = strings.ToUpper(v)
+		case "-H", "--header":
+			if v, err = value(&i, flag); err != nil {
+				return nil, err
+			}
+			kv := strings.SplitN(v, ":", 2)
+			h := &resources.KeyValue{Key: strings.TrimSpace(kv[0])}
+			if len(kv) > 1 {
+				h.Value = strings.TrimSpace(kv[1])
+			}
+			headers = append(headers, h)
+		case "-d", "--data", "--data-raw", "--data-binary", "--data-ascii":
+			if v, err = value(&i, flag); err != nil {
+				return nil, err
+			}
+			data = append(data, v)
+		case "--json":
+			if v, err = value(&i, flag); err != nil {
+				return nil, err
+			}
+			data = append(data, v)
+			headers = append(headers,
+				&resources.KeyValue{Key: "Content-Type", Value: "application/json"},
+				&resources.KeyValue{Key: "Accept", Value: "application/json"})
+		case "--data-urlencode":
+			if v, err = value(&i, flag); err != nil {
+				return nil, err
+			}
+			urlencode = append(urlencode, v)
+		case "-F", "--form", "--form-string":
+			if v, err = value(&i, flag); err != nil {
+				return nil, err
+			}
+			form = append(form, formParam(v, flag == "--form-string"))
+		case "-u", "--user":
+			if v, err = value(&i, flag); err != nil {
+				return nil, err
+			}
+			kv := strings.SplitN(v, ":", 2)
+			auth = &gen.Auth{Type: "basic", Basic: []*gen.AuthAttribute{
+				{Key: "username", Value: kv[0], Type: "string"},
+				{Key: "password", Value: strings.Join(kv[1:], ""), Type: "string"},
+			}}
+		case "-A", "--user-agent":
+			if v, err = value(&i, flag); err != nil {
+				return nil, err
+			}
+			headers = append(headers, &resources.KeyValue{Key: "User-Agent", Value: v})
+		case "-b", "--cookie":
+			if v, err = value(&i, flag); err != nil {
+				return nil, err
+			}
+			headers = append(headers, &resources.KeyValue{Key: "Cookie", Value: v})
+		case "-e", "--referer":
+			if v, err = value(&i, flag); err != nil {
+				return nil, err
+			}
+			headers = append(headers, &resources.KeyValue{Key: "Referer", Value: v})
+		case "--url":
+			if rawURL, err = value(&i, flag); err != nil {
+				return nil, err
+			}
+		case "-I", "--head":
+			method = "HEAD"
+		case "-G", "--get":
+			get = true
+		case "-o", "--output", "-m", "--max-time", "--connect-timeout", "-w", "--write-out", "--retry", "-x", "--proxy", "--cacert", "--cert", "--key":
+			// Options with arguments that do not affect the request.
+			if _, err = value(&i, flag); err != nil {
+				return nil, err
+			}
+		default:
+			if strings.HasPrefix(arg, "-") {
+				// Flags such as -s, -L, -k and --compressed do not affect the
+				// request.
+				continue
+			}
+			rawURL = arg
+		}
+	}
+
+	if rawURL == "" {
+		return nil, errors.New("no URL found in curl command")
+	}
+	if !strings.Contains(rawURL, "://") && !strings.HasPrefix(rawURL, "{{") {
+		rawURL = "http://" + rawURL
+	}
+
+	if get {
+		query := append(append([]string{}, data...), urlencode...)
+		if len(query) > 0 {
+			sep := "?"
+			if strings.Contains(rawURL, "?") {
+				sep = "&"
+			}
+			rawURL += sep + strings.Join(query, "&")
+		}
+		data, urlencode = nil, nil
+		if method == "" {
+			method = "GET"
+		}
+	}
+
+	r := &resources.Request{
+		Method: method,
+		URL:    resources.ParseURL(rawURL),
+		Header: headers,
+		Auth:   auth,
+	}
+
+	contentType := mediaTypeKey(headerValue(headers, "Content-Type"))
+	switch {
+	case len(form) > 0:
+		r.Body = &resources.Body{Mode: "formdata", FormData: form}
+	case len(urlencode) > 0 || (len(data) > 0 && isFormData(contentType, data)):
+		r.Body = &resources.Body{Mode: "urlencoded"}
+		for _, d := range data {
+			r.Body.URLEncoded = append(r.Body.URLEncoded, formPairs(d, false)...)
+		}
+		for _, d := range urlencode {
+			r.Body.URLEncoded = append(r.Body.URLEncoded, formPairs(d, true)...)
+		}
+	case len(data) > 0:
+		raw := strings.Join(data, "&")
+		if contentType == "" {
+			contentType = rawContentType("", raw)
+		}
+		r.Body = &resources.Body{Mode: "raw", Raw: raw, Options: rawOptions(contentType)}
+	}
+
+	if r.Method == "" {
+		r.Method = "GET"
+		if r.Body != nil {
+			r.Method = "POST"
+		}
+	}
+
+	if name == "" {
+		name = r.Method + " /" + strings.Join(r.URL.Path, "/")
+	}
+
+	return &gen.Item{Name: name, Request: r}, nil
+}
+
+// isFormData reports whether -d data is sent as a form, which is curl's
+// default when no other content type is given.
+func isFormData(contentType string, data []string) bool {
+	if contentType != "" {
+		return contentType == "application/x-www-form-urlencoded"
+	}
+
+	for _, d := range data {
+		d = strings.TrimSpace(d)
+		if strings.HasPrefix(d, "{") || strings.HasPrefix(d, "[") || !strings.Contains(d, "=") {
+			return false
+		}
+	}
+
+	return true
+}
+
+func formPairs(data string, encode bool) []*resources.KeyValue {
+	var params []*resources.KeyValue
+	for _, pair := range strings.Split(data, "&") {
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		p := &resources.KeyValue{Key: kv[0], Type: "text"}
+		if len(kv) > 1 {
+			p.Value = kv[1]
+		}
+		if !encode {
+			p.Key, p.Value = queryUnescape(p.Key), queryUnescape(p.Value)
+		}
+		params = append(params, p)
+	}
+
+	return params
+}
+
+// formParam parses a -F argument such as name=value or name=@file.
+func formParam(v string, literal bool) *resources.KeyValue {
+	kv := strings.SplitN(v, "=", 2)
+	p := &resources.KeyValue{Key: kv[0], Type: "text"}
+	if len(kv) < 2 {
+		return p
+	}
+
+	p.Value = kv[1]
+	if literal {
+		return p
+	}
+
+	// Drop ;type= and ;filename= attributes.
+	if i := strings.Index(p.Value, ";"); i >= 0 {
+		p.Value = p.Value[:i]
+	}
+
+	if strings.HasPrefix(p.Value, "@") {
+		p.Type = "file"
+		p.Src = p.Value[1:]
+		p.Value = ""
+	}
+
+	return p
+}
+
+// splitCommandLine splits a POSIX shell command line into words, handling
+// quotes, escapes and line continuations.
+func splitCommandLine(s string) ([]string, error) {
+	var (
+		args      []string
+		current   strings.Builder
+		inWord    bool
+		quote     rune
+		escaped   bool
+		dqEscaped bool
+	)
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			if r != '\n' {
+				current.WriteRune(r)
+				inWord = true
+			}
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case quote == '"' && dqEscaped:
+			// Within double quotes, backslash only escapes these characters.
+			if !strings.ContainsRune("$`\"\\\n", r) {
+				current.WriteRune('\\')
+			}
+			if r != '\n' {
+				current.WriteRune(r)
+			}
+			dqEscaped = false
+		case quote == '"':
+			switch r {
+			case '"':
+				quote = 0
+			case '\\':
+				dqEscaped = true
+			default:
+				current.WriteRune(r)
+			}
+		case r == '\\':
+			escaped = true
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inWord {
+				args = append(args, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, errors.New("unterminated quote in command")
+	}
+
+	if inWord {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
@@ -0,0 +1,229 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+const testCurlCollection = `{
+	"info": {"name": "Test", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+	"auth": {"type": "bearer", "bearer": [{"key": "token", "value": "{{token}}"}]},
+	"variable": [{"key": "baseUrl", "value": "https://api.example.com"}, {"key": "token", "value": "default"}],
+	"item": [{
+		"name": "Users",
+		"item": [{
+			"name": "Admin",
+			"item": [{
+				"name": "Create user",
+				"request": {
+					"method": "POST",
+					"url": "{{baseUrl}}/users/:org",
+					"header": [{"key": "Content-Type", "value": "application/json"}],
+					"body": {"mode": "raw", "raw": "{\"name\": \"O'Brien\"}"}
+				}
+			}]
+		}]
+	}]
+}`
+
+func readTestCollection(t *testing.T, s string) *resources.Collection {
+	t.Helper()
+
+	c, err := convert.ReadCollection(strings.NewReader(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func TestCurlCommand(t *testing.T) {
+	c := readTestCollection(t, testCurlCollection)
+
+	have, err := convert.CurlCommand(c, "Users/Admin/Create user", map[string]string{"token": "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `curl -X POST 'https://api.example.com/users/:org' \
+  -H 'Authorization: Bearer secret' \
+  -H 'Content-Type: application/json' \
+  --data-raw '{"name": "O'\''Brien"}'`
+
+	if have != want {
+		t.Errorf("Command is incorrect, have:\n%s\nwant:\n%s", have, want)
+	}
+}
+
+func TestCurlCommandDynamicOnce(t *testing.T) {
+	c := readTestCollection(t, `{
+	"info": {"name": "Test", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+	"item": [{
+		"name": "Trace",
+		"request": {
+			"method": "GET",
+			"url": "https://api.example.com/{{$guid}}",
+			"header": [{"key": "X-Request-Id", "value": "{{$guid}}"}]
+		}
+	}]
+}`)
+
+	have, err := convert.CurlCommand(c, "Trace", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(have, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Command is incorrect, have:\n%s", have)
+	}
+	guid := strings.TrimSuffix(strings.TrimPrefix(lines[0], "curl 'https://api.example.com/"), "' \\")
+	if guid == "" || strings.Contains(guid, "{{") || lines[1] != "  -H 'X-Request-Id: "+guid+"'" {
+		t.Errorf("Dynamic variables are resolved more than once, have:\n%s", have)
+	}
+}
+
+func TestCurlCommandNotFound(t *testing.T) {
+	c := readTestCollection(t, testCurlCollection)
+
+	if _, err := convert.CurlCommand(c, "Users/Create user", nil); err == nil {
+		t.Error("Expected an error for a missing request")
+	}
+}
+
+func TestCurlToItem(t *testing.T) {
+	tests := []struct {
+		command string
+		want    map[string]interface{}
+	}{
+		{
+			command: `curl -XPUT "https://api.example.com/users/1?x=1" \
+  -H 'Content-Type: application/json' -H "Accept: */*" \
+  --data-raw '{"name": "Jane"}' --compressed -sS`,
+			want: map[string]interface{}{
+				"method": "PUT",
+				"raw":    "https://api.example.com/users/1?x=1",
+				"mode":   "raw",
+				"body":   `{"name": "Jane"}`,
+			},
+		},
+		{
+			command: `curl https://example.com/login -d 'user=jane&pass=a%20b' -u admin:secret`,
+			want: map[string]interface{}{
+				"method": "POST",
+				"raw":    "https://example.com/login",
+				"mode":   "urlencoded",
+				"auth":   "basic",
+			},
+		},
+		{
+			command: `curl -F 'file=@photo.png;type=image/png' -F name=pic example.com/upload`,
+			want: map[string]interface{}{
+				"method": "POST",
+				"raw":    "http://example.com/upload",
+				"mode":   "formdata",
+			},
+		},
+		{
+			command: `curl -G https://example.com/search -d q=go`,
+			want: map[string]interface{}{
+				"method": "GET",
+				"raw":    "https://example.com/search?q=go",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		item, err := convert.CurlToItem(tt.command, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := json.Marshal(item.Request)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var r struct {
+			Method string `json:"method"`
+			URL    struct {
+				Raw string `json:"raw"`
+			} `json:"url"`
+			Body *struct {
+				Mode string `json:"mode"`
+				Raw  string `json:"raw"`
+			} `json:"body"`
+			Auth *struct {
+				Type string `json:"type"`
+			} `json:"auth"`
+		}
+		if err := json.Unmarshal(b, &r); err != nil {
+			t.Fatal(err)
+		}
+
+		if r.Method != tt.want["method"] || r.URL.Raw != tt.want["raw"] {
+			t.Errorf("Request is incorrect for %q, have: %s", tt.command, b)
+		}
+
+		if mode, ok := tt.want["mode"]; ok && (r.Body == nil || r.Body.Mode != mode) {
+			t.Errorf("Body mode is incorrect for %q, have: %s", tt.command, b)
+		}
+
+		if body, ok := tt.want["body"]; ok && r.Body.Raw != body {
+			t.Errorf("Body is incorrect, have: %s, want: %s", r.Body.Raw, body)
+		}
+
+		if auth, ok := tt.want["auth"]; ok && (r.Auth == nil || r.Auth.Type != auth) {
+			t.Errorf("Auth is incorrect for %q, have: %s", tt.command, b)
+		}
+	}
+}
+
+func TestCurlToItemRoundTrip(t *testing.T) {
+	item, err := convert.CurlToItem(`curl -X DELETE 'https://example.com/a b' -H 'X-Id: 1'`, "Delete")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if item.Name != "Delete" {
+		t.Errorf("Name is incorrect, have: %s, want: %s", item.Name, "Delete")
+	}
+
+	b, err := json.Marshal(map[string]interface{}{
+		"info": map[string]string{"name": "Test", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"item": []interface{}{item},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := convert.CurlCommand(readTestCollection(t, string(b)), "Delete", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "curl -X DELETE 'https://example.com/a b' \\\n  -H 'X-Id: 1'"
+	if have != want {
+		t.Errorf("Command is incorrect, have:\n%s\nwant:\n%s", have, want)
+	}
+}
@@ -0,0 +1,311 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"gopkg.in/yaml.v2"
+)
+
+// SecretType is the type of environment values holding secrets.
+const SecretType = "secret"
+
+var (
+	dotenvKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+	k8sKeyPattern    = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+	k8sNamePattern   = regexp.MustCompile(`[^a-z0-9.-]+`)
+)
+
+// DotenvOptions configures DotenvToEnvironment.
+type DotenvOptions struct {
+	// SecretKeys are the keys, or path.Match patterns such as "*_TOKEN",
+	// of values given the secret type.
+	SecretKeys []string
+	// SkipDisabled drops commented out assignments instead of importing
+	// them as disabled values.
+	SkipDisabled bool
+}
+
+// DotenvToEnvironment reads an environment from a dotenv file. Commented
+// out assignments, such as "# KEY=value", become disabled values.
+func DotenvToEnvironment(reader io.Reader, name string, opts DotenvOptions) (*resources.Environment, error) {
+	b, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := parseDotenv(string(bytes.TrimPrefix(b, []byte("\ufeff"))))
+	if err != nil {
+		return nil, err
+	}
+
+	env := &resources.Environment{Name: name, Values: []resources.KeyValuePair{}}
+	for _, v := range values {
+		if !v.Enabled && opts.SkipDisabled {
+			continue
+		}
+
+		v.Type = "default"
+		for _, pattern := range opts.SecretKeys {
+			if ok, _ := path.Match(pattern, v.Key); ok {
+				v.Type = SecretType
+				break
+			}
+		}
+
+		env.Values = append(env.Values, v)
+	}
+
+	return env, nil
+}
+
+// EnvironmentToDotenv writes an environment as a dotenv file. Disabled
+// values are written as comments.
+func EnvironmentToDotenv(env *resources.Environment) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, v := range env.Values {
+		if !dotenvKeyPattern.MatchString(v.Key) {
+			return nil, fmt.Errorf("key %q is not a valid dotenv name", v.Key)
+		}
+
+		if !v.Enabled {
+			buf.WriteString("# ")
+		}
+		fmt.Fprintf(&buf, "%s=%s\n", v.Key, quoteDotenv(v.Value))
+	}
+
+	return buf.Bytes(), nil
+}
+
+type k8sObject struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   k8sMetadata       `yaml:"metadata"`
+	Type       string            `yaml:"type,omitempty"`
+	Data       map[string]string `yaml:"data"`
+}
+
+type k8sMetadata struct {
+	Name string `yaml:"name"`
+}
+
+// EnvironmentToSecret writes the enabled values of an environment as a
+// Kubernetes Secret manifest.
+func EnvironmentToSecret(env *resources.Environment) ([]byte, error) {
+	return environmentToK8s(env, "Secret", func(v resources.KeyValuePair) (string, bool) {
+		return base64.StdEncoding.EncodeToString([]byte(v.Value)), true
+	})
+}
+
+// EnvironmentToConfigMap writes the enabled values of an environment as a
+// Kubernetes ConfigMap manifest. Values of the secret type are left out;
+// use EnvironmentToSecret for those.
+func EnvironmentToConfigMap(env *resources.Environment) ([]byte, error) {
+	return environmentToK8s(env, "ConfigMap", func(v resources.KeyValuePair) (string, bool) {
+		return v.Value, v.Type != SecretType
+	})
+}
+
+func environmentToK8s(env *resources.Environment, kind string, value func(resources.KeyValuePair) (string, bool)) ([]byte, error) {
+	name := strings.Trim(k8sNamePattern.ReplaceAllString(strings.ToLower(env.Name), "-"), "-.")
+	if name == "" {
+		return nil, fmt.Errorf("environment name %q cannot be used as a Kubernetes name", env.Name)
+	}
+	if len(name) > 253 {
+		name = name[:253]
+	}
+
+	obj := k8sObject{
+		APIVersion: "v1",
+		Kind:       kind,
+		Metadata:   k8sMetadata{Name: name},
+		Data:       make(map[string]string),
+	}
+	if kind == "Secret" {
+		obj.Type = "Opaque"
+	}
+
+	for _, v := range env.Values {
+		if !v.Enabled {
+			continue
+		}
+
+		if !k8sKeyPattern.MatchString(v.Key) {
+			return nil, fmt.Errorf("key %q is not a valid Kubernetes data key", v.Key)
+		}
+
+		if s, ok := value(v); ok {
+			obj.Data[v.Key] = s
+		}
+	}
+
+	return yaml.Marshal(obj)
+}
+
+// quoteDotenv quotes values with spaces, quotes, comments or line breaks.
+// Single quotes are preferred as their content is taken literally.
+func quoteDotenv(s string) string {
+	if !strings.ContainsAny(s, " \t\r\n#\"'\\$`") {
+		return s
+	}
+
+	if !strings.ContainsAny(s, "'\r\n") {
+		return "'" + s + "'"
+	}
+
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// parseDotenv parses KEY=value lines. Values may be single quoted, taken
+// literally, or double quoted with \n, \r, \t, \" and \\ escapes. Both may
+// span lines. Unquoted values end at a " #" comment.
+func parseDotenv(s string) ([]resources.KeyValuePair, error) {
+	var values []resources.KeyValuePair
+
+	line := 0
+	for len(s) > 0 {
+		var text string
+		if i := strings.IndexByte(s, '\n'); i >= 0 {
+			text, s = s[:i], s[i+1:]
+		} else {
+			text, s = s, ""
+		}
+		line++
+
+		text = strings.TrimSpace(text)
+		enabled := true
+		if strings.HasPrefix(text, "#") {
+			text = strings.TrimSpace(text[1:])
+			enabled = false
+		}
+		text = strings.TrimPrefix(text, "export ")
+
+		eq := strings.IndexByte(text, '=')
+		if text == "" || (!enabled && eq < 0) {
+			continue
+		}
+
+		var key string
+		if eq >= 0 {
+			key = strings.TrimSpace(text[:eq])
+		}
+		if !dotenvKeyPattern.MatchString(key) {
+			if !enabled {
+				// An ordinary comment.
+				continue
+			}
+			return nil, fmt.Errorf("line %d: expected KEY=value", line)
+		}
+
+		value := strings.TrimLeft(text[eq+1:], " \t")
+		if value != "" && (value[0] == '"' || value[0] == '\'') {
+			quote := value[0]
+			value = value[1:]
+
+			// Read on until the closing quote.
+			end := closingQuote(value, quote)
+			for end < 0 && len(s) > 0 && enabled {
+				var next string
+				if i := strings.IndexByte(s, '\n'); i >= 0 {
+					next, s = s[:i], s[i+1:]
+				} else {
+					next, s = s, ""
+				}
+				line++
+				value += "\n" + strings.TrimRight(next, "\r")
+				end = closingQuote(value, quote)
+			}
+			if end < 0 {
+				if !enabled {
+					continue
+				}
+				return nil, fmt.Errorf("line %d: unterminated quoted value", line)
+			}
+
+			if rest := strings.TrimSpace(value[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+				if !enabled {
+					continue
+				}
+				return nil, fmt.Errorf("line %d: unexpected text after quoted value", line)
+			}
+
+			value = value[:end]
+			if quote == '"' {
+				value = unescapeDotenv(value)
+			}
+		} else {
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = value[:i]
+			}
+			value = strings.TrimSpace(value)
+		}
+
+		values = append(values, resources.KeyValuePair{Key: key, Value: value, Enabled: enabled})
+	}
+
+	return values, nil
+}
+
+func closingQuote(s string, quote byte) int {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quote == '"':
+			i++
+		case s[i] == quote:
+			return i
+		}
+	}
+
+	return -1
+}
+
+func unescapeDotenv(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '"', '\\', '$':
+			b.WriteByte(s[i])
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,151 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+const testDotenv = `# Service configuration
+export BASE_URL=https://api.example.com # production
+API_TOKEN="abc\"123"
+GREETING='hello #world'
+CERT="line1
+line2"
+# DEBUG=true
+`
+
+func TestDotenvToEnvironment(t *testing.T) {
+	env, err := convert.DotenvToEnvironment(strings.NewReader(testDotenv), "staging", convert.DotenvOptions{
+		SecretKeys: []string{"*_TOKEN"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []resources.KeyValuePair{
+		{Key: "BASE_URL", Value: "https://api.example.com", Enabled: true, Type: "default"},
+		{Key: "API_TOKEN", Value: `abc"123`, Enabled: true, Type: "secret"},
+		{Key: "GREETING", Value: "hello #world", Enabled: true, Type: "default"},
+		{Key: "CERT", Value: "line1\nline2", Enabled: true, Type: "default"},
+		{Key: "DEBUG", Value: "true", Enabled: false, Type: "default"},
+	}
+
+	if env.Name != "staging" || !reflect.DeepEqual(env.Values, expected) {
+		t.Errorf("Environment is incorrect, have: %+v, want: %+v", env.Values, expected)
+	}
+
+	env, err = convert.DotenvToEnvironment(strings.NewReader(testDotenv), "staging", convert.DotenvOptions{SkipDisabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(env.Values) != 4 {
+		t.Errorf("Expected disabled values to be skipped, have: %+v", env.Values)
+	}
+
+	if _, err := convert.DotenvToEnvironment(strings.NewReader("not an assignment\n"), "x", convert.DotenvOptions{}); err == nil {
+		t.Error("Expected an error for an invalid line")
+	}
+}
+
+func TestEnvironmentToDotenvRoundTrip(t *testing.T) {
+	env := &resources.Environment{
+		Name: "staging",
+		Values: []resources.KeyValuePair{
+			{Key: "plain", Value: "value", Enabled: true},
+			{Key: "spaces", Value: "a b $c", Enabled: true},
+			{Key: "quotes", Value: "it's \"quoted\"\nnext", Enabled: true},
+			{Key: "empty", Value: "", Enabled: true},
+			{Key: "off", Value: "x", Enabled: false},
+		},
+	}
+
+	b, err := convert.EnvironmentToDotenv(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := convert.DotenvToEnvironment(bytes.NewReader(b), "staging", convert.DotenvOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range have.Values {
+		have.Values[i].Type = ""
+	}
+
+	if !reflect.DeepEqual(have.Values, env.Values) {
+		t.Errorf("Round trip is incorrect, have: %+v, want: %+v\n%s", have.Values, env.Values, b)
+	}
+
+	env.Values = append(env.Values, resources.KeyValuePair{Key: "not valid", Enabled: true})
+	if _, err := convert.EnvironmentToDotenv(env); err == nil {
+		t.Error("Expected an error for an invalid key")
+	}
+}
+
+func TestEnvironmentToK8s(t *testing.T) {
+	env := &resources.Environment{
+		Name: "Staging (EU)",
+		Values: []resources.KeyValuePair{
+			{Key: "baseUrl", Value: "https://api.example.com", Enabled: true},
+			{Key: "token", Value: "abc", Enabled: true, Type: "secret"},
+			{Key: "debug", Value: "true", Enabled: false},
+		},
+	}
+
+	secret, err := convert.EnvironmentToSecret(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `apiVersion: v1
+kind: Secret
+metadata:
+  name: staging-eu
+type: Opaque
+data:
+  baseUrl: aHR0cHM6Ly9hcGkuZXhhbXBsZS5jb20=
+  token: YWJj
+`
+	if string(secret) != expected {
+		t.Errorf("Secret is incorrect, have:\n%s\nwant:\n%s", secret, expected)
+	}
+
+	configMap, err := convert.EnvironmentToConfigMap(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: staging-eu
+data:
+  baseUrl: https://api.example.com
+`
+	if string(configMap) != expected {
+		t.Errorf("ConfigMap is incorrect, have:\n%s\nwant:\n%s", configMap, expected)
+	}
+}
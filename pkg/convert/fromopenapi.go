@@ -0,0 +1,664 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+	"gopkg.in/yaml.v2"
+)
+
+const collectionSchemaURL = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// maxExampleDepth bounds example generation for recursive schemas.
+const maxExampleDepth = 8
+
+// ReadOpenAPI reads an OpenAPI 3 or Swagger 2.0 document in JSON or YAML.
+// Swagger documents are upgraded to OpenAPI 3.
+func ReadOpenAPI(reader io.Reader) (*OpenAPI, error) {
+	b, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseOpenAPI(b)
+}
+
+// ParseOpenAPI parses an OpenAPI 3 or Swagger 2.0 document in JSON or YAML,
+// such as the contents of a resources.Schema.
+func ParseOpenAPI(b []byte) (*OpenAPI, error) {
+	b, err := yamlToJSON(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var version struct {
+		OpenAPI string `json:"openapi"`
+		Swagger string `json:"swagger"`
+	}
+	if err := json.Unmarshal(b, &version); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case strings.HasPrefix(version.Swagger, "2."):
+		return upgradeSwagger(b)
+	case strings.HasPrefix(version.OpenAPI, "3."):
+		var doc OpenAPI
+		if err := json.Unmarshal(b, &doc); err != nil {
+			return nil, err
+		}
+		return &doc, nil
+	}
+
+	return nil, errors.New("document is not an OpenAPI 3 or Swagger 2.0 definition")
+}
+
+// yamlToJSON converts YAML to JSON. JSON input is returned as is.
+func yamlToJSON(b []byte) ([]byte, error) {
+	if json.Valid(b) {
+		return b, nil
+	}
+
+	var v interface{}
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(normalizeYAML(v))
+}
+
+// normalizeYAML converts the map[interface{}]interface{} values produced by
+// the YAML decoder into values encoding/json can marshal.
+func normalizeYAML(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			m[fmt.Sprint(k)] = normalizeYAML(e)
+		}
+		return m
+	case []interface{}:
+		for i, e := range t {
+			t[i] = normalizeYAML(e)
+		}
+		return t
+	}
+
+	return v
+}
+
+// OpenAPIToCollection converts an OpenAPI 3 document into a collection.
+// Operations are grouped into folders by their first tag, servers become
+// collection variables and security schemes become auth.
+func OpenAPIToCollection(doc *OpenAPI) (*gen.Collection, error) {
+	if doc == nil {
+		return nil, errors.New("no OpenAPI document")
+	}
+
+	name := doc.Info.Title
+	if name == "" {
+		name = "Imported API"
+	}
+
+	c := &gen.Collection{
+		Info: &gen.Info{
+			Name:   name,
+			Schema: collectionSchemaURL,
+		},
+		Item: []interface{}{},
+	}
+
+	if doc.Info.Description != "" {
+		c.Info.Description = doc.Info.Description
+	}
+
+	for i, s := range doc.Servers {
+		key := "baseUrl"
+		if i > 0 {
+			key = "baseUrl" + strconv.Itoa(i+1)
+		}
+		c.Variable = append(c.Variable, &gen.Variable{
+			Key:         key,
+			Value:       serverURL(s),
+			Type:        "string",
+			Description: s.Description,
+		})
+	}
+	if len(doc.Servers) == 0 {
+		c.Variable = append(c.Variable, &gen.Variable{Key: "baseUrl", Value: "/", Type: "string"})
+	}
+
+	c.Auth = doc.auth(doc.Security)
+
+	folders := make(map[string]*gen.ItemGroup)
+	for _, t := range doc.Tags {
+		folders[t.Name] = &gen.ItemGroup{Name: t.Name, Description: t.Description, Item: []interface{}{}}
+		c.Item = append(c.Item, folders[t.Name])
+	}
+
+	paths := make([]string, 0, len(doc.Paths))
+	for p := range doc.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		pathItem := doc.Paths[p]
+		for _, method := range httpMethods {
+			op := pathItem.Operation(method)
+			if op == nil {
+				continue
+			}
+
+			item, err := doc.item(p, method, pathItem, op)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(op.Tags) == 0 {
+				c.Item = append(c.Item, item)
+				continue
+			}
+
+			f, ok := folders[op.Tags[0]]
+			if !ok {
+				f = &gen.ItemGroup{Name: op.Tags[0], Item: []interface{}{}}
+				folders[op.Tags[0]] = f
+				c.Item = append(c.Item, f)
+			}
+			f.Item = append(f.Item, item)
+		}
+	}
+
+	// Drop folders for declared tags that no operation uses.
+	items := c.Item[:0]
+	for _, it := range c.Item {
+		if f, ok := it.(*gen.ItemGroup); ok && len(f.Item) == 0 {
+			continue
+		}
+		items = append(items, it)
+	}
+	c.Item = items
+
+	return c, nil
+}
+
+func serverURL(s *Server) string {
+	u := s.URL
+	for name, v := range s.Variables {
+		u = strings.Replace(u, "{"+name+"}", v.Default, -1)
+	}
+
+	return strings.TrimSuffix(u, "/")
+}
+
+func (doc *OpenAPI) item(path, method string, pathItem *PathItem, op *Operation) (*gen.Item, error) {
+	name := op.Summary
+	if name == "" {
+		name = op.OperationID
+	}
+	if name == "" {
+		name = method + " " + path
+	}
+
+	r := &resources.Request{
+		Method:      method,
+		Description: optionalString(op.Description),
+	}
+
+	u := &resources.URL{Host: []string{"{{baseUrl}}"}}
+	for _, s := range strings.Split(strings.Trim(path, "/"), "/") {
+		if s == "" {
+			continue
+		}
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			s = ":" + s[1:len(s)-1]
+		}
+		u.Path = append(u.Path, s)
+	}
+
+	params := make(map[string]*Parameter)
+	var order []string
+	for _, l := range [][]*Parameter{pathItem.Parameters, op.Parameters} {
+		for _, p := range l {
+			p, err := doc.ResolveParameter(p)
+			if err != nil {
+				return nil, err
+			}
+			key := p.In + ":" + p.Name
+			if _, ok := params[key]; !ok {
+				order = append(order, key)
+			}
+			params[key] = p
+		}
+	}
+
+	for _, key := range order {
+		p := params[key]
+		value := valueString(doc.parameterExample(p))
+		switch p.In {
+		case "path":
+			u.Variable = append(u.Variable, &gen.Variable{Key: p.Name, Value: value, Description: p.Description})
+		case "query":
+			u.Query = append(u.Query, &resources.KeyValue{Key: p.Name, Value: value, Description: optionalString(p.Description), Disabled: !p.Required})
+		case "header":
+			r.Header = append(r.Header, &resources.KeyValue{Key: p.Name, Value: value, Description: optionalString(p.Description)})
+		}
+	}
+
+	u.Raw = u.String()
+	r.URL = u
+
+	if op.RequestBody != nil {
+		body, err := doc.ResolveRequestBody(op.RequestBody)
+		if err != nil {
+			return nil, err
+		}
+
+		if ct, media := preferredMediaType(body.Content); media != nil {
+			r.Header = append(r.Header, &resources.KeyValue{Key: "Content-Type", Value: ct})
+			r.Body = doc.requestBody(ct, media)
+		}
+	}
+
+	if op.Security != nil {
+		r.Auth = doc.auth(op.Security)
+		if r.Auth == nil {
+			r.Auth = &gen.Auth{Type: "noauth"}
+		}
+	}
+
+	item := &gen.Item{
+		Name:    name,
+		Request: r,
+	}
+
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		res, err := doc.ResolveResponse(op.Responses[code])
+		if err != nil {
+			return nil, err
+		}
+
+		item.Response = append(item.Response, doc.response(code, res, r))
+	}
+
+	return item, nil
+}
+
+func (doc *OpenAPI) requestBody(contentType string, media *MediaType) *resources.Body {
+	example := media.Example
+	if example == nil {
+		example = doc.example(media.Schema, 0)
+	}
+
+	switch mediaTypeKey(contentType) {
+	case "application/x-www-form-urlencoded", "multipart/form-data":
+		var params []*resources.KeyValue
+		s := doc.ResolveSchema(media.Schema)
+		keys := make([]string, 0)
+		if s != nil {
+			for k := range s.Properties {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		m, _ := example.(map[string]interface{})
+		for _, k := range keys {
+			p := &resources.KeyValue{Key: k, Value: valueString(m[k]), Type: "text"}
+			if prop := doc.ResolveSchema(s.Properties[k]); prop != nil && prop.Format == "binary" {
+				p.Type = "file"
+				p.Value = ""
+			}
+			params = append(params, p)
+		}
+
+		if mediaTypeKey(contentType) == "multipart/form-data" {
+			return &resources.Body{Mode: "formdata", FormData: params}
+		}
+		return &resources.Body{Mode: "urlencoded", URLEncoded: params}
+	}
+
+	return &resources.Body{
+		Mode:    "raw",
+		Raw:     exampleString(contentType, example),
+		Options: rawOptions(contentType),
+	}
+}
+
+func (doc *OpenAPI) response(code string, res *Response, original *resources.Request) *gen.Response {
+	r := &gen.Response{
+		Status:          res.Description,
+		OriginalRequest: original,
+	}
+
+	if n, err := strconv.Atoi(code); err == nil {
+		r.Code = n
+		if t := http.StatusText(n); t != "" {
+			r.Status = t
+		}
+	}
+
+	if ct, media := preferredMediaType(res.Content); media != nil {
+		example := media.Example
+		if example == nil {
+			example = doc.example(media.Schema, 0)
+		}
+		r.Header = []*resources.KeyValue{{Key: "Content-Type", Value: ct}}
+		r.Body = exampleString(ct, example)
+	}
+
+	return r
+}
+
+// auth converts the first security requirement into collection auth.
+func (doc *OpenAPI) auth(security []SecurityRequirement) *gen.Auth {
+	if len(security) == 0 || doc.Components == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(security[0]))
+	for name := range security[0] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		s, ok := doc.Components.SecuritySchemes[name]
+		if !ok {
+			continue
+		}
+
+		switch {
+		case s.Type == "http" && strings.EqualFold(s.Scheme, "basic"):
+			return &gen.Auth{Type: "basic", Basic: []*gen.AuthAttribute{
+				{Key: "username", Value: "{{username}}", Type: "string"},
+				{Key: "password", Value: "{{password}}", Type: "string"},
+			}}
+		case s.Type == "http" && strings.EqualFold(s.Scheme, "bearer"):
+			return &gen.Auth{Type: "bearer", Bearer: []*gen.AuthAttribute{
+				{Key: "token", Value: "{{bearerToken}}", Type: "string"},
+			}}
+		case s.Type == "http" && strings.EqualFold(s.Scheme, "digest"):
+			return &gen.Auth{Type: "digest", Digest: []*gen.AuthAttribute{
+				{Key: "username", Value: "{{username}}", Type: "string"},
+				{Key: "password", Value: "{{password}}", Type: "string"},
+			}}
+		case s.Type == "apiKey":
+			return &gen.Auth{Type: "apikey", Apikey: []*gen.AuthAttribute{
+				{Key: "key", Value: s.Name, Type: "string"},
+				{Key: "value", Value: "{{apiKey}}", Type: "string"},
+				{Key: "in", Value: s.In, Type: "string"},
+			}}
+		case s.Type == "oauth2" && s.Flows != nil:
+			return oauth2Auth(s.Flows, security[0][name])
+		}
+	}
+
+	return nil
+}
+
+func oauth2Auth(flows *OAuthFlows, scopes []string) *gen.Auth {
+	var (
+		flow      *OAuthFlow
+		grantType string
+	)
+
+	switch {
+	case flows.AuthorizationCode != nil:
+		flow, grantType = flows.AuthorizationCode, "authorization_code"
+	case flows.ClientCredentials != nil:
+		flow, grantType = flows.ClientCredentials, "client_credentials"
+	case flows.Password != nil:
+		flow, grantType = flows.Password, "password_credentials"
+	case flows.Implicit != nil:
+		flow, grantType = flows.Implicit, "implicit"
+	default:
+		return nil
+	}
+
+	attrs := []*gen.AuthAttribute{
+		{Key: "grant_type", Value: grantType, Type: "string"},
+	}
+	if flow.AuthorizationURL != "" {
+		attrs = append(attrs, &gen.AuthAttribute{Key: "authUrl", Value: flow.AuthorizationURL, Type: "string"})
+	}
+	if flow.TokenURL != "" {
+		attrs = append(attrs, &gen.AuthAttribute{Key: "accessTokenUrl", Value: flow.TokenURL, Type: "string"})
+	}
+	if len(scopes) > 0 {
+		attrs = append(attrs, &gen.AuthAttribute{Key: "scope", Value: strings.Join(scopes, " "), Type: "string"})
+	}
+
+	return &gen.Auth{Type: "oauth2", Oauth2: attrs}
+}
+
+func (doc *OpenAPI) parameterExample(p *Parameter) interface{} {
+	if p.Example != nil {
+		return p.Example
+	}
+
+	return doc.example(p.Schema, 0)
+}
+
+// example generates an example value for a schema, using Postman's
+// <type> placeholder convention where no example is given.
+func (doc *OpenAPI) example(s *Schema, depth int) interface{} {
+	s = doc.ResolveSchema(s)
+	if s == nil || depth > maxExampleDepth {
+		return nil
+	}
+
+	if s.Example != nil {
+		return s.Example
+	}
+	if s.Default != nil {
+		return s.Default
+	}
+	if len(s.Enum) > 0 {
+		return s.Enum[0]
+	}
+
+	if len(s.AllOf) > 0 {
+		m := make(map[string]interface{})
+		for _, c := range s.AllOf {
+			if v, ok := doc.example(c, depth+1).(map[string]interface{}); ok {
+				for k, e := range v {
+					m[k] = e
+				}
+			}
+		}
+		return m
+	}
+	if len(s.OneOf) > 0 {
+		return doc.example(s.OneOf[0], depth+1)
+	}
+	if len(s.AnyOf) > 0 {
+		return doc.example(s.AnyOf[0], depth+1)
+	}
+
+	switch s.Type {
+	case "object", "":
+		if s.Type == "" && len(s.Properties) == 0 {
+			return nil
+		}
+		m := make(map[string]interface{}, len(s.Properties))
+		for k, p := range s.Properties {
+			m[k] = doc.example(p, depth+1)
+		}
+		return m
+	case "array":
+		return []interface{}{doc.example(s.Items, depth+1)}
+	case "integer":
+		return "<integer>"
+	case "number":
+		return "<number>"
+	case "boolean":
+		return "<boolean>"
+	case "string":
+		switch s.Format {
+		case "date-time":
+			return "<dateTime>"
+		case "":
+			return "<string>"
+		default:
+			return "<" + s.Format + ">"
+		}
+	}
+
+	return nil
+}
+
+// ResolveSchema follows $ref to a schema in the components of the document.
+// It returns nil when the reference cannot be resolved.
+func (doc *OpenAPI) ResolveSchema(s *Schema) *Schema {
+	for i := 0; s != nil && s.Ref != "" && i < maxExampleDepth; i++ {
+		name := strings.TrimPrefix(s.Ref, "#/components/schemas/")
+		if doc.Components == nil || doc.Components.Schemas[name] == nil {
+			return nil
+		}
+		s = doc.Components.Schemas[name]
+	}
+
+	return s
+}
+
+// ResolveParameter follows $ref to a parameter in the components of the
+// document.
+func (doc *OpenAPI) ResolveParameter(p *Parameter) (*Parameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+
+	name := strings.TrimPrefix(p.Ref, "#/components/parameters/")
+	if doc.Components != nil && doc.Components.Parameters[name] != nil {
+		return doc.Components.Parameters[name], nil
+	}
+
+	return nil, fmt.Errorf("unable to resolve parameter reference %s", p.Ref)
+}
+
+// ResolveRequestBody follows $ref to a request body in the components of the
+// document.
+func (doc *OpenAPI) ResolveRequestBody(b *RequestBody) (*RequestBody, error) {
+	if b.Ref == "" {
+		return b, nil
+	}
+
+	name := strings.TrimPrefix(b.Ref, "#/components/requestBodies/")
+	if doc.Components != nil && doc.Components.RequestBodies[name] != nil {
+		return doc.Components.RequestBodies[name], nil
+	}
+
+	return nil, fmt.Errorf("unable to resolve request body reference %s", b.Ref)
+}
+
+// ResolveResponse follows $ref to a response in the components of the
+// document.
+func (doc *OpenAPI) ResolveResponse(r *Response) (*Response, error) {
+	if r.Ref == "" {
+		return r, nil
+	}
+
+	name := strings.TrimPrefix(r.Ref, "#/components/responses/")
+	if doc.Components != nil && doc.Components.Responses[name] != nil {
+		return doc.Components.Responses[name], nil
+	}
+
+	return nil, fmt.Errorf("unable to resolve response reference %s", r.Ref)
+}
+
+// preferredMediaType picks JSON content where available.
+func preferredMediaType(content map[string]*MediaType) (string, *MediaType) {
+	if len(content) == 0 {
+		return "", nil
+	}
+
+	keys := make([]string, 0, len(content))
+	for k := range content {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if strings.Contains(k, "json") {
+			return k, content[k]
+		}
+	}
+
+	return keys[0], content[keys[0]]
+}
+
+func exampleString(contentType string, v interface{}) string {
+	if s, ok := v.(string); ok && !strings.Contains(contentType, "json") {
+		return s
+	}
+
+	if v == nil {
+		return ""
+	}
+
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
+
+// optionalString returns nil for empty strings so they are omitted from
+// the collection.
+func optionalString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
+
+func rawOptions(contentType string) map[string]interface{} {
+	language := "text"
+	switch {
+	case strings.Contains(contentType, "json"):
+		language = "json"
+	case strings.Contains(contentType, "xml"):
+		language = "xml"
+	case strings.Contains(contentType, "html"):
+		language = "html"
+	}
+
+	return map[string]interface{}{
+		"raw": map[string]interface{}{"language": language},
+	}
+}
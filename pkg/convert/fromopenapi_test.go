@@ -0,0 +1,246 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+)
+
+const testOpenAPI = `
+openapi: 3.0.0
+info:
+  title: Orders API
+  version: 1.0.0
+servers:
+  - url: https://{region}.example.com/v1
+    variables:
+      region:
+        default: us
+tags:
+  - name: orders
+security:
+  - bearer: []
+paths:
+  /orders/{orderId}:
+    parameters:
+      - name: orderId
+        in: path
+        required: true
+        schema:
+          type: string
+          example: abc
+    get:
+      tags: [orders]
+      summary: Get order
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Order'
+    put:
+      tags: [orders]
+      summary: Replace order
+      security: []
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Order'
+      responses:
+        "204":
+          description: No Content
+  /health:
+    get:
+      summary: Health
+      responses:
+        "200":
+          description: OK
+components:
+  securitySchemes:
+    bearer:
+      type: http
+      scheme: bearer
+  schemas:
+    Order:
+      type: object
+      properties:
+        id:
+          type: string
+          format: uuid
+        total:
+          type: number
+        createdAt:
+          type: string
+          format: date-time
+`
+
+const testSwagger = `{
+  "swagger": "2.0",
+  "info": {"title": "Pets", "version": "1"},
+  "host": "pets.example.com",
+  "basePath": "/api",
+  "schemes": ["https"],
+  "securityDefinitions": {"key": {"type": "apiKey", "name": "X-Key", "in": "header"}},
+  "security": [{"key": []}],
+  "paths": {
+    "/pets": {
+      "post": {
+        "operationId": "addPet",
+        "parameters": [{"name": "body", "in": "body", "schema": {"$ref": "#/definitions/Pet"}}],
+        "responses": {"201": {"description": "Created", "schema": {"$ref": "#/definitions/Pet"}}}
+      }
+    }
+  },
+  "definitions": {"Pet": {"type": "object", "properties": {"name": {"type": "string", "example": "Rex"}}}}
+}`
+
+func toCollection(t *testing.T, doc string) map[string]interface{} {
+	t.Helper()
+
+	o, err := convert.ReadOpenAPI(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := convert.OpenAPIToCollection(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+func TestOpenAPIToCollection(t *testing.T) {
+	c, err := convert.OpenAPIToCollection(mustReadOpenAPI(t, testOpenAPI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Info.Name != "Orders API" {
+		t.Errorf("Name is incorrect, have: %s, want: %s", c.Info.Name, "Orders API")
+	}
+
+	if len(c.Variable) != 1 || c.Variable[0].Value != "https://us.example.com/v1" {
+		t.Errorf("Variables are incorrect, have: %+v", c.Variable[0])
+	}
+
+	if auth, ok := c.Auth.(*gen.Auth); !ok || auth.Type != "bearer" {
+		t.Errorf("Auth is incorrect, have: %+v", c.Auth)
+	}
+
+	if len(c.Item) != 2 {
+		t.Fatalf("Item count is incorrect, have: %d, want: %d", len(c.Item), 2)
+	}
+
+	folder, ok := c.Item[0].(*gen.ItemGroup)
+	if !ok || folder.Name != "orders" || len(folder.Item) != 2 {
+		t.Fatalf("Folder is incorrect, have: %+v", c.Item[0])
+	}
+
+	if item, ok := c.Item[1].(*gen.Item); !ok || item.Name != "Health" {
+		t.Errorf("Root item is incorrect, have: %+v", c.Item[1])
+	}
+}
+
+func TestOpenAPIToCollectionRequests(t *testing.T) {
+	m := toCollection(t, testOpenAPI)
+	folder := m["item"].([]interface{})[0].(map[string]interface{})
+	items := folder["item"].([]interface{})
+
+	get := items[0].(map[string]interface{})["request"].(map[string]interface{})
+	url := get["url"].(map[string]interface{})
+	if url["raw"] != "{{baseUrl}}/orders/:orderId" {
+		t.Errorf("URL is incorrect, have: %v", url["raw"])
+	}
+
+	variable := url["variable"].([]interface{})[0].(map[string]interface{})
+	if variable["key"] != "orderId" || variable["value"] != "abc" {
+		t.Errorf("Path variable is incorrect, have: %v", variable)
+	}
+
+	put := items[1].(map[string]interface{})["request"].(map[string]interface{})
+	if put["auth"].(map[string]interface{})["type"] != "noauth" {
+		t.Errorf("Auth is incorrect, have: %v", put["auth"])
+	}
+
+	var body map[string]interface{}
+	raw := put["body"].(map[string]interface{})["raw"].(string)
+	if err := json.Unmarshal([]byte(raw), &body); err != nil {
+		t.Fatal(err)
+	}
+
+	if body["id"] != "<uuid>" || body["total"] != "<number>" || body["createdAt"] != "<dateTime>" {
+		t.Errorf("Body example is incorrect, have: %v", body)
+	}
+}
+
+func TestSwaggerToCollection(t *testing.T) {
+	m := toCollection(t, testSwagger)
+
+	variable := m["variable"].([]interface{})[0].(map[string]interface{})
+	if variable["value"] != "https://pets.example.com/api" {
+		t.Errorf("Base URL is incorrect, have: %v", variable["value"])
+	}
+
+	auth := m["auth"].(map[string]interface{})
+	if auth["type"] != "apikey" {
+		t.Errorf("Auth type is incorrect, have: %v, want: %s", auth["type"], "apikey")
+	}
+
+	item := m["item"].([]interface{})[0].(map[string]interface{})
+	if item["name"] != "addPet" {
+		t.Errorf("Name is incorrect, have: %v, want: %s", item["name"], "addPet")
+	}
+
+	raw := item["request"].(map[string]interface{})["body"].(map[string]interface{})["raw"].(string)
+	if !strings.Contains(raw, `"Rex"`) {
+		t.Errorf("Body example is incorrect, have: %s", raw)
+	}
+
+	res := item["response"].([]interface{})[0].(map[string]interface{})
+	if res["code"] != float64(201) {
+		t.Errorf("Response code is incorrect, have: %v, want: %d", res["code"], 201)
+	}
+}
+
+func mustReadOpenAPI(t *testing.T, doc string) *convert.OpenAPI {
+	t.Helper()
+
+	o, err := convert.ReadOpenAPI(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return o
+}
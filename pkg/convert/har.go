@@ -0,0 +1,596 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+)
+
+// HAR is an HTTP Archive 1.2 document.
+type HAR struct {
+	Log *HARLog `json:"log"`
+}
+
+// HARLog is the root of an HTTP Archive.
+type HARLog struct {
+	Version string      `json:"version"`
+	Creator *HARCreator `json:"creator"`
+	Pages   []*HARPage  `json:"pages,omitempty"`
+	Entries []*HAREntry `json:"entries"`
+}
+
+// HARCreator identifies the application that created an archive.
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HARPage is a page in an archive.
+type HARPage struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// HAREntry is a single request and its response.
+type HAREntry struct {
+	StartedDateTime string       `json:"startedDateTime"`
+	Time            float64      `json:"time"`
+	Request         *HARRequest  `json:"request"`
+	Response        *HARResponse `json:"response"`
+	Cache           struct{}     `json:"cache"`
+	Timings         *HARTimings  `json:"timings"`
+	Comment         string       `json:"comment,omitempty"`
+}
+
+// HARRequest is a request in an archive.
+type HARRequest struct {
+	Method      string          `json:"method"`
+	URL         string          `json:"url"`
+	HTTPVersion string          `json:"httpVersion"`
+	Cookies     []*HARNameValue `json:"cookies"`
+	Headers     []*HARNameValue `json:"headers"`
+	QueryString []*HARNameValue `json:"queryString"`
+	PostData    *HARPostData    `json:"postData,omitempty"`
+	HeadersSize int             `json:"headersSize"`
+	BodySize    int             `json:"bodySize"`
+}
+
+// HARResponse is a response in an archive.
+type HARResponse struct {
+	Status      int             `json:"status"`
+	StatusText  string          `json:"statusText"`
+	HTTPVersion string          `json:"httpVersion"`
+	Cookies     []*HARNameValue `json:"cookies"`
+	Headers     []*HARNameValue `json:"headers"`
+	Content     *HARContent     `json:"content"`
+	RedirectURL string          `json:"redirectURL"`
+	HeadersSize int             `json:"headersSize"`
+	BodySize    int             `json:"bodySize"`
+}
+
+// HARNameValue is a header, cookie or query string parameter.
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARPostData is the body of a request.
+type HARPostData struct {
+	MimeType string      `json:"mimeType"`
+	Text     string      `json:"text,omitempty"`
+	Params   []*HARParam `json:"params,omitempty"`
+}
+
+// HARParam is a posted form parameter.
+type HARParam struct {
+	Name        string `json:"name"`
+	Value       string `json:"value,omitempty"`
+	FileName    string `json:"fileName,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// HARContent is the body of a response.
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// HARTimings are the timings of an entry in milliseconds.
+type HARTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// HARGrouping controls how imported requests are organized into folders.
+type HARGrouping string
+
+// Values for HARGrouping.
+const (
+	GroupByNone HARGrouping = ""
+	GroupByHost HARGrouping = "host"
+	GroupByPath HARGrouping = "path"
+)
+
+// ReadHAR reads an HTTP Archive.
+func ReadHAR(reader io.Reader) (*HAR, error) {
+	var h HAR
+	if err := json.NewDecoder(reader).Decode(&h); err != nil {
+		return nil, err
+	}
+
+	if h.Log == nil {
+		return nil, errors.New("document is not an HTTP Archive")
+	}
+
+	return &h, nil
+}
+
+// HARToCollection converts the entries of an archive into a collection.
+// Requests are grouped into folders by host or first path segment,
+// depending on groupBy. Recorded responses are saved as examples.
+func HARToCollection(h *HAR, name string, groupBy HARGrouping) (*gen.Collection, error) {
+	if h == nil || h.Log == nil {
+		return nil, errors.New("no HTTP Archive")
+	}
+
+	if name == "" && len(h.Log.Pages) > 0 {
+		name = h.Log.Pages[0].Title
+	}
+	if name == "" {
+		name = "HAR Import"
+	}
+
+	c := &gen.Collection{
+		Info: &gen.Info{
+			Name:   name,
+			Schema: collectionSchemaURL,
+		},
+		Item: []interface{}{},
+	}
+
+	folders := make(map[string]*gen.ItemGroup)
+	for _, e := range h.Log.Entries {
+		if e.Request == nil {
+			continue
+		}
+
+		u, err := url.Parse(e.Request.URL)
+		if err != nil {
+			return nil, err
+		}
+
+		item := harItem(e, u)
+
+		folder := ""
+		switch groupBy {
+		case GroupByHost:
+			folder = u.Host
+		case GroupByPath:
+			folder = strings.SplitN(strings.TrimPrefix(u.Path, "/"), "/", 2)[0]
+		}
+
+		if folder == "" {
+			c.Item = append(c.Item, item)
+			continue
+		}
+
+		f, ok := folders[folder]
+		if !ok {
+			f = &gen.ItemGroup{Name: folder, Item: []interface{}{}}
+			folders[folder] = f
+			c.Item = append(c.Item, f)
+		}
+		f.Item = append(f.Item, item)
+	}
+
+	return c, nil
+}
+
+func harItem(e *HAREntry, u *url.URL) *gen.Item {
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	r := &resources.Request{
+		Method: strings.ToUpper(e.Request.Method),
+		URL:    resources.ParseURL(e.Request.URL),
+	}
+
+	for _, h := range e.Request.Headers {
+		// HTTP/2 pseudo-headers such as :authority are not real headers.
+		if strings.HasPrefix(h.Name, ":") {
+			continue
+		}
+		r.Header = append(r.Header, &resources.KeyValue{Key: h.Name, Value: h.Value})
+	}
+
+	if p := e.Request.PostData; p != nil {
+		r.Body = harRequestBody(p)
+	}
+
+	item := &gen.Item{
+		Name:    r.Method + " " + path,
+		Request: r,
+	}
+
+	if res := e.Response; res != nil && res.Status > 0 {
+		saved := &gen.Response{
+			Code:            res.Status,
+			Status:          res.StatusText,
+			OriginalRequest: r,
+			ResponseTime:    e.Time,
+		}
+		if saved.Status == "" {
+			saved.Status = http.StatusText(res.Status)
+		}
+
+		var headers []*resources.KeyValue
+		for _, h := range res.Headers {
+			headers = append(headers, &resources.KeyValue{Key: h.Name, Value: h.Value})
+		}
+		saved.Header = headers
+
+		if res.Content != nil {
+			saved.Body = res.Content.Text
+			if res.Content.Encoding == "base64" {
+				if b, err := base64.StdEncoding.DecodeString(res.Content.Text); err == nil {
+					saved.Body = string(b)
+				}
+			}
+		}
+
+		item.Response = append(item.Response, saved)
+	}
+
+	return item
+}
+
+func harRequestBody(p *HARPostData) *resources.Body {
+	mimeType := mediaTypeKey(p.MimeType)
+
+	if len(p.Params) > 0 {
+		var params []*resources.KeyValue
+		for _, param := range p.Params {
+			kv := &resources.KeyValue{Key: param.Name, Value: param.Value, Type: "text"}
+			if param.FileName != "" {
+				kv.Type = "file"
+				kv.Value = ""
+				kv.Src = param.FileName
+			}
+			params = append(params, kv)
+		}
+
+		if mimeType == "multipart/form-data" {
+			return &resources.Body{Mode: "formdata", FormData: params}
+		}
+		return &resources.Body{Mode: "urlencoded", URLEncoded: params}
+	}
+
+	return &resources.Body{
+		Mode:    "raw",
+		Raw:     p.Text,
+		Options: rawOptions(mimeType),
+	}
+}
+
+// CollectionToHAR converts the requests of a collection into an archive.
+// Collection variables are resolved, and each saved example becomes an entry
+// of its own. Requests without examples are exported with an empty response.
+func CollectionToHAR(c *resources.Collection) (*HAR, error) {
+	if c == nil || c.Collection == nil {
+		return nil, errors.New("no collection")
+	}
+
+	vars := make(map[string]string)
+	for _, v := range c.Variable {
+		if !v.Disabled {
+			vars[v.Key] = valueString(v.Value)
+		}
+	}
+
+	started := time.Now().UTC().Format(time.RFC3339Nano)
+	h := newHAR()
+
+	err := walkItems(c.Item, nil, func(item *gen.Item, folders []*gen.ItemGroup) error {
+		r, err := resources.DecodeRequest(item.Request)
+		if err != nil {
+			return err
+		}
+
+		if len(item.Response) == 0 {
+			h.Log.Entries = append(h.Log.Entries, &HAREntry{
+				StartedDateTime: started,
+				Request:         harRequest(r, vars),
+				Response:        harResponse(0, "", nil, ""),
+				Timings:         &HARTimings{},
+				Comment:         item.Name,
+			})
+			return nil
+		}
+
+		for _, genResponse := range item.Response {
+			res, err := resources.DecodeResponse(genResponse)
+			if err != nil {
+				return err
+			}
+
+			req := r
+			if res.OriginalRequest != nil {
+				req = res.OriginalRequest
+			}
+
+			entry := &HAREntry{
+				StartedDateTime: started,
+				Request:         harRequest(req, vars),
+				Response:        harResponse(res.Code, res.Status, res.Header, res.Body),
+				Timings:         &HARTimings{},
+				Comment:         item.Name,
+			}
+			if t, ok := res.ResponseTime.(float64); ok {
+				entry.Time = t
+				entry.Timings.Wait = t
+			}
+
+			h.Log.Entries = append(h.Log.Entries, entry)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// MonitorRunToHAR converts the executions of a monitor run, as returned by
+// the Postman API, into an archive.
+func MonitorRunToHAR(b []byte) (*HAR, error) {
+	var result struct {
+		Run struct {
+			Executions []struct {
+				Item struct {
+					Name string `json:"name"`
+				} `json:"item"`
+				Request struct {
+					Method    string                 `json:"method"`
+					URL       string                 `json:"url"`
+					Headers   map[string]interface{} `json:"headers"`
+					Body      interface{}            `json:"body"`
+					Timestamp string                 `json:"timestamp"`
+				} `json:"request"`
+				Response struct {
+					Code         int                    `json:"code"`
+					Body         interface{}            `json:"body"`
+					ResponseTime float64                `json:"responseTime"`
+					Headers      map[string]interface{} `json:"headers"`
+				} `json:"response"`
+			} `json:"executions"`
+		} `json:"run"`
+	}
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, err
+	}
+
+	h := newHAR()
+	for _, e := range result.Run.Executions {
+		r := &resources.Request{
+			Method: strings.ToUpper(e.Request.Method),
+			URL:    resources.ParseURL(e.Request.URL),
+			Header: mapHeaders(e.Request.Headers),
+		}
+		if body := runBody(e.Request.Body); body != "" {
+			r.Body = &resources.Body{Mode: "raw", Raw: body}
+		}
+
+		started := e.Request.Timestamp
+		if started == "" {
+			started = time.Now().UTC().Format(time.RFC3339Nano)
+		}
+
+		h.Log.Entries = append(h.Log.Entries, &HAREntry{
+			StartedDateTime: started,
+			Time:            e.Response.ResponseTime,
+			Request:         harRequest(r, nil),
+			Response:        harResponse(e.Response.Code, "", mapHeaders(e.Response.Headers), runBody(e.Response.Body)),
+			Timings:         &HARTimings{Wait: e.Response.ResponseTime},
+			Comment:         e.Item.Name,
+		})
+	}
+
+	return h, nil
+}
+
+func newHAR() *HAR {
+	return &HAR{Log: &HARLog{
+		Version: "1.2",
+		Creator: &HARCreator{Name: "postmanctl"},
+		Entries: []*HAREntry{},
+	}}
+}
+
+func harRequest(r *resources.Request, vars map[string]string) *HARRequest {
+	resolve := func(s string) string {
+		return variablePattern.ReplaceAllStringFunc(s, func(m string) string {
+			if v, ok := vars[variablePattern.FindStringSubmatch(m)[1]]; ok {
+				return v
+			}
+			return m
+		})
+	}
+
+	raw := r.URL.String()
+	for _, v := range r.URL.Variable {
+		raw = strings.Replace(raw, "/:"+v.Key, "/"+valueString(v.Value), -1)
+	}
+	raw = resolve(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+
+	h := &HARRequest{
+		Method:      r.Method,
+		URL:         raw,
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []*HARNameValue{},
+		Headers:     []*HARNameValue{},
+		QueryString: []*HARNameValue{},
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+
+	if u, err := url.Parse(raw); err == nil {
+		for _, pair := range strings.Split(u.RawQuery, "&") {
+			if pair == "" {
+				continue
+			}
+			kv := strings.SplitN(pair, "=", 2)
+			q := &HARNameValue{Name: queryUnescape(kv[0])}
+			if len(kv) > 1 {
+				q.Value = queryUnescape(kv[1])
+			}
+			h.QueryString = append(h.QueryString, q)
+		}
+	}
+
+	for _, kv := range r.Header {
+		if !kv.Disabled {
+			h.Headers = append(h.Headers, &HARNameValue{Name: kv.Key, Value: resolve(kv.Value)})
+		}
+	}
+
+	if r.Body == nil || r.Body.Disabled {
+		return h
+	}
+
+	contentType := headerValue(r.Header, "Content-Type")
+	switch r.Body.Mode {
+	case "raw":
+		if contentType == "" {
+			contentType = rawContentType(r.Body.Language(), r.Body.Raw)
+		}
+		h.PostData = &HARPostData{MimeType: contentType, Text: resolve(r.Body.Raw)}
+	case "urlencoded":
+		form := url.Values{}
+		h.PostData = &HARPostData{MimeType: "application/x-www-form-urlencoded"}
+		for _, kv := range r.Body.URLEncoded {
+			if kv.Disabled {
+				continue
+			}
+			form.Add(kv.Key, resolve(kv.Value))
+			h.PostData.Params = append(h.PostData.Params, &HARParam{Name: kv.Key, Value: resolve(kv.Value)})
+		}
+		h.PostData.Text = form.Encode()
+	case "formdata":
+		h.PostData = &HARPostData{MimeType: "multipart/form-data"}
+		for _, kv := range r.Body.FormData {
+			if kv.Disabled {
+				continue
+			}
+			p := &HARParam{Name: kv.Key, Value: resolve(kv.Value)}
+			if kv.Type == "file" {
+				p.Value = ""
+				p.FileName = valueString(kv.Src)
+			}
+			h.PostData.Params = append(h.PostData.Params, p)
+		}
+	}
+
+	if h.PostData != nil {
+		h.BodySize = len(h.PostData.Text)
+	}
+
+	return h
+}
+
+func harResponse(code int, status string, headers []*resources.KeyValue, body string) *HARResponse {
+	if status == "" {
+		status = http.StatusText(code)
+	}
+
+	mimeType := headerValue(headers, "Content-Type")
+	if mimeType == "" && body != "" {
+		mimeType = rawContentType("", body)
+	}
+
+	res := &HARResponse{
+		Status:      code,
+		StatusText:  status,
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []*HARNameValue{},
+		Headers:     []*HARNameValue{},
+		Content:     &HARContent{Size: len(body), MimeType: mimeType, Text: body},
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
+
+	for _, kv := range headers {
+		res.Headers = append(res.Headers, &HARNameValue{Name: kv.Key, Value: kv.Value})
+	}
+
+	return res
+}
+
+func queryUnescape(s string) string {
+	if u, err := url.QueryUnescape(s); err == nil {
+		return u
+	}
+
+	return s
+}
+
+// mapHeaders converts a header map, as used by monitor runs, to a list.
+func mapHeaders(m map[string]interface{}) []*resources.KeyValue {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var headers []*resources.KeyValue
+	for _, k := range keys {
+		headers = append(headers, &resources.KeyValue{Key: k, Value: valueString(m[k])})
+	}
+
+	return headers
+}
+
+// runBody returns the body of a monitor run request or response as text.
+func runBody(v interface{}) string {
+	if m, ok := v.(map[string]interface{}); ok {
+		if raw, ok := m["raw"].(string); ok {
+			return raw
+		}
+	}
+
+	return valueString(v)
+}
@@ -0,0 +1,220 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+)
+
+const testHAR = `{
+  "log": {
+    "version": "1.2",
+    "creator": {"name": "test", "version": "1"},
+    "entries": [
+      {
+        "startedDateTime": "2020-05-01T10:00:00Z",
+        "time": 42,
+        "request": {
+          "method": "POST",
+          "url": "https://api.example.com/users?page=2",
+          "headers": [
+            {"name": ":authority", "value": "api.example.com"},
+            {"name": "Content-Type", "value": "application/x-www-form-urlencoded"}
+          ],
+          "postData": {
+            "mimeType": "application/x-www-form-urlencoded",
+            "params": [{"name": "name", "value": "Jane"}]
+          }
+        },
+        "response": {
+          "status": 201,
+          "statusText": "Created",
+          "headers": [{"name": "Content-Type", "value": "application/json"}],
+          "content": {"mimeType": "application/json", "text": "eyJpZCI6MX0=", "encoding": "base64"}
+        }
+      },
+      {
+        "startedDateTime": "2020-05-01T10:00:01Z",
+        "time": 10,
+        "request": {"method": "GET", "url": "https://api.example.com/users/1", "headers": []},
+        "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"mimeType": "text/plain", "text": "ok"}}
+      },
+      {
+        "startedDateTime": "2020-05-01T10:00:02Z",
+        "time": 5,
+        "request": {"method": "GET", "url": "https://cdn.example.com/app.js", "headers": []},
+        "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"mimeType": "text/javascript", "text": ""}}
+      }
+    ]
+  }
+}`
+
+func TestHARToCollectionGroupByHost(t *testing.T) {
+	h, err := convert.ReadHAR(strings.NewReader(testHAR))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := convert.HARToCollection(h, "Traffic", convert.GroupByHost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Info.Name != "Traffic" {
+		t.Errorf("Name is incorrect, have: %s, want: %s", c.Info.Name, "Traffic")
+	}
+
+	if len(c.Item) != 2 {
+		t.Fatalf("Folder count is incorrect, have: %d, want: %d", len(c.Item), 2)
+	}
+
+	api := c.Item[0].(*gen.ItemGroup)
+	if api.Name != "api.example.com" || len(api.Item) != 2 {
+		t.Errorf("Folder is incorrect, have: %s with %d items", api.Name, len(api.Item))
+	}
+
+	item := api.Item[0].(*gen.Item)
+	if item.Name != "POST /users" {
+		t.Errorf("Name is incorrect, have: %s, want: %s", item.Name, "POST /users")
+	}
+
+	if len(item.Response) != 1 || item.Response[0].Body != `{"id":1}` {
+		t.Errorf("Response is incorrect, have: %+v", item.Response)
+	}
+
+	b, err := json.Marshal(item.Request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(b), ":authority") {
+		t.Errorf("Pseudo-headers should be skipped, have: %s", b)
+	}
+
+	if !strings.Contains(string(b), `"mode":"urlencoded"`) {
+		t.Errorf("Body mode is incorrect, have: %s", b)
+	}
+}
+
+func TestHARToCollectionGroupByPath(t *testing.T) {
+	h, err := convert.ReadHAR(strings.NewReader(testHAR))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := convert.HARToCollection(h, "", convert.GroupByPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c.Item) != 2 {
+		t.Fatalf("Item count is incorrect, have: %d, want: %d", len(c.Item), 2)
+	}
+
+	if f := c.Item[0].(*gen.ItemGroup); f.Name != "users" || len(f.Item) != 2 {
+		t.Errorf("Folder is incorrect, have: %s with %d items", f.Name, len(f.Item))
+	}
+
+	if f := c.Item[1].(*gen.ItemGroup); f.Name != "app.js" {
+		t.Errorf("Folder is incorrect, have: %s, want: %s", f.Name, "app.js")
+	}
+}
+
+func TestCollectionToHAR(t *testing.T) {
+	c, err := convert.ReadCollection(strings.NewReader(`{
+		"info": {"name": "Test", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"variable": [{"key": "baseUrl", "value": "https://api.example.com"}],
+		"item": [{
+			"name": "Get user",
+			"request": {
+				"method": "GET",
+				"url": {
+					"raw": "{{baseUrl}}/users/:id?expand=true",
+					"host": ["{{baseUrl}}"],
+					"path": ["users", ":id"],
+					"query": [{"key": "expand", "value": "true"}],
+					"variable": [{"key": "id", "value": "7"}]
+				},
+				"header": [{"key": "X-Trace", "value": "1", "disabled": true}]
+			},
+			"response": [{"name": "OK", "code": 200, "status": "OK", "body": "{}", "responseTime": 30,
+				"header": [{"key": "Content-Type", "value": "application/json"}]}]
+		}]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := convert.CollectionToHAR(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(h.Log.Entries) != 1 {
+		t.Fatalf("Entry count is incorrect, have: %d, want: %d", len(h.Log.Entries), 1)
+	}
+
+	e := h.Log.Entries[0]
+	if want := "https://api.example.com/users/7?expand=true"; e.Request.URL != want {
+		t.Errorf("URL is incorrect, have: %s, want: %s", e.Request.URL, want)
+	}
+
+	if len(e.Request.Headers) != 0 {
+		t.Errorf("Disabled headers should be skipped, have: %v", e.Request.Headers)
+	}
+
+	if len(e.Request.QueryString) != 1 || e.Request.QueryString[0].Name != "expand" {
+		t.Errorf("Query string is incorrect, have: %v", e.Request.QueryString)
+	}
+
+	if e.Response.Status != 200 || e.Response.Content.MimeType != "application/json" || e.Time != 30 {
+		t.Errorf("Response is incorrect, have: %+v", e.Response)
+	}
+}
+
+func TestMonitorRunToHAR(t *testing.T) {
+	h, err := convert.MonitorRunToHAR([]byte(`{
+		"run": {
+			"executions": [{
+				"item": {"name": "Ping"},
+				"request": {"method": "GET", "url": "https://example.com/ping", "headers": {"User-Agent": "PostmanRuntime"}, "timestamp": "2020-05-01T10:00:00.000Z"},
+				"response": {"code": 200, "responseTime": 15, "body": {"ok": true}, "headers": {"Content-Type": "application/json"}}
+			}]
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(h.Log.Entries) != 1 {
+		t.Fatalf("Entry count is incorrect, have: %d, want: %d", len(h.Log.Entries), 1)
+	}
+
+	e := h.Log.Entries[0]
+	if e.Comment != "Ping" || e.StartedDateTime != "2020-05-01T10:00:00.000Z" || e.Time != 15 {
+		t.Errorf("Entry is incorrect, have: %+v", e)
+	}
+
+	if e.Response.Content.Text != `{"ok":true}` {
+		t.Errorf("Body is incorrect, have: %s", e.Response.Content.Text)
+	}
+}
@@ -0,0 +1,266 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+// jmeterFunctions maps Postman dynamic variables to JMeter functions.
+var jmeterFunctions = map[string]string{
+	"$guid":         "${__UUID()}",
+	"$randomUUID":   "${__UUID()}",
+	"$timestamp":    "${__time(/1000,)}",
+	"$isoTimestamp": "${__time(yyyy-MM-dd'T'HH:mm:ss.SSS'Z',)}",
+	"$randomInt":    "${__Random(0,1000,)}",
+}
+
+// CollectionToJMeter converts a collection into a JMeter test plan (JMX).
+// Each request becomes an HTTP sampler in a single thread group, with a
+// status code assertion when the request has saved examples.
+func CollectionToJMeter(c *resources.Collection, opts LoadTestOptions) ([]byte, error) {
+	requests, vars, err := loadTestPlan(c)
+	if err != nil {
+		return nil, err
+	}
+
+	threads := opts.Threads
+	if threads < 1 {
+		threads = 1
+	}
+
+	w := &jmxWriter{}
+	w.line(0, `<?xml version="1.0" encoding="UTF-8"?>`)
+	w.line(0, `<jmeterTestPlan version="1.2" properties="5.0" jmeter="5.2.1">`)
+	w.line(1, `<hashTree>`)
+	w.line(2, `<TestPlan guiclass="TestPlanGui" testclass="TestPlan" testname="%s" enabled="true">`, xmlEscape(c.Info.Name))
+	w.prop(3, "boolProp", "TestPlan.functional_mode", "false")
+	w.prop(3, "boolProp", "TestPlan.serialize_threadgroups", "false")
+	w.line(3, `<elementProp name="TestPlan.user_defined_variables" elementType="Arguments" guiclass="ArgumentsPanel" testclass="Arguments" testname="User Defined Variables" enabled="true">`)
+	w.line(4, `<collectionProp name="Arguments.arguments">`)
+	for _, v := range vars {
+		w.line(5, `<elementProp name="%s" elementType="Argument">`, xmlEscape(v.Key))
+		w.prop(6, "stringProp", "Argument.name", v.Key)
+		w.prop(6, "stringProp", "Argument.value", jmeterVariables(valueString(v.Value)))
+		w.prop(6, "stringProp", "Argument.metadata", "=")
+		w.line(5, `</elementProp>`)
+	}
+	w.line(4, `</collectionProp>`)
+	w.line(3, `</elementProp>`)
+	w.line(2, `</TestPlan>`)
+	w.line(2, `<hashTree>`)
+
+	if opts.DataFile != "" {
+		w.line(3, `<CSVDataSet guiclass="TestBeanGUI" testclass="CSVDataSet" testname="Environments" enabled="true">`)
+		w.prop(4, "stringProp", "filename", opts.DataFile)
+		w.prop(4, "stringProp", "fileEncoding", "UTF-8")
+		w.prop(4, "stringProp", "variableNames", strings.Join(opts.DataColumns, ","))
+		w.prop(4, "boolProp", "ignoreFirstLine", "true")
+		w.prop(4, "stringProp", "delimiter", ",")
+		w.prop(4, "boolProp", "quotedData", "true")
+		w.prop(4, "boolProp", "recycle", "true")
+		w.prop(4, "boolProp", "stopThread", "false")
+		w.prop(4, "stringProp", "shareMode", "shareMode.all")
+		w.line(3, `</CSVDataSet>`)
+		w.line(3, `<hashTree/>`)
+	}
+
+	loops := "1"
+	if opts.Duration > 0 {
+		loops = "-1"
+	}
+
+	w.line(3, `<ThreadGroup guiclass="ThreadGroupGui" testclass="ThreadGroup" testname="%s" enabled="true">`, xmlEscape(c.Info.Name))
+	w.line(4, `<elementProp name="ThreadGroup.main_controller" elementType="LoopController" guiclass="LoopControlPanel" testclass="LoopController" enabled="true">`)
+	w.prop(5, "boolProp", "LoopController.continue_forever", "false")
+	w.prop(5, "stringProp", "LoopController.loops", loops)
+	w.line(4, `</elementProp>`)
+	w.prop(4, "stringProp", "ThreadGroup.num_threads", strconv.Itoa(threads))
+	w.prop(4, "stringProp", "ThreadGroup.ramp_time", strconv.Itoa(int(opts.RampUp.Seconds())))
+	w.prop(4, "boolProp", "ThreadGroup.scheduler", strconv.FormatBool(opts.Duration > 0))
+	w.prop(4, "stringProp", "ThreadGroup.duration", strconv.Itoa(int(opts.Duration.Seconds())))
+	w.prop(4, "stringProp", "ThreadGroup.delay", "0")
+	w.prop(4, "stringProp", "ThreadGroup.on_sample_error", "continue")
+	w.prop(4, "boolProp", "ThreadGroup.same_user_on_next_iteration", "true")
+	w.line(3, `</ThreadGroup>`)
+	w.line(3, `<hashTree>`)
+
+	for _, r := range requests {
+		w.sampler(r)
+	}
+
+	w.line(3, `</hashTree>`)
+	w.line(2, `</hashTree>`)
+	w.line(1, `</hashTree>`)
+	w.line(0, `</jmeterTestPlan>`)
+
+	return w.buf.Bytes(), nil
+}
+
+type jmxWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *jmxWriter) line(indent int, format string, args ...interface{}) {
+	w.buf.WriteString(strings.Repeat("  ", indent))
+	fmt.Fprintf(&w.buf, format, args...)
+	w.buf.WriteString("\n")
+}
+
+func (w *jmxWriter) prop(indent int, kind, name, value string) {
+	w.line(indent, `<%s name="%s">%s</%s>`, kind, xmlEscape(name), xmlEscape(value), kind)
+}
+
+func (w *jmxWriter) sampler(r *loadTestRequest) {
+	name := r.Name
+	if r.Folder != "" {
+		name = r.Folder + "/" + r.Name
+	}
+
+	w.line(4, `<HTTPSamplerProxy guiclass="HttpTestSampleGui" testclass="HTTPSamplerProxy" testname="%s" enabled="true">`, xmlEscape(name))
+
+	switch {
+	case r.Raw != "":
+		w.prop(5, "boolProp", "HTTPSampler.postBodyRaw", "true")
+		w.line(5, `<elementProp name="HTTPsampler.Arguments" elementType="Arguments">`)
+		w.line(6, `<collectionProp name="Arguments.arguments">`)
+		w.line(7, `<elementProp name="" elementType="HTTPArgument">`)
+		w.prop(8, "boolProp", "HTTPArgument.always_encode", "false")
+		w.prop(8, "stringProp", "Argument.value", jmeterVariables(r.Raw))
+		w.prop(8, "stringProp", "Argument.metadata", "=")
+		w.line(7, `</elementProp>`)
+		w.line(6, `</collectionProp>`)
+		w.line(5, `</elementProp>`)
+	case len(r.Form) > 0 || len(r.FormData) > 0:
+		var files []*resources.KeyValue
+		w.line(5, `<elementProp name="HTTPsampler.Arguments" elementType="Arguments" guiclass="HTTPArgumentsPanel" testclass="Arguments" enabled="true">`)
+		w.line(6, `<collectionProp name="Arguments.arguments">`)
+		for _, p := range append(append([]*resources.KeyValue{}, r.Form...), r.FormData...) {
+			if p.Type == "file" {
+				files = append(files, p)
+				continue
+			}
+			w.line(7, `<elementProp name="%s" elementType="HTTPArgument">`, xmlEscape(p.Key))
+			w.prop(8, "boolProp", "HTTPArgument.always_encode", "true")
+			w.prop(8, "stringProp", "Argument.name", jmeterVariables(p.Key))
+			w.prop(8, "stringProp", "Argument.value", jmeterVariables(p.Value))
+			w.prop(8, "stringProp", "Argument.metadata", "=")
+			w.prop(8, "boolProp", "HTTPArgument.use_equals", "true")
+			w.line(7, `</elementProp>`)
+		}
+		w.line(6, `</collectionProp>`)
+		w.line(5, `</elementProp>`)
+
+		if len(files) > 0 {
+			w.line(5, `<elementProp name="HTTPsampler.Files" elementType="HTTPFileArgs">`)
+			w.line(6, `<collectionProp name="HTTPFileArgs.files">`)
+			for _, f := range files {
+				src := valueString(f.Src)
+				w.line(7, `<elementProp name="%s" elementType="HTTPFileArg">`, xmlEscape(src))
+				w.prop(8, "stringProp", "File.path", src)
+				w.prop(8, "stringProp", "File.paramname", f.Key)
+				w.prop(8, "stringProp", "File.mimetype", "")
+				w.line(7, `</elementProp>`)
+			}
+			w.line(6, `</collectionProp>`)
+			w.line(5, `</elementProp>`)
+		}
+	}
+
+	w.prop(5, "stringProp", "HTTPSampler.domain", "")
+	w.prop(5, "stringProp", "HTTPSampler.port", "")
+	w.prop(5, "stringProp", "HTTPSampler.protocol", "")
+	// An absolute URL in the path is used as is when the domain is empty,
+	// which allows variables such as {{baseUrl}} to carry the scheme.
+	w.prop(5, "stringProp", "HTTPSampler.path", jmeterVariables(r.URL))
+	w.prop(5, "stringProp", "HTTPSampler.method", r.Method)
+	w.prop(5, "boolProp", "HTTPSampler.follow_redirects", "true")
+	w.prop(5, "boolProp", "HTTPSampler.use_keepalive", "true")
+	w.prop(5, "boolProp", "HTTPSampler.DO_MULTIPART_POST", strconv.FormatBool(len(r.FormData) > 0))
+	w.line(4, `</HTTPSamplerProxy>`)
+	w.line(4, `<hashTree>`)
+
+	if len(r.Headers) > 0 {
+		w.line(5, `<HeaderManager guiclass="HeaderPanel" testclass="HeaderManager" testname="HTTP Header Manager" enabled="true">`)
+		w.line(6, `<collectionProp name="HeaderManager.headers">`)
+		for _, h := range r.Headers {
+			w.line(7, `<elementProp name="" elementType="Header">`)
+			w.prop(8, "stringProp", "Header.name", jmeterVariables(h.Key))
+			w.prop(8, "stringProp", "Header.value", jmeterVariables(h.Value))
+			w.line(7, `</elementProp>`)
+		}
+		w.line(6, `</collectionProp>`)
+		w.line(5, `</HeaderManager>`)
+		w.line(5, `<hashTree/>`)
+	}
+
+	if r.Username != "" || r.Password != "" {
+		w.line(5, `<AuthManager guiclass="AuthPanel" testclass="AuthManager" testname="HTTP Authorization Manager" enabled="true">`)
+		w.line(6, `<collectionProp name="AuthManager.auth_list">`)
+		w.line(7, `<elementProp name="" elementType="Authorization">`)
+		w.prop(8, "stringProp", "Authorization.url", "")
+		w.prop(8, "stringProp", "Authorization.username", jmeterVariables(r.Username))
+		w.prop(8, "stringProp", "Authorization.password", jmeterVariables(r.Password))
+		w.prop(8, "stringProp", "Authorization.domain", "")
+		w.prop(8, "stringProp", "Authorization.realm", "")
+		w.line(7, `</elementProp>`)
+		w.line(6, `</collectionProp>`)
+		w.line(5, `</AuthManager>`)
+		w.line(5, `<hashTree/>`)
+	}
+
+	if len(r.Codes) > 0 {
+		w.line(5, `<ResponseAssertion guiclass="AssertionGui" testclass="ResponseAssertion" testname="Status code" enabled="true">`)
+		// "Asserion" is spelled as JMeter expects it.
+		w.line(6, `<collectionProp name="Asserion.test_strings">`)
+		for _, code := range r.Codes {
+			w.prop(7, "stringProp", strconv.Itoa(code), strconv.Itoa(code))
+		}
+		w.line(6, `</collectionProp>`)
+		w.prop(6, "stringProp", "Assertion.test_field", "Assertion.response_code")
+		w.prop(6, "boolProp", "Assertion.assume_success", "false")
+		// Equals (8), any of the patterns (32).
+		w.prop(6, "intProp", "Assertion.test_type", "40")
+		w.line(5, `</ResponseAssertion>`)
+		w.line(5, `<hashTree/>`)
+	}
+
+	w.line(4, `</hashTree>`)
+}
+
+// jmeterVariables rewrites {{name}} references as ${name}.
+func jmeterVariables(s string) string {
+	return variablePattern.ReplaceAllStringFunc(s, func(m string) string {
+		name := variablePattern.FindStringSubmatch(m)[1]
+		if f, ok := jmeterFunctions[name]; ok {
+			return f
+		}
+		return "${" + name + "}"
+	})
+}
+
+func xmlEscape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,248 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+// k6Prelude resolves {{variable}} references at runtime and reads the CSV
+// data set, so values from environments can change between iterations.
+const k6Prelude = `function resolve(vars, s) {
+  return s.replace(/{{\s*([^{}\s]+)\s*}}/g, (m, name) => {
+    if (name in vars) {
+      return vars[name];
+    }
+    if (name === '$guid' || name === '$randomUUID') {
+      return uuid();
+    }
+    if (name === '$timestamp') {
+      return String(Math.floor(Date.now() / 1000));
+    }
+    if (name === '$isoTimestamp') {
+      return new Date().toISOString();
+    }
+    if (name === '$randomInt') {
+      return String(Math.floor(Math.random() * 1001));
+    }
+    return m;
+  });
+}
+
+function uuid() {
+  return 'xxxxxxxx-xxxx-4xxx-yxxx-xxxxxxxxxxxx'.replace(/[xy]/g, (c) => {
+    const r = (Math.random() * 16) | 0;
+    return (c === 'x' ? r : (r & 0x3) | 0x8).toString(16);
+  });
+}
+
+function parseCSV(text) {
+  const rows = [];
+  let row = [];
+  let field = '';
+  let quoted = false;
+  for (let i = 0; i < text.length; i++) {
+    const c = text[i];
+    if (quoted) {
+      if (c === '"' && text[i + 1] === '"') {
+        field += '"';
+        i++;
+      } else if (c === '"') {
+        quoted = false;
+      } else {
+        field += c;
+      }
+    } else if (c === '"') {
+      quoted = true;
+    } else if (c === ',') {
+      row.push(field);
+      field = '';
+    } else if (c === '\n') {
+      row.push(field);
+      rows.push(row);
+      row = [];
+      field = '';
+    } else if (c !== '\r') {
+      field += c;
+    }
+  }
+  if (field !== '' || row.length > 0) {
+    row.push(field);
+    rows.push(row);
+  }
+
+  const header = rows.shift() || [];
+  return rows.map((r) => header.reduce((o, h, i) => {
+    o[h] = r[i] || '';
+    return o;
+  }, {}));
+}
+`
+
+// CollectionToK6 converts a collection into a k6 script. Requests in a
+// folder run in a group named after the folder, and saved examples become
+// status code checks.
+func CollectionToK6(c *resources.Collection, opts LoadTestOptions) ([]byte, error) {
+	requests, vars, err := loadTestPlan(c)
+	if err != nil {
+		return nil, err
+	}
+
+	vus := opts.Threads
+	if vus < 1 {
+		vus = 1
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Generated by postmanctl from the collection " + jsString(c.Info.Name) + ".\n")
+	b.WriteString("import http from 'k6/http';\n")
+	b.WriteString("import encoding from 'k6/encoding';\n")
+	b.WriteString("import { check, group } from 'k6';\n\n")
+
+	b.WriteString("export const options = {\n")
+	switch {
+	case opts.Duration > 0 && opts.RampUp > 0:
+		fmt.Fprintf(&b, "  stages: [\n    { duration: '%s', target: %d },\n    { duration: '%s', target: %d },\n  ],\n",
+			k6Duration(opts.RampUp), vus, k6Duration(opts.Duration), vus)
+	case opts.Duration > 0:
+		fmt.Fprintf(&b, "  vus: %d,\n  duration: '%s',\n", vus, k6Duration(opts.Duration))
+	default:
+		fmt.Fprintf(&b, "  vus: %d,\n  iterations: %d,\n", vus, vus)
+	}
+	b.WriteString("};\n\n")
+
+	b.WriteString("const variables = {\n")
+	for _, v := range vars {
+		fmt.Fprintf(&b, "  %s: %s,\n", jsString(v.Key), jsString(valueString(v.Value)))
+	}
+	b.WriteString("};\n\n")
+
+	if opts.DataFile != "" {
+		fmt.Fprintf(&b, "const data = parseCSV(open(%s));\n\n", jsString(opts.DataFile))
+	} else {
+		b.WriteString("const data = [];\n\n")
+	}
+
+	// open() is only available in the init context, so files uploaded by
+	// form requests are read up front.
+	b.WriteString("const files = {\n")
+	seen := make(map[string]bool)
+	for _, r := range requests {
+		for _, p := range r.FormData {
+			if src := valueString(p.Src); p.Type == "file" && !seen[src] {
+				seen[src] = true
+				fmt.Fprintf(&b, "  %s: open(%s, 'b'),\n", jsString(src), jsString(src))
+			}
+		}
+	}
+	b.WriteString("};\n\n")
+
+	b.WriteString(k6Prelude)
+	b.WriteString("\nexport default function () {\n")
+	b.WriteString("  const vars = Object.assign({}, variables, data.length ? data[__ITER % data.length] : {});\n")
+	b.WriteString("  let res;\n")
+
+	for _, r := range requests {
+		indent := "  "
+		if r.Folder != "" {
+			fmt.Fprintf(&b, "\n  group(%s, function () {\n", jsString(r.Folder))
+			indent = "    "
+		} else {
+			b.WriteString("\n")
+		}
+
+		writeK6Request(&b, indent, r)
+
+		if r.Folder != "" {
+			b.WriteString("  });\n")
+		}
+	}
+
+	b.WriteString("}\n")
+
+	return b.Bytes(), nil
+}
+
+func writeK6Request(b *bytes.Buffer, indent string, r *loadTestRequest) {
+	fmt.Fprintf(b, "%s// %s\n", indent, strings.Replace(r.Name, "\n", " ", -1))
+
+	var headers []string
+	for _, h := range r.Headers {
+		headers = append(headers, fmt.Sprintf("%s: resolve(vars, %s)", jsString(h.Key), jsString(h.Value)))
+	}
+	if r.Username != "" || r.Password != "" {
+		headers = append(headers, fmt.Sprintf("'Authorization': 'Basic ' + encoding.b64encode(resolve(vars, %s))",
+			jsString(r.Username+":"+r.Password)))
+	}
+
+	body := "null"
+	switch {
+	case r.Raw != "":
+		body = fmt.Sprintf("resolve(vars, %s)", jsString(r.Raw))
+	case len(r.Form) > 0 || len(r.FormData) > 0:
+		var fields []string
+		for _, p := range append(append([]*resources.KeyValue{}, r.Form...), r.FormData...) {
+			if p.Type == "file" {
+				src := valueString(p.Src)
+				fields = append(fields, fmt.Sprintf("%s: http.file(files[%s], %s)", jsString(p.Key), jsString(src), jsString(src)))
+				continue
+			}
+			fields = append(fields, fmt.Sprintf("%s: resolve(vars, %s)", jsString(p.Key), jsString(p.Value)))
+		}
+		body = "{ " + strings.Join(fields, ", ") + " }"
+	}
+
+	fmt.Fprintf(b, "%sres = http.request(%s, resolve(vars, %s), %s, {\n", indent, jsString(r.Method), jsString(r.URL), body)
+	fmt.Fprintf(b, "%s  headers: { %s },\n", indent, strings.Join(headers, ", "))
+	fmt.Fprintf(b, "%s  tags: { name: %s },\n", indent, jsString(r.Name))
+	fmt.Fprintf(b, "%s});\n", indent)
+
+	if len(r.Codes) > 0 {
+		codes := make([]string, len(r.Codes))
+		for i, c := range r.Codes {
+			codes[i] = fmt.Sprint(c)
+		}
+		list := strings.Join(codes, ", ")
+		fmt.Fprintf(b, "%scheck(res, { %s: (r) => [%s].includes(r.status) });\n",
+			indent, jsString(r.Name+" status is "+strings.Join(codes, " or ")), list)
+	}
+}
+
+// k6Duration formats a duration the way k6 options expect, e.g. 1m30s.
+func k6Duration(d time.Duration) string {
+	s := d.Round(time.Second).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+
+	return s
+}
+
+// jsString quotes s as a JavaScript string literal.
+func jsString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
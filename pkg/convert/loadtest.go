@@ -0,0 +1,245 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+)
+
+// LoadTestOptions configures the JMeter and k6 exporters.
+type LoadTestOptions struct {
+	// Threads is the number of JMeter threads or k6 virtual users.
+	Threads int
+	// RampUp is the time taken to start all threads.
+	RampUp time.Duration
+	// Duration is how long the test runs. When zero, each thread runs the
+	// collection once.
+	Duration time.Duration
+	// DataFile is the path of a CSV data set, such as one written by
+	// DataSet.WriteCSV. Its columns override collection variables.
+	DataFile string
+	// DataColumns are the column names of the data file.
+	DataColumns []string
+}
+
+// DataSet is a table of variable values with one row per environment.
+type DataSet struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// EnvironmentsDataSet builds a data set from environments. Columns are the
+// union of the enabled keys of all environments.
+func EnvironmentsDataSet(envs []*resources.Environment) *DataSet {
+	seen := make(map[string]bool)
+	d := &DataSet{}
+	for _, e := range envs {
+		for _, v := range e.Values {
+			if v.Enabled && !seen[v.Key] {
+				seen[v.Key] = true
+				d.Columns = append(d.Columns, v.Key)
+			}
+		}
+	}
+	sort.Strings(d.Columns)
+
+	for _, e := range envs {
+		values := make(map[string]string)
+		for _, v := range e.Values {
+			if v.Enabled {
+				values[v.Key] = v.Value
+			}
+		}
+
+		row := make([]string, len(d.Columns))
+		for i, c := range d.Columns {
+			row[i] = values[c]
+		}
+		d.Rows = append(d.Rows, row)
+	}
+
+	return d
+}
+
+// WriteCSV writes the data set as CSV with a header row.
+func (d *DataSet) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(d.Columns); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(d.Rows); err != nil {
+		return err
+	}
+
+	return cw.Error()
+}
+
+// loadTestRequest is a request flattened for the load test exporters.
+// Strings may contain {{variable}} references.
+type loadTestRequest struct {
+	Name     string
+	Folder   string
+	Method   string
+	URL      string
+	Headers  []*resources.KeyValue
+	Raw      string
+	Form     []*resources.KeyValue
+	FormData []*resources.KeyValue
+	Codes    []int
+	// Username and Password are set for basic auth. They are encoded by the
+	// test tool, as they may contain variables.
+	Username string
+	Password string
+}
+
+// loadTestPlan collects the requests and variables of a collection.
+func loadTestPlan(c *resources.Collection) ([]*loadTestRequest, []*gen.Variable, error) {
+	if c == nil || c.Collection == nil || c.Info == nil {
+		return nil, nil, errors.New("collection has no info block")
+	}
+
+	var requests []*loadTestRequest
+	err := walkItems(c.Item, nil, func(item *gen.Item, folders []*gen.ItemGroup) error {
+		r, err := resources.DecodeRequest(item.Request)
+		if err != nil {
+			return err
+		}
+
+		names := make([]string, 0, len(folders))
+		for _, f := range folders {
+			names = append(names, f.Name)
+		}
+
+		raw := r.URL.String()
+		for _, v := range r.URL.Variable {
+			raw = strings.Replace(raw, "/:"+v.Key, "/"+valueString(v.Value), -1)
+		}
+
+		lr := &loadTestRequest{
+			Name:   item.Name,
+			Folder: strings.Join(names, "/"),
+			Method: r.Method,
+			URL:    raw,
+			Codes:  expectedCodes(item.Response),
+		}
+
+		for _, h := range r.Header {
+			if !h.Disabled {
+				lr.Headers = append(lr.Headers, h)
+			}
+		}
+
+		if auth := effectiveAuth(r, folders, c.Collection); auth != nil {
+			applyAuth(lr, auth)
+		}
+
+		if b := r.Body; b != nil && !b.Disabled {
+			switch b.Mode {
+			case "raw":
+				lr.Raw = b.Raw
+				if lr.Raw != "" && headerValue(lr.Headers, "Content-Type") == "" && b.Language() != "" {
+					lr.Headers = append(lr.Headers, &resources.KeyValue{Key: "Content-Type", Value: rawContentType(b.Language(), b.Raw)})
+				}
+			case "urlencoded":
+				lr.Form = enabled(b.URLEncoded)
+			case "formdata":
+				lr.FormData = enabled(b.FormData)
+			}
+		}
+
+		requests = append(requests, lr)
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var vars []*gen.Variable
+	for _, v := range c.Variable {
+		if !v.Disabled {
+			vars = append(vars, v)
+		}
+	}
+
+	return requests, vars, nil
+}
+
+// applyAuth adds the headers or query parameters of an auth method.
+func applyAuth(r *loadTestRequest, auth *gen.Auth) {
+	switch auth.Type {
+	case "basic":
+		r.Username = authAttribute(auth.Basic, "username")
+		r.Password = authAttribute(auth.Basic, "password")
+	case "bearer":
+		r.Headers = append(r.Headers, &resources.KeyValue{Key: "Authorization", Value: "Bearer " + authAttribute(auth.Bearer, "token")})
+	case "apikey":
+		key, value := authAttribute(auth.Apikey, "key"), authAttribute(auth.Apikey, "value")
+		if authAttribute(auth.Apikey, "in") == "query" {
+			sep := "?"
+			if strings.Contains(r.URL, "?") {
+				sep = "&"
+			}
+			r.URL += sep + url.QueryEscape(key) + "=" + value
+		} else {
+			r.Headers = append(r.Headers, &resources.KeyValue{Key: key, Value: value})
+		}
+	}
+}
+
+// expectedCodes returns the status codes of saved examples to assert on.
+// Successful codes are preferred when examples also document errors.
+func expectedCodes(responses []*gen.Response) []int {
+	var all, ok []int
+	seen := make(map[int]bool)
+	for _, r := range responses {
+		if r.Code == 0 || seen[r.Code] {
+			continue
+		}
+		seen[r.Code] = true
+		all = append(all, r.Code)
+		if r.Code < 400 {
+			ok = append(ok, r.Code)
+		}
+	}
+
+	if len(ok) > 0 {
+		return ok
+	}
+
+	return all
+}
+
+func enabled(params []*resources.KeyValue) []*resources.KeyValue {
+	var r []*resources.KeyValue
+	for _, p := range params {
+		if !p.Disabled {
+			r = append(r, p)
+		}
+	}
+
+	return r
+}
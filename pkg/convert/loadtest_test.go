@@ -0,0 +1,143 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+const testLoadCollection = `{
+	"info": {"name": "Demo & Co", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+	"variable": [{"key": "baseUrl", "value": "https://api.example.com"}],
+	"item": [{
+		"name": "Users",
+		"item": [{
+			"name": "Create user",
+			"request": {
+				"method": "POST",
+				"url": "{{baseUrl}}/users",
+				"header": [{"key": "X-Token", "value": "{{token}}"}],
+				"body": {"mode": "raw", "raw": "{\"name\": \"<n>\"}", "options": {"raw": {"language": "json"}}}
+			},
+			"response": [{"name": "Created", "code": 201}, {"name": "Invalid", "code": 400}]
+		}]
+	}]
+}`
+
+func TestCollectionToJMeter(t *testing.T) {
+	c := readTestCollection(t, testLoadCollection)
+
+	b, err := convert.CollectionToJMeter(c, convert.LoadTestOptions{
+		Threads:     10,
+		Duration:    time.Minute,
+		DataFile:    "data.csv",
+		DataColumns: []string{"token"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := xml.NewDecoder(bytes.NewReader(b))
+	for {
+		if _, err := d.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("Test plan is not well-formed XML: %s", err)
+		}
+	}
+
+	jmx := string(b)
+	for _, want := range []string{
+		`testname="Demo &amp; Co"`,
+		`<stringProp name="ThreadGroup.num_threads">10</stringProp>`,
+		`<stringProp name="ThreadGroup.duration">60</stringProp>`,
+		`<stringProp name="LoopController.loops">-1</stringProp>`,
+		`<stringProp name="variableNames">token</stringProp>`,
+		`<stringProp name="HTTPSampler.path">${baseUrl}/users</stringProp>`,
+		`<stringProp name="Header.value">${token}</stringProp>`,
+		`<stringProp name="Header.value">application/json</stringProp>`,
+		`<stringProp name="201">201</stringProp>`,
+	} {
+		if !strings.Contains(jmx, want) {
+			t.Errorf("Test plan does not contain %s", want)
+		}
+	}
+
+	if strings.Contains(jmx, `<stringProp name="400">`) {
+		t.Error("Error examples should not be asserted when successful ones exist")
+	}
+}
+
+func TestCollectionToK6(t *testing.T) {
+	c := readTestCollection(t, testLoadCollection)
+
+	b, err := convert.CollectionToK6(c, convert.LoadTestOptions{
+		Threads:  5,
+		RampUp:   30 * time.Second,
+		Duration: 90 * time.Second,
+		DataFile: "data.csv",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	script := string(b)
+	for _, want := range []string{
+		`{ duration: '30s', target: 5 }`,
+		`{ duration: '1m30s', target: 5 }`,
+		`"baseUrl": "https://api.example.com",`,
+		`const data = parseCSV(open("data.csv"));`,
+		`group("Users", function () {`,
+		`res = http.request("POST", resolve(vars, "{{baseUrl}}/users")`,
+		`"Create user status is 201": (r) => [201].includes(r.status)`,
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("Script does not contain %s", want)
+		}
+	}
+}
+
+func TestEnvironmentsDataSet(t *testing.T) {
+	d := convert.EnvironmentsDataSet([]*resources.Environment{
+		{Name: "staging", Values: []resources.KeyValuePair{
+			{Key: "token", Value: "a,b", Enabled: true},
+			{Key: "baseUrl", Value: "https://staging", Enabled: true},
+		}},
+		{Name: "prod", Values: []resources.KeyValuePair{
+			{Key: "baseUrl", Value: "https://prod", Enabled: true},
+			{Key: "secret", Value: "x", Enabled: false},
+		}},
+	})
+
+	var b bytes.Buffer
+	if err := d.WriteCSV(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "baseUrl,token\nhttps://staging,\"a,b\"\nhttps://prod,\n"
+	if b.String() != want {
+		t.Errorf("Data set is incorrect, have: %q, want: %q", b.String(), want)
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"encoding/json"
+	"math"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// Schema is the subset of the OpenAPI 3 schema object used by postmanctl.
+type Schema struct {
+	Ref         string             `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Type        string             `json:"type,omitempty" yaml:"type,omitempty"`
+	Format      string             `json:"format,omitempty" yaml:"format,omitempty"`
+	Description string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Nullable    bool               `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty" yaml:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
+	Enum        []interface{}      `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Default     interface{}        `json:"default,omitempty" yaml:"default,omitempty"`
+	Example     interface{}        `json:"example,omitempty" yaml:"example,omitempty"`
+	AllOf       []*Schema          `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+	OneOf       []*Schema          `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+	AnyOf       []*Schema          `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// InferSchema derives a JSON Schema from an example value decoded by
+// encoding/json. Objects inside arrays are merged so that every property
+// seen in any element is described.
+func InferSchema(v interface{}) *Schema {
+	switch t := v.(type) {
+	case nil:
+		return &Schema{Nullable: true}
+	case bool:
+		return &Schema{Type: "boolean"}
+	case float64:
+		if t == math.Trunc(t) && math.Abs(t) < 1<<53 {
+			return &Schema{Type: "integer"}
+		}
+		return &Schema{Type: "number"}
+	case json.Number:
+		if _, err := t.Int64(); err == nil {
+			return &Schema{Type: "integer"}
+		}
+		return &Schema{Type: "number"}
+	case string:
+		s := &Schema{Type: "string"}
+		if _, err := time.Parse(time.RFC3339, t); err == nil {
+			s.Format = "date-time"
+		} else if _, err := time.Parse("2006-01-02", t); err == nil {
+			s.Format = "date"
+		} else if uuidPattern.MatchString(t) {
+			s.Format = "uuid"
+		}
+		return s
+	case []interface{}:
+		s := &Schema{Type: "array"}
+		for _, e := range t {
+			s.Items = mergeSchema(s.Items, InferSchema(e))
+		}
+		if s.Items == nil {
+			s.Items = &Schema{}
+		}
+		return s
+	case map[string]interface{}:
+		s := &Schema{Type: "object", Properties: make(map[string]*Schema, len(t))}
+		keys := make([]string, 0, len(t))
+		for k, e := range t {
+			s.Properties[k] = InferSchema(e)
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		s.Required = keys
+		return s
+	}
+
+	return &Schema{}
+}
+
+// mergeSchema combines two inferred schemas for the same location.
+func mergeSchema(a, b *Schema) *Schema {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	if a.Type == "" && a.Nullable {
+		b.Nullable = true
+		return b
+	}
+	if b.Type == "" && b.Nullable {
+		a.Nullable = true
+		return a
+	}
+
+	if a.Type != b.Type {
+		if a.Type == "integer" && b.Type == "number" || a.Type == "number" && b.Type == "integer" {
+			return &Schema{Type: "number", Nullable: a.Nullable || b.Nullable}
+		}
+		return &Schema{}
+	}
+
+	if a.Format != b.Format {
+		a.Format = ""
+	}
+	a.Nullable = a.Nullable || b.Nullable
+
+	switch a.Type {
+	case "array":
+		a.Items = mergeSchema(a.Items, b.Items)
+	case "object":
+		required := make(map[string]bool)
+		for _, k := range b.Required {
+			required[k] = true
+		}
+
+		var keep []string
+		for _, k := range a.Required {
+			if required[k] {
+				keep = append(keep, k)
+			}
+		}
+		a.Required = keep
+
+		for k, p := range b.Properties {
+			a.Properties[k] = mergeSchema(a.Properties[k], p)
+		}
+	}
+
+	return a
+}
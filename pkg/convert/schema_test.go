@@ -0,0 +1,70 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+)
+
+func TestInferSchemaMergesArrayElements(t *testing.T) {
+	var v interface{}
+	subject := `[{"id": 1, "name": "a"}, {"id": 2.5, "tags": ["x"]}, {"id": 3, "name": null}]`
+	if err := json.Unmarshal([]byte(subject), &v); err != nil {
+		t.Fatal(err)
+	}
+
+	s := convert.InferSchema(v)
+	if s.Type != "array" || s.Items == nil || s.Items.Type != "object" {
+		t.Fatalf("Schema is incorrect, have: %+v", s)
+	}
+
+	props := s.Items.Properties
+	if props["id"].Type != "number" {
+		t.Errorf("Type is incorrect, have: %s, want: %s", props["id"].Type, "number")
+	}
+
+	if props["name"].Type != "string" || !props["name"].Nullable {
+		t.Errorf("Name schema is incorrect, have: %+v", props["name"])
+	}
+
+	if props["tags"].Type != "array" || props["tags"].Items.Type != "string" {
+		t.Errorf("Tags schema is incorrect, have: %+v", props["tags"])
+	}
+
+	if !reflect.DeepEqual(s.Items.Required, []string{"id"}) {
+		t.Errorf("Required is incorrect, have: %v, want: %v", s.Items.Required, []string{"id"})
+	}
+}
+
+func TestInferSchemaStringFormats(t *testing.T) {
+	tests := map[string]string{
+		"2020-05-01T10:00:00Z":                 "date-time",
+		"2020-05-01":                           "date",
+		"0a428e3b-4112-46ee-b57a-d2f3e1b7c860": "uuid",
+		"hello":                                "",
+	}
+
+	for in, want := range tests {
+		if have := convert.InferSchema(in).Format; have != want {
+			t.Errorf("Format for %q is incorrect, have: %s, want: %s", in, have, want)
+		}
+	}
+}
@@ -0,0 +1,374 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// swagger is the subset of a Swagger 2.0 document needed to upgrade it to
+// OpenAPI 3.
+type swagger struct {
+	Swagger             string                            `json:"swagger"`
+	Info                Info                              `json:"info"`
+	Host                string                            `json:"host"`
+	BasePath            string                            `json:"basePath"`
+	Schemes             []string                          `json:"schemes"`
+	Consumes            []string                          `json:"consumes"`
+	Produces            []string                          `json:"produces"`
+	Paths               map[string]*swaggerPathItem       `json:"paths"`
+	Definitions         map[string]*Schema                `json:"definitions"`
+	Parameters          map[string]*swaggerParameter      `json:"parameters"`
+	Responses           map[string]*swaggerResponse       `json:"responses"`
+	SecurityDefinitions map[string]*swaggerSecurityScheme `json:"securityDefinitions"`
+	Security            []SecurityRequirement             `json:"security"`
+	Tags                []*Tag                            `json:"tags"`
+}
+
+type swaggerPathItem struct {
+	Parameters []*swaggerParameter `json:"parameters"`
+	Get        *swaggerOperation   `json:"get"`
+	Put        *swaggerOperation   `json:"put"`
+	Post       *swaggerOperation   `json:"post"`
+	Delete     *swaggerOperation   `json:"delete"`
+	Options    *swaggerOperation   `json:"options"`
+	Head       *swaggerOperation   `json:"head"`
+	Patch      *swaggerOperation   `json:"patch"`
+}
+
+func (p *swaggerPathItem) operations() map[string]*swaggerOperation {
+	r := map[string]*swaggerOperation{
+		"GET":     p.Get,
+		"PUT":     p.Put,
+		"POST":    p.Post,
+		"DELETE":  p.Delete,
+		"OPTIONS": p.Options,
+		"HEAD":    p.Head,
+		"PATCH":   p.Patch,
+	}
+
+	for k, v := range r {
+		if v == nil {
+			delete(r, k)
+		}
+	}
+
+	return r
+}
+
+type swaggerOperation struct {
+	Tags        []string                    `json:"tags"`
+	Summary     string                      `json:"summary"`
+	Description string                      `json:"description"`
+	OperationID string                      `json:"operationId"`
+	Consumes    []string                    `json:"consumes"`
+	Produces    []string                    `json:"produces"`
+	Parameters  []*swaggerParameter         `json:"parameters"`
+	Responses   map[string]*swaggerResponse `json:"responses"`
+	Deprecated  bool                        `json:"deprecated"`
+	Security    []SecurityRequirement       `json:"security"`
+}
+
+type swaggerParameter struct {
+	Ref         string        `json:"$ref"`
+	Name        string        `json:"name"`
+	In          string        `json:"in"`
+	Description string        `json:"description"`
+	Required    bool          `json:"required"`
+	Type        string        `json:"type"`
+	Format      string        `json:"format"`
+	Items       *Schema       `json:"items"`
+	Enum        []interface{} `json:"enum"`
+	Default     interface{}   `json:"default"`
+	Schema      *Schema       `json:"schema"`
+}
+
+type swaggerResponse struct {
+	Ref         string                 `json:"$ref"`
+	Description string                 `json:"description"`
+	Schema      *Schema                `json:"schema"`
+	Examples    map[string]interface{} `json:"examples"`
+}
+
+type swaggerSecurityScheme struct {
+	Type             string            `json:"type"`
+	Description      string            `json:"description"`
+	Name             string            `json:"name"`
+	In               string            `json:"in"`
+	Flow             string            `json:"flow"`
+	AuthorizationURL string            `json:"authorizationUrl"`
+	TokenURL         string            `json:"tokenUrl"`
+	Scopes           map[string]string `json:"scopes"`
+}
+
+// upgradeSwagger converts a Swagger 2.0 document to OpenAPI 3.
+func upgradeSwagger(b []byte) (*OpenAPI, error) {
+	var s swagger
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, err
+	}
+
+	doc := &OpenAPI{
+		OpenAPI:  "3.0.3",
+		Info:     s.Info,
+		Tags:     s.Tags,
+		Security: s.Security,
+		Paths:    make(map[string]*PathItem),
+		Components: &Components{
+			Schemas:         make(map[string]*Schema),
+			SecuritySchemes: make(map[string]*SecurityScheme),
+		},
+	}
+
+	if s.Host != "" {
+		schemes := s.Schemes
+		if len(schemes) == 0 {
+			schemes = []string{"https"}
+		}
+		for _, scheme := range schemes {
+			doc.Servers = append(doc.Servers, &Server{URL: scheme + "://" + s.Host + s.BasePath})
+		}
+	} else if s.BasePath != "" {
+		doc.Servers = append(doc.Servers, &Server{URL: s.BasePath})
+	}
+
+	for name, schema := range s.Definitions {
+		doc.Components.Schemas[name] = upgradeSchemaRefs(schema)
+	}
+
+	for name, sec := range s.SecurityDefinitions {
+		doc.Components.SecuritySchemes[name] = upgradeSecurityScheme(sec)
+	}
+
+	for path, item := range s.Paths {
+		pathItem := &PathItem{}
+		for method, op := range item.operations() {
+			consumes := op.Consumes
+			if len(consumes) == 0 {
+				consumes = s.Consumes
+			}
+			produces := op.Produces
+			if len(produces) == 0 {
+				produces = s.Produces
+			}
+
+			o := &Operation{
+				Tags:        op.Tags,
+				Summary:     op.Summary,
+				Description: op.Description,
+				OperationID: op.OperationID,
+				Deprecated:  op.Deprecated,
+				Security:    op.Security,
+				Responses:   make(map[string]*Response),
+			}
+
+			params := append(append([]*swaggerParameter{}, item.Parameters...), op.Parameters...)
+			var form []*swaggerParameter
+			for _, p := range params {
+				p, err := s.resolveParameter(p)
+				if err != nil {
+					return nil, err
+				}
+
+				switch p.In {
+				case "body":
+					o.RequestBody = &RequestBody{
+						Description: p.Description,
+						Required:    p.Required,
+						Content:     make(map[string]*MediaType),
+					}
+					for _, ct := range defaultStrings(consumes, "application/json") {
+						o.RequestBody.Content[ct] = &MediaType{Schema: upgradeSchemaRefs(p.Schema)}
+					}
+				case "formData":
+					form = append(form, p)
+				default:
+					o.Parameters = append(o.Parameters, &Parameter{
+						Name:        p.Name,
+						In:          p.In,
+						Description: p.Description,
+						Required:    p.Required || p.In == "path",
+						Schema:      p.schema(),
+					})
+				}
+			}
+
+			if len(form) > 0 {
+				o.RequestBody = formRequestBody(form, consumes)
+			}
+
+			for code, res := range op.Responses {
+				res, err := s.resolveResponse(res)
+				if err != nil {
+					return nil, err
+				}
+
+				r := &Response{Description: res.Description}
+				if res.Schema != nil || len(res.Examples) > 0 {
+					r.Content = make(map[string]*MediaType)
+					for _, ct := range defaultStrings(produces, "application/json") {
+						r.Content[ct] = &MediaType{
+							Schema:  upgradeSchemaRefs(res.Schema),
+							Example: res.Examples[ct],
+						}
+					}
+				}
+				o.Responses[code] = r
+			}
+
+			pathItem.SetOperation(method, o)
+		}
+		doc.Paths[path] = pathItem
+	}
+
+	return doc, nil
+}
+
+func (s *swagger) resolveParameter(p *swaggerParameter) (*swaggerParameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+
+	name := strings.TrimPrefix(p.Ref, "#/parameters/")
+	if r, ok := s.Parameters[name]; ok {
+		return r, nil
+	}
+
+	return nil, fmt.Errorf("unable to resolve parameter reference %s", p.Ref)
+}
+
+func (s *swagger) resolveResponse(r *swaggerResponse) (*swaggerResponse, error) {
+	if r.Ref == "" {
+		return r, nil
+	}
+
+	name := strings.TrimPrefix(r.Ref, "#/responses/")
+	if res, ok := s.Responses[name]; ok {
+		return res, nil
+	}
+
+	return nil, fmt.Errorf("unable to resolve response reference %s", r.Ref)
+}
+
+func (p *swaggerParameter) schema() *Schema {
+	if p.Schema != nil {
+		return upgradeSchemaRefs(p.Schema)
+	}
+
+	t := p.Type
+	if t == "file" {
+		return &Schema{Type: "string", Format: "binary"}
+	}
+
+	return &Schema{
+		Type:    t,
+		Format:  p.Format,
+		Items:   upgradeSchemaRefs(p.Items),
+		Enum:    p.Enum,
+		Default: p.Default,
+	}
+}
+
+func formRequestBody(form []*swaggerParameter, consumes []string) *RequestBody {
+	ct := "application/x-www-form-urlencoded"
+	for _, c := range consumes {
+		if c == "multipart/form-data" {
+			ct = c
+		}
+	}
+
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for _, p := range form {
+		if p.Type == "file" {
+			ct = "multipart/form-data"
+		}
+		s.Properties[p.Name] = p.schema()
+		if p.Required {
+			s.Required = append(s.Required, p.Name)
+		}
+	}
+
+	return &RequestBody{Content: map[string]*MediaType{ct: {Schema: s}}}
+}
+
+func upgradeSecurityScheme(s *swaggerSecurityScheme) *SecurityScheme {
+	switch s.Type {
+	case "basic":
+		return &SecurityScheme{Type: "http", Scheme: "basic", Description: s.Description}
+	case "apiKey":
+		return &SecurityScheme{Type: "apiKey", Name: s.Name, In: s.In, Description: s.Description}
+	case "oauth2":
+		flow := &OAuthFlow{
+			AuthorizationURL: s.AuthorizationURL,
+			TokenURL:         s.TokenURL,
+			Scopes:           s.Scopes,
+		}
+		if flow.Scopes == nil {
+			flow.Scopes = make(map[string]string)
+		}
+
+		flows := &OAuthFlows{}
+		switch s.Flow {
+		case "implicit":
+			flows.Implicit = flow
+		case "password":
+			flows.Password = flow
+		case "application":
+			flows.ClientCredentials = flow
+		default:
+			flows.AuthorizationCode = flow
+		}
+
+		return &SecurityScheme{Type: "oauth2", Flows: flows, Description: s.Description}
+	}
+
+	return &SecurityScheme{Type: s.Type, Description: s.Description}
+}
+
+// upgradeSchemaRefs rewrites Swagger definition references to OpenAPI 3
+// component references.
+func upgradeSchemaRefs(s *Schema) *Schema {
+	if s == nil {
+		return nil
+	}
+
+	if strings.HasPrefix(s.Ref, "#/definitions/") {
+		s.Ref = "#/components/schemas/" + strings.TrimPrefix(s.Ref, "#/definitions/")
+	}
+
+	for _, p := range s.Properties {
+		upgradeSchemaRefs(p)
+	}
+	upgradeSchemaRefs(s.Items)
+	for _, l := range [][]*Schema{s.AllOf, s.OneOf, s.AnyOf} {
+		for _, c := range l {
+			upgradeSchemaRefs(c)
+		}
+	}
+
+	return s
+}
+
+func defaultStrings(s []string, d string) []string {
+	if len(s) == 0 {
+		return []string{d}
+	}
+
+	return s
+}
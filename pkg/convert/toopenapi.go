@@ -0,0 +1,631 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+)
+
+var (
+	variablePattern     = regexp.MustCompile(`{{\s*([^{}\s]+)\s*}}`)
+	bareVariablePattern = regexp.MustCompile(`(^|[^"])({{[^{}]+}})`)
+)
+
+// CollectionToOpenAPI3 converts a collection into an OpenAPI 3.0 document.
+// Folders become tags, Postman path variables become path parameters and
+// JSON bodies of requests and saved responses are used to infer schemas.
+func CollectionToOpenAPI3(c *resources.Collection) (*OpenAPI, error) {
+	if c == nil || c.Collection == nil || c.Info == nil {
+		return nil, errors.New("collection has no info block")
+	}
+
+	b := &openAPIBuilder{
+		collection: c.Collection,
+		variables:  make(map[string]string),
+		doc: &OpenAPI{
+			OpenAPI: "3.0.3",
+			Info: Info{
+				Title:       c.Info.Name,
+				Description: descriptionString(c.Info.Description),
+				Version:     infoVersion(c.Info.Version),
+			},
+			Paths: make(map[string]*PathItem),
+		},
+		tags:         make(map[string]bool),
+		servers:      make(map[string]bool),
+		operationIDs: make(map[string]int),
+	}
+
+	for _, v := range c.Variable {
+		if v.Disabled {
+			continue
+		}
+		key := v.Key
+		if key == "" {
+			key = v.ID
+		}
+		b.variables[key] = valueString(v.Value)
+	}
+
+	if err := walkItems(c.Item, nil, b.addItem); err != nil {
+		return nil, err
+	}
+
+	return b.doc, nil
+}
+
+type openAPIBuilder struct {
+	collection   *gen.Collection
+	variables    map[string]string
+	doc          *OpenAPI
+	tags         map[string]bool
+	servers      map[string]bool
+	operationIDs map[string]int
+}
+
+func (b *openAPIBuilder) addItem(item *gen.Item, folders []*gen.ItemGroup) error {
+	r, err := resources.DecodeRequest(item.Request)
+	if err != nil {
+		return fmt.Errorf("request %q: %s", item.Name, err)
+	}
+
+	b.addServer(r.URL)
+
+	path, pathParams := b.pathTemplate(r.URL)
+	pathItem, ok := b.doc.Paths[path]
+	if !ok {
+		pathItem = &PathItem{}
+		b.doc.Paths[path] = pathItem
+	}
+
+	op := pathItem.Operation(r.Method)
+	if op == nil {
+		op = &Operation{
+			Summary:     item.Name,
+			Description: descriptionString(r.Description),
+			OperationID: b.operationID(r.Method, item.Name),
+			Responses:   make(map[string]*Response),
+		}
+		if op.Description == "" {
+			op.Description = descriptionString(item.Description)
+		}
+
+		for _, f := range folders {
+			op.Tags = append(op.Tags, f.Name)
+			b.addTag(f)
+		}
+
+		op.Parameters = append(op.Parameters, pathParams...)
+		op.Parameters = append(op.Parameters, queryParameters(r.URL)...)
+		op.Parameters = append(op.Parameters, headerParameters(r.Header)...)
+		op.RequestBody = requestBodyFor(r)
+		op.Security = b.security(effectiveAuth(r, folders, b.collection))
+
+		pathItem.SetOperation(r.Method, op)
+	}
+
+	for _, genResponse := range item.Response {
+		res, err := resources.DecodeResponse(genResponse)
+		if err != nil {
+			return err
+		}
+
+		code, response := responseFor(res)
+		if _, ok := op.Responses[code]; !ok {
+			op.Responses[code] = response
+		}
+	}
+
+	if len(op.Responses) == 0 {
+		op.Responses["200"] = &Response{Description: "Default Response"}
+	}
+
+	return nil
+}
+
+func (b *openAPIBuilder) addTag(f *gen.ItemGroup) {
+	if b.tags[f.Name] {
+		return
+	}
+
+	b.tags[f.Name] = true
+	b.doc.Tags = append(b.doc.Tags, &Tag{
+		Name:        f.Name,
+		Description: descriptionString(f.Description),
+	})
+}
+
+func (b *openAPIBuilder) addServer(u *resources.URL) {
+	if len(u.Host) == 0 {
+		return
+	}
+
+	host := strings.Join(u.Host, ".")
+	if u.Port != "" {
+		host += ":" + u.Port
+	}
+
+	if u.Protocol != "" {
+		host = u.Protocol + "://" + host
+	}
+
+	var unresolved []string
+	host = variablePattern.ReplaceAllStringFunc(host, func(m string) string {
+		name := variablePattern.FindStringSubmatch(m)[1]
+		if v, ok := b.variables[name]; ok {
+			return v
+		}
+		unresolved = append(unresolved, name)
+		return "{" + name + "}"
+	})
+
+	if !strings.Contains(host, "://") && !strings.HasPrefix(host, "{") {
+		host = "https://" + host
+	}
+
+	if b.servers[host] {
+		return
+	}
+	b.servers[host] = true
+
+	server := &Server{URL: strings.TrimSuffix(host, "/")}
+	if len(unresolved) > 0 {
+		server.Variables = make(map[string]*ServerVariable)
+		for _, name := range unresolved {
+			server.Variables[name] = &ServerVariable{Default: ""}
+		}
+	}
+
+	b.doc.Servers = append(b.doc.Servers, server)
+}
+
+// pathTemplate converts Postman path segments into an OpenAPI path template
+// and the path parameters referenced by it.
+func (b *openAPIBuilder) pathTemplate(u *resources.URL) (string, []*Parameter) {
+	var (
+		segments []string
+		params   []*Parameter
+	)
+
+	variables := make(map[string]*gen.Variable)
+	for _, v := range u.Variable {
+		key := v.Key
+		if key == "" {
+			key = v.ID
+		}
+		variables[key] = v
+	}
+
+	for _, s := range u.Path {
+		name := ""
+		if strings.HasPrefix(s, ":") && len(s) > 1 {
+			name = s[1:]
+		} else if m := variablePattern.FindStringSubmatch(s); m != nil && m[0] == s {
+			name = m[1]
+		}
+
+		if name == "" {
+			segments = append(segments, s)
+			continue
+		}
+
+		segments = append(segments, "{"+name+"}")
+		p := &Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		}
+		if v, ok := variables[name]; ok {
+			p.Description = descriptionString(v.Description)
+			if s := valueString(v.Value); s != "" {
+				p.Example = s
+			}
+		}
+		params = append(params, p)
+	}
+
+	return "/" + strings.Join(segments, "/"), params
+}
+
+func (b *openAPIBuilder) operationID(method, name string) string {
+	var id strings.Builder
+	id.WriteString(strings.ToLower(method))
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		id.WriteRune(r)
+	}
+
+	s := id.String()
+	b.operationIDs[s]++
+	if n := b.operationIDs[s]; n > 1 {
+		s += strconv.Itoa(n)
+	}
+
+	return s
+}
+
+// security registers a security scheme for the auth and returns the
+// requirement for an operation using it.
+func (b *openAPIBuilder) security(a *gen.Auth) []SecurityRequirement {
+	if a == nil {
+		return nil
+	}
+
+	var (
+		name   string
+		scheme *SecurityScheme
+	)
+
+	switch a.Type {
+	case "noauth":
+		return []SecurityRequirement{}
+	case "basic":
+		name = "basicAuth"
+		scheme = &SecurityScheme{Type: "http", Scheme: "basic"}
+	case "digest":
+		name = "digestAuth"
+		scheme = &SecurityScheme{Type: "http", Scheme: "digest"}
+	case "bearer":
+		name = "bearerAuth"
+		scheme = &SecurityScheme{Type: "http", Scheme: "bearer"}
+	case "apikey":
+		in := authAttribute(a.Apikey, "in")
+		if in == "" {
+			in = "header"
+		}
+		key := authAttribute(a.Apikey, "key")
+		if key == "" {
+			key = "X-API-Key"
+		}
+		name = "apiKeyAuth"
+		scheme = &SecurityScheme{Type: "apiKey", Name: key, In: in}
+	case "oauth2":
+		name = "oauth2"
+		scheme = &SecurityScheme{Type: "oauth2", Flows: oauthFlows(a.Oauth2)}
+	default:
+		return nil
+	}
+
+	if b.doc.Components == nil {
+		b.doc.Components = &Components{}
+	}
+	if b.doc.Components.SecuritySchemes == nil {
+		b.doc.Components.SecuritySchemes = make(map[string]*SecurityScheme)
+	}
+
+	// Keep distinct schemes of the same type apart, e.g. two API keys.
+	base := name
+	for i := 2; ; i++ {
+		existing, ok := b.doc.Components.SecuritySchemes[name]
+		if !ok {
+			b.doc.Components.SecuritySchemes[name] = scheme
+			break
+		}
+		if sameScheme(existing, scheme) {
+			break
+		}
+		name = base + strconv.Itoa(i)
+	}
+
+	var scopes []string
+	if a.Type == "oauth2" {
+		if s := authAttribute(a.Oauth2, "scope"); s != "" {
+			scopes = strings.Fields(s)
+		}
+	}
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return []SecurityRequirement{{name: scopes}}
+}
+
+func sameScheme(a, b *SecurityScheme) bool {
+	x, _ := json.Marshal(a)
+	y, _ := json.Marshal(b)
+	return string(x) == string(y)
+}
+
+func oauthFlows(attrs []*gen.AuthAttribute) *OAuthFlows {
+	scopes := make(map[string]string)
+	for _, s := range strings.Fields(authAttribute(attrs, "scope")) {
+		scopes[s] = ""
+	}
+
+	flow := &OAuthFlow{
+		AuthorizationURL: authAttribute(attrs, "authUrl"),
+		TokenURL:         authAttribute(attrs, "accessTokenUrl"),
+		Scopes:           scopes,
+	}
+
+	switch authAttribute(attrs, "grant_type") {
+	case "client_credentials":
+		flow.AuthorizationURL = ""
+		return &OAuthFlows{ClientCredentials: flow}
+	case "implicit":
+		flow.TokenURL = ""
+		return &OAuthFlows{Implicit: flow}
+	case "password_credentials":
+		flow.AuthorizationURL = ""
+		return &OAuthFlows{Password: flow}
+	}
+
+	return &OAuthFlows{AuthorizationCode: flow}
+}
+
+func queryParameters(u *resources.URL) []*Parameter {
+	var params []*Parameter
+	for _, q := range u.Query {
+		if q.Key == "" {
+			continue
+		}
+		p := &Parameter{
+			Name:        q.Key,
+			In:          "query",
+			Description: descriptionString(q.Description),
+			Schema:      &Schema{Type: "string"},
+		}
+		if q.Value != "" {
+			p.Example = q.Value
+		}
+		params = append(params, p)
+	}
+
+	return params
+}
+
+// headerParameters skips headers that OpenAPI describes elsewhere.
+func headerParameters(headers []*resources.KeyValue) []*Parameter {
+	var params []*Parameter
+	for _, h := range headers {
+		if h.Disabled {
+			continue
+		}
+
+		switch strings.ToLower(h.Key) {
+		case "", "accept", "content-type", "authorization":
+			continue
+		}
+
+		p := &Parameter{
+			Name:        h.Key,
+			In:          "header",
+			Description: descriptionString(h.Description),
+			Schema:      &Schema{Type: "string"},
+		}
+		if h.Value != "" {
+			p.Example = h.Value
+		}
+		params = append(params, p)
+	}
+
+	return params
+}
+
+func requestBodyFor(r *resources.Request) *RequestBody {
+	if r.Body == nil || r.Body.Disabled || r.Body.Mode == "" {
+		return nil
+	}
+
+	contentType := headerValue(r.Header, "Content-Type")
+	var media *MediaType
+
+	switch r.Body.Mode {
+	case "raw":
+		if strings.TrimSpace(r.Body.Raw) == "" {
+			return nil
+		}
+		if contentType == "" {
+			contentType = rawContentType(r.Body.Language(), r.Body.Raw)
+		}
+		media = exampleMediaType(contentType, r.Body.Raw)
+	case "urlencoded":
+		contentType = "application/x-www-form-urlencoded"
+		media = formMediaType(r.Body.URLEncoded)
+	case "formdata":
+		contentType = "multipart/form-data"
+		media = formMediaType(r.Body.FormData)
+	case "file":
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		media = &MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+	case "graphql":
+		contentType = "application/json"
+		media = &MediaType{
+			Schema: &Schema{
+				Type: "object",
+				Properties: map[string]*Schema{
+					"query":     {Type: "string"},
+					"variables": {Type: "object"},
+				},
+			},
+		}
+	default:
+		return nil
+	}
+
+	return &RequestBody{
+		Content: map[string]*MediaType{mediaTypeKey(contentType): media},
+	}
+}
+
+func responseFor(res *resources.Response) (string, *Response) {
+	code := "default"
+	if res.Code > 0 {
+		code = strconv.Itoa(res.Code)
+	}
+
+	description := res.Status
+	if description == "" && res.Code > 0 {
+		description = http.StatusText(res.Code)
+	}
+	if description == "" {
+		description = "Response"
+	}
+
+	response := &Response{Description: description}
+
+	body := res.Body
+	if strings.TrimSpace(body) == "" {
+		return code, response
+	}
+
+	contentType := headerValue(res.Header, "Content-Type")
+	if contentType == "" {
+		contentType = rawContentType("", body)
+	}
+
+	response.Content = map[string]*MediaType{
+		mediaTypeKey(contentType): exampleMediaType(contentType, body),
+	}
+
+	return code, response
+}
+
+// exampleMediaType infers a schema when the example is JSON.
+func exampleMediaType(contentType, body string) *MediaType {
+	if !strings.Contains(contentType, "json") {
+		return &MediaType{Schema: &Schema{Type: "string"}, Example: body}
+	}
+
+	v, ok := parseJSONExample(body)
+	if !ok {
+		return &MediaType{Schema: &Schema{Type: "string"}, Example: body}
+	}
+
+	return &MediaType{Schema: InferSchema(v), Example: v}
+}
+
+// parseJSONExample parses a JSON body, tolerating unquoted {{variable}}
+// placeholders that are common in Postman request bodies.
+func parseJSONExample(body string) (interface{}, bool) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(body), &v); err == nil {
+		return v, true
+	}
+
+	quoted := bareVariablePattern.ReplaceAllString(body, `$1"$2"`)
+	if err := json.Unmarshal([]byte(quoted), &v); err == nil {
+		return v, true
+	}
+
+	return nil, false
+}
+
+func formMediaType(params []*resources.KeyValue) *MediaType {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	example := make(map[string]interface{})
+	for _, p := range params {
+		if p.Key == "" || p.Disabled {
+			continue
+		}
+		if p.Type == "file" {
+			s.Properties[p.Key] = &Schema{Type: "string", Format: "binary"}
+			continue
+		}
+		s.Properties[p.Key] = &Schema{Type: "string", Description: descriptionString(p.Description)}
+		example[p.Key] = p.Value
+	}
+
+	m := &MediaType{Schema: s}
+	if len(example) > 0 {
+		m.Example = example
+	}
+
+	return m
+}
+
+func rawContentType(language, body string) string {
+	switch language {
+	case "json":
+		return "application/json"
+	case "xml":
+		return "application/xml"
+	case "html":
+		return "text/html"
+	case "javascript":
+		return "application/javascript"
+	case "text":
+		return "text/plain"
+	}
+
+	if _, ok := parseJSONExample(body); ok {
+		return "application/json"
+	}
+
+	return "text/plain"
+}
+
+// mediaTypeKey strips parameters such as charset from a content type.
+func mediaTypeKey(contentType string) string {
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+
+	return strings.TrimSpace(strings.ToLower(contentType))
+}
+
+func headerValue(headers []*resources.KeyValue, key string) string {
+	for _, h := range headers {
+		if !h.Disabled && strings.EqualFold(h.Key, key) {
+			return h.Value
+		}
+	}
+
+	return ""
+}
+
+func infoVersion(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		if t != "" {
+			return t
+		}
+	case map[string]interface{}:
+		var parts []string
+		for _, k := range []string{"major", "minor", "patch"} {
+			n, ok := t[k].(float64)
+			if !ok {
+				return "1.0.0"
+			}
+			parts = append(parts, strconv.Itoa(int(n)))
+		}
+		return strings.Join(parts, ".")
+	}
+
+	return "1.0.0"
+}
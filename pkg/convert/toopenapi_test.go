@@ -0,0 +1,178 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+)
+
+const testCollection = `{
+	"info": {
+		"name": "Orders API",
+		"schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+	},
+	"auth": {"type": "bearer", "bearer": [{"key": "token", "value": "{{token}}"}]},
+	"variable": [{"key": "baseUrl", "value": "https://api.example.com/v1"}],
+	"item": [
+		{
+			"name": "Orders",
+			"description": "Order management",
+			"item": [
+				{
+					"name": "Get order",
+					"request": {
+						"method": "GET",
+						"url": {
+							"raw": "{{baseUrl}}/orders/:orderId?expand=items",
+							"host": ["{{baseUrl}}"],
+							"path": ["orders", ":orderId"],
+							"query": [{"key": "expand", "value": "items"}],
+							"variable": [{"key": "orderId", "value": "42", "description": "The order ID"}]
+						},
+						"header": [{"key": "X-Trace", "value": "abc"}]
+					},
+					"response": [
+						{
+							"name": "OK",
+							"code": 200,
+							"status": "OK",
+							"header": [{"key": "Content-Type", "value": "application/json"}],
+							"body": "{\"id\": 42, \"total\": 9.5, \"createdAt\": \"2020-05-01T10:00:00Z\"}"
+						}
+					]
+				},
+				{
+					"name": "Create order",
+					"request": {
+						"method": "POST",
+						"url": "{{baseUrl}}/orders",
+						"body": {"mode": "raw", "raw": "{\"sku\": \"abc\", \"quantity\": {{qty}}}", "options": {"raw": {"language": "json"}}}
+					}
+				}
+			]
+		},
+		{
+			"name": "Health",
+			"request": {"method": "GET", "url": "https://status.example.com/health"},
+			"auth": {"type": "noauth"}
+		}
+	]
+}`
+
+func TestCollectionToOpenAPI3(t *testing.T) {
+	c, err := convert.ReadCollection(strings.NewReader(testCollection))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := convert.CollectionToOpenAPI3(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.Info.Title != "Orders API" {
+		t.Errorf("Title is incorrect, have: %s, want: %s", doc.Info.Title, "Orders API")
+	}
+
+	if len(doc.Servers) != 2 || doc.Servers[0].URL != "https://api.example.com/v1" {
+		t.Errorf("Servers are incorrect, have: %+v", doc.Servers)
+	}
+
+	if len(doc.Tags) != 1 || doc.Tags[0].Name != "Orders" || doc.Tags[0].Description != "Order management" {
+		t.Errorf("Tags are incorrect, have: %+v", doc.Tags)
+	}
+
+	path, ok := doc.Paths["/orders/{orderId}"]
+	if !ok || path.Get == nil {
+		t.Fatalf("Path is missing, have: %+v", doc.Paths)
+	}
+
+	get := path.Get
+	if get.OperationID != "getGetOrder" {
+		t.Errorf("OperationID is incorrect, have: %s, want: %s", get.OperationID, "getGetOrder")
+	}
+
+	params := make(map[string]*convert.Parameter)
+	for _, p := range get.Parameters {
+		params[p.In+":"+p.Name] = p
+	}
+
+	if p, ok := params["path:orderId"]; !ok || !p.Required || p.Description != "The order ID" {
+		t.Errorf("Path parameter is incorrect, have: %+v", p)
+	}
+
+	if _, ok := params["query:expand"]; !ok {
+		t.Errorf("Query parameter is missing.")
+	}
+
+	if _, ok := params["header:X-Trace"]; !ok {
+		t.Errorf("Header parameter is missing.")
+	}
+
+	if len(get.Security) != 1 {
+		t.Fatalf("Security is incorrect, have: %+v", get.Security)
+	}
+
+	if _, ok := get.Security[0]["bearerAuth"]; !ok {
+		t.Errorf("Security requirement is incorrect, have: %+v", get.Security[0])
+	}
+
+	if s := doc.Components.SecuritySchemes["bearerAuth"]; s == nil || s.Scheme != "bearer" {
+		t.Errorf("Security scheme is incorrect, have: %+v", s)
+	}
+
+	res, ok := get.Responses["200"]
+	if !ok {
+		t.Fatalf("Response is missing, have: %+v", get.Responses)
+	}
+
+	schema := res.Content["application/json"].Schema
+	if schema.Type != "object" {
+		t.Fatalf("Response schema is incorrect, have: %+v", schema)
+	}
+
+	if schema.Properties["id"].Type != "integer" || schema.Properties["total"].Type != "number" {
+		t.Errorf("Response schema properties are incorrect, have: %+v", schema.Properties)
+	}
+
+	if schema.Properties["createdAt"].Format != "date-time" {
+		t.Errorf("Format is incorrect, have: %s, want: %s", schema.Properties["createdAt"].Format, "date-time")
+	}
+
+	post := doc.Paths["/orders"].Post
+	if post == nil || post.RequestBody == nil {
+		t.Fatalf("Request body is missing.")
+	}
+
+	body := post.RequestBody.Content["application/json"].Schema
+	if body.Properties["quantity"].Type != "string" || body.Properties["sku"].Type != "string" {
+		t.Errorf("Request body schema is incorrect, have: %+v", body.Properties)
+	}
+
+	if _, ok := post.Responses["200"]; !ok {
+		t.Errorf("Default response is missing.")
+	}
+}
+
+func TestCollectionToOpenAPI3RequiresInfo(t *testing.T) {
+	if _, err := convert.CollectionToOpenAPI3(nil); err == nil {
+		t.Errorf("Should return an error.")
+	}
+}
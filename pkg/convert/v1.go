@@ -0,0 +1,526 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+	"github.com/kevinswiber/postmanctl/pkg/util"
+)
+
+// CollectionV1 is a collection in Collection Format v1. Requests are kept
+// in a flat list and placed by the order lists of the collection and its
+// folders.
+type CollectionV1 struct {
+	ID           string          `json:"id"`
+	Name         string          `json:"name"`
+	Description  string          `json:"description,omitempty"`
+	Order        []string        `json:"order,omitempty"`
+	Folders      []*FolderV1     `json:"folders,omitempty"`
+	FoldersOrder []string        `json:"folders_order,omitempty"`
+	Requests     []*RequestV1    `json:"requests,omitempty"`
+	Auth         interface{}     `json:"auth,omitempty"`
+	Events       []*gen.Event    `json:"events,omitempty"`
+	Variables    []*gen.Variable `json:"variables,omitempty"`
+}
+
+// FolderV1 is a folder in Collection Format v1.
+type FolderV1 struct {
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	Description  string       `json:"description,omitempty"`
+	Order        []string     `json:"order,omitempty"`
+	FoldersOrder []string     `json:"folders_order,omitempty"`
+	Auth         interface{}  `json:"auth,omitempty"`
+	Events       []*gen.Event `json:"events,omitempty"`
+}
+
+// RequestV1 is a request in Collection Format v1.
+type RequestV1 struct {
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name"`
+	Description      string                 `json:"description,omitempty"`
+	URL              string                 `json:"url"`
+	Method           string                 `json:"method"`
+	Headers          string                 `json:"headers,omitempty"`
+	HeaderData       []*ParamV1             `json:"headerData,omitempty"`
+	QueryParams      []*ParamV1             `json:"queryParams,omitempty"`
+	PathVariableData []*ParamV1             `json:"pathVariableData,omitempty"`
+	PathVariables    map[string]interface{} `json:"pathVariables,omitempty"`
+	DataMode         string                 `json:"dataMode,omitempty"`
+	Data             []*ParamV1             `json:"data,omitempty"`
+	RawModeData      interface{}            `json:"rawModeData,omitempty"`
+	GraphQLModeData  map[string]interface{} `json:"graphqlModeData,omitempty"`
+	DataOptions      map[string]interface{} `json:"dataOptions,omitempty"`
+	Auth             interface{}            `json:"auth,omitempty"`
+	CurrentHelper    string                 `json:"currentHelper,omitempty"`
+	HelperAttributes interface{}            `json:"helperAttributes,omitempty"`
+	Events           []*gen.Event           `json:"events,omitempty"`
+	PreRequestScript string                 `json:"preRequestScript,omitempty"`
+	Tests            string                 `json:"tests,omitempty"`
+	Responses        []*ResponseV1          `json:"responses,omitempty"`
+}
+
+// ResponseV1 is a saved response in Collection Format v1.
+type ResponseV1 struct {
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name"`
+	Status       string `json:"status,omitempty"`
+	ResponseCode struct {
+		Code int    `json:"code"`
+		Name string `json:"name"`
+	} `json:"responseCode"`
+	Time     interface{}   `json:"time,omitempty"`
+	Headers  []*ParamV1    `json:"headers,omitempty"`
+	Cookies  []*gen.Cookie `json:"cookies,omitempty"`
+	Text     string        `json:"text,omitempty"`
+	Language string        `json:"language,omitempty"`
+	// Request is the ID of a request in the collection, or a request object.
+	Request interface{} `json:"request,omitempty"`
+}
+
+// ParamV1 is a header, parameter or form field in Collection Format v1.
+type ParamV1 struct {
+	Key         string      `json:"key"`
+	Name        string      `json:"name,omitempty"`
+	Value       interface{} `json:"value"`
+	Type        string      `json:"type,omitempty"`
+	Enabled     *bool       `json:"enabled,omitempty"`
+	Description interface{} `json:"description,omitempty"`
+}
+
+// v1Helpers maps the auth helpers of v1 requests to v2.1 auth types.
+var v1Helpers = map[string]string{
+	"basicAuth":  "basic",
+	"bearerAuth": "bearer",
+	"digestAuth": "digest",
+	"oAuth1":     "oauth1",
+	"oAuth2":     "oauth2",
+	"hawkAuth":   "hawk",
+	"awsSigV4":   "awsv4",
+	"ntlmAuth":   "ntlm",
+}
+
+// IsCollectionV1 reports whether a document is a collection in Collection
+// Format v1, which has no info block and keeps requests in a flat list.
+func IsCollectionV1(b []byte) bool {
+	var v map[string]json.RawMessage
+	if err := json.Unmarshal(b, &v); err != nil {
+		return false
+	}
+
+	if c, ok := v["collection"]; ok && len(v) == 1 {
+		return IsCollectionV1(c)
+	}
+
+	_, info := v["info"]
+	_, requests := v["requests"]
+	_, order := v["order"]
+
+	return !info && (requests || order)
+}
+
+// ReadCollectionV1 decodes a collection in Collection Format v1.
+func ReadCollectionV1(b []byte) (*CollectionV1, error) {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(b, &envelope); err != nil {
+		return nil, err
+	}
+	if c, ok := envelope["collection"]; ok && len(envelope) == 1 {
+		b = c
+	}
+
+	var c CollectionV1
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// CollectionV1ToV21 converts a v1 collection to Collection Format v2.1.
+// Folders and requests keep their order. Requests that no order list
+// refers to are added to the end of the collection.
+func CollectionV1ToV21(v1 *CollectionV1) (*gen.Collection, error) {
+	if v1 == nil {
+		return nil, errors.New("no collection")
+	}
+
+	cv := &v1Converter{
+		folders:  make(map[string]*FolderV1),
+		requests: make(map[string]*RequestV1),
+		placed:   make(map[string]bool),
+	}
+	for _, f := range v1.Folders {
+		cv.folders[f.ID] = f
+	}
+	for _, r := range v1.Requests {
+		cv.requests[r.ID] = r
+	}
+
+	c := &gen.Collection{
+		Info: &gen.Info{
+			Name:      v1.Name,
+			PostmanID: v1.ID,
+			Schema:    collectionSchemaURL,
+		},
+		Event:    v1.Events,
+		Variable: v1.Variables,
+	}
+	if a := cv.auth(v1.Auth, "", nil); a != nil {
+		c.Auth = a
+	}
+	if v1.Description != "" {
+		c.Info.Description = v1.Description
+	}
+
+	foldersOrder := v1.FoldersOrder
+	if foldersOrder == nil {
+		// Older exports have no folders_order and no nested folders.
+		for _, f := range v1.Folders {
+			foldersOrder = append(foldersOrder, f.ID)
+		}
+	}
+
+	items, err := cv.items(foldersOrder, v1.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range v1.Requests {
+		if cv.placed[r.ID] {
+			continue
+		}
+		item, err := cv.item(r)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	c.Item = items
+
+	return c, nil
+}
+
+type v1Converter struct {
+	folders  map[string]*FolderV1
+	requests map[string]*RequestV1
+	placed   map[string]bool
+}
+
+// items converts folders, then requests, as Postman lists them.
+func (cv *v1Converter) items(foldersOrder, order []string) ([]interface{}, error) {
+	items := []interface{}{}
+
+	for _, id := range foldersOrder {
+		f, ok := cv.folders[id]
+		if !ok || cv.placed[id] {
+			continue
+		}
+		cv.placed[id] = true
+
+		children, err := cv.items(f.FoldersOrder, f.Order)
+		if err != nil {
+			return nil, err
+		}
+
+		g := &gen.ItemGroup{
+			Name:  f.Name,
+			Event: f.Events,
+			Item:  children,
+		}
+		if a := cv.auth(f.Auth, "", nil); a != nil {
+			g.Auth = a
+		}
+		if f.Description != "" {
+			g.Description = f.Description
+		}
+		items = append(items, g)
+	}
+
+	for _, id := range order {
+		r, ok := cv.requests[id]
+		if !ok || cv.placed[id] {
+			continue
+		}
+		cv.placed[id] = true
+
+		item, err := cv.item(r)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// item converts a request. The item is returned as a map, as gen.Response
+// has no field for the names of saved responses.
+func (cv *v1Converter) item(r *RequestV1) (map[string]interface{}, error) {
+	item := &gen.Item{
+		ID:      r.ID,
+		Name:    r.Name,
+		Request: cv.request(r),
+		Event:   r.Events,
+	}
+	if item.Name == "" {
+		item.Name = r.URL
+	}
+
+	if item.Event == nil {
+		if r.PreRequestScript != "" {
+			item.Event = append(item.Event, v1Event("prerequest", r.PreRequestScript))
+		}
+		if r.Tests != "" {
+			item.Event = append(item.Event, v1Event("test", r.Tests))
+		}
+	}
+
+	b, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	m = util.ReformatMap(m, true, nil)
+
+	if len(r.Responses) > 0 {
+		responses := make([]*resources.Response, len(r.Responses))
+		for i, res := range r.Responses {
+			responses[i] = cv.response(res, r)
+		}
+		m["response"] = responses
+	}
+
+	return m, nil
+}
+
+func (cv *v1Converter) request(r *RequestV1) *resources.Request {
+	req := &resources.Request{
+		Method: strings.ToUpper(r.Method),
+		URL:    resources.ParseURL(r.URL),
+		Auth:   cv.auth(r.Auth, r.CurrentHelper, r.HelperAttributes),
+	}
+	if req.Method == "" {
+		req.Method = "GET"
+	}
+	if r.Description != "" {
+		req.Description = r.Description
+	}
+
+	if r.QueryParams != nil {
+		req.URL.Query = v1Params(r.QueryParams)
+	}
+
+	if r.PathVariableData != nil {
+		for _, p := range r.PathVariableData {
+			req.URL.Variable = append(req.URL.Variable, &gen.Variable{Key: p.Key, Value: valueString(p.Value)})
+		}
+	} else {
+		keys := make([]string, 0, len(r.PathVariables))
+		for k := range r.PathVariables {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			req.URL.Variable = append(req.URL.Variable, &gen.Variable{Key: k, Value: valueString(r.PathVariables[k])})
+		}
+	}
+
+	if r.HeaderData != nil {
+		req.Header = v1Params(r.HeaderData)
+	} else {
+		req.Header = v1HeaderString(r.Headers)
+	}
+
+	req.Body = v1Body(r)
+
+	return req
+}
+
+func (cv *v1Converter) response(res *ResponseV1, r *RequestV1) *resources.Response {
+	out := &resources.Response{
+		ID:           res.ID,
+		Name:         res.Name,
+		Code:         res.ResponseCode.Code,
+		Status:       res.ResponseCode.Name,
+		Header:       v1Params(res.Headers),
+		Cookie:       res.Cookies,
+		Body:         res.Text,
+		ResponseTime: res.Time,
+	}
+	if out.Status == "" {
+		out.Status = res.Status
+	}
+	if res.Language != "" {
+		out.PreviewLanguage = res.Language
+	}
+
+	switch t := res.Request.(type) {
+	case string:
+		if original, ok := cv.requests[t]; ok {
+			out.OriginalRequest = cv.request(original)
+		}
+	case map[string]interface{}:
+		b, err := json.Marshal(t)
+		if err != nil {
+			break
+		}
+		var original RequestV1
+		if err := json.Unmarshal(b, &original); err == nil {
+			out.OriginalRequest = cv.request(&original)
+		}
+	}
+	if out.OriginalRequest == nil {
+		out.OriginalRequest = cv.request(r)
+	}
+
+	return out
+}
+
+// auth converts a v1 auth block, or the older helper attributes.
+func (cv *v1Converter) auth(auth interface{}, helper string, attributes interface{}) *gen.Auth {
+	if a := decodeAuth(auth); a != nil {
+		return a
+	}
+
+	t, ok := v1Helpers[helper]
+	if !ok {
+		return nil
+	}
+
+	m, _ := attributes.(map[string]interface{})
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		if k != "id" && k != "saveToRequest" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	attrs := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, map[string]interface{}{"key": k, "value": m[k], "type": "string"})
+	}
+
+	return decodeAuth(map[string]interface{}{"type": t, t: attrs})
+}
+
+func v1Body(r *RequestV1) *resources.Body {
+	var b *resources.Body
+	switch r.DataMode {
+	case "raw":
+		raw := valueString(r.RawModeData)
+		if raw == "" {
+			return nil
+		}
+		b = &resources.Body{Mode: "raw", Raw: raw}
+	case "urlencoded":
+		b = &resources.Body{Mode: "urlencoded", URLEncoded: v1Params(r.Data)}
+	case "params":
+		b = &resources.Body{Mode: "formdata", FormData: v1Params(r.Data)}
+		for i, p := range b.FormData {
+			if p.Type == "file" {
+				p.Src = r.Data[i].Value
+				p.Value = ""
+			}
+		}
+	case "binary":
+		b = &resources.Body{Mode: "file", File: map[string]interface{}{"src": r.RawModeData}}
+	case "graphql":
+		b = &resources.Body{Mode: "graphql", GraphQL: r.GraphQLModeData}
+	default:
+		return nil
+	}
+
+	b.Options = r.DataOptions
+
+	return b
+}
+
+func v1Params(params []*ParamV1) []*resources.KeyValue {
+	if params == nil {
+		return nil
+	}
+
+	r := make([]*resources.KeyValue, 0, len(params))
+	for _, p := range params {
+		key := p.Key
+		if key == "" {
+			key = p.Name
+		}
+
+		kv := &resources.KeyValue{
+			Key:         key,
+			Value:       valueString(p.Value),
+			Disabled:    p.Enabled != nil && !*p.Enabled,
+			Description: p.Description,
+		}
+		if p.Type == "file" || p.Type == "text" {
+			kv.Type = p.Type
+		}
+		if s, ok := kv.Description.(string); ok && s == "" {
+			kv.Description = nil
+		}
+		r = append(r, kv)
+	}
+
+	return r
+}
+
+// v1HeaderString parses "Key: Value" lines. Lines commented out with //
+// are disabled headers.
+func v1HeaderString(s string) []*resources.KeyValue {
+	var headers []*resources.KeyValue
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		disabled := strings.HasPrefix(line, "//")
+		line = strings.TrimSpace(strings.TrimPrefix(line, "//"))
+
+		kv := strings.SplitN(line, ":", 2)
+		h := &resources.KeyValue{Key: strings.TrimSpace(kv[0]), Disabled: disabled}
+		if len(kv) > 1 {
+			h.Value = strings.TrimSpace(kv[1])
+		}
+		headers = append(headers, h)
+	}
+
+	return headers
+}
+
+func v1Event(listen, script string) *gen.Event {
+	return &gen.Event{
+		Listen: listen,
+		Script: &gen.Script{
+			Type: "text/javascript",
+			Exec: strings.Split(script, "\n"),
+		},
+	}
+}
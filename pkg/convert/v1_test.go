@@ -0,0 +1,142 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/util"
+	"github.com/kevinswiber/postmanctl/pkg/validate"
+)
+
+const testV1Collection = `{
+	"id": "c1",
+	"name": "Legacy",
+	"order": ["r3"],
+	"folders_order": ["f1"],
+	"folders": [
+		{"id": "f1", "name": "Users", "order": ["r1"], "folders_order": ["f2"]},
+		{"id": "f2", "name": "Admin", "order": ["r2"]}
+	],
+	"requests": [
+		{"id": "r1", "name": "List users", "url": "{{host}}/users?page=1", "method": "get",
+			"headers": "Accept: application/json\n// X-Debug: 1\n",
+			"tests": "pm.test('ok', function () {});",
+			"currentHelper": "basicAuth", "helperAttributes": {"id": "basic", "username": "u", "password": "p"},
+			"responses": [{"name": "OK", "responseCode": {"code": 200, "name": "OK"}, "text": "[]", "request": "r1"}]},
+		{"id": "r2", "name": "Create user", "url": "{{host}}/users/:org", "method": "POST",
+			"pathVariables": {"org": "acme"},
+			"dataMode": "urlencoded", "data": [{"key": "name", "value": "x", "enabled": false}]},
+		{"id": "r3", "name": "Upload", "url": "{{host}}/files", "method": "POST", "dataMode": "params",
+			"data": [{"key": "f", "value": "a.txt", "type": "file"}]},
+		{"id": "r4", "name": "Orphan", "url": "{{host}}/o", "method": "PUT", "dataMode": "raw", "rawModeData": "{}"}
+	]
+}`
+
+func TestIsCollectionV1(t *testing.T) {
+	if !convert.IsCollectionV1([]byte(testV1Collection)) {
+		t.Error("Expected a v1 collection.")
+	}
+
+	if convert.IsCollectionV1([]byte(testCurlCollection)) {
+		t.Error("Expected a v2.1 collection.")
+	}
+}
+
+func TestReadCollectionV1(t *testing.T) {
+	c := readTestCollection(t, testV1Collection)
+
+	if c.Info.Name != "Legacy" || c.Info.PostmanID != "c1" {
+		t.Errorf("Info is incorrect, have: %s %s", c.Info.Name, c.Info.PostmanID)
+	}
+
+	var names []string
+	var walk func(node *resources.ItemTreeNode, prefix string)
+	walk = func(node *resources.ItemTreeNode, prefix string) {
+		if node.Branches != nil {
+			for _, b := range *node.Branches {
+				walk(&b, prefix+b.Name+"/")
+			}
+		}
+		if node.Items != nil {
+			for _, i := range *node.Items {
+				names = append(names, prefix+i.Name)
+			}
+		}
+	}
+	walk(&c.Items.Root, "")
+
+	expected := "Users/Admin/Create user,Users/List users,Upload,Orphan"
+	if have := strings.Join(names, ","); have != expected {
+		t.Errorf("Item order is incorrect, have: %s, want: %s", have, expected)
+	}
+
+	b, err := json.Marshal(c.Collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(b)
+	for _, want := range []string{
+		`"type":"basic"`,
+		`"listen":"test"`,
+		`"name":"OK"`,
+		`"variable":[{"key":"org","value":"acme"}]`,
+		`"disabled":true,"key":"X-Debug"`,
+		`"mode":"formdata"`,
+		`"src":"a.txt"`,
+	} {
+		if !strings.Contains(s, want) {
+			t.Errorf("Expected %s in converted collection.", want)
+		}
+	}
+}
+
+func TestCollectionV1ToV21Valid(t *testing.T) {
+	v1, err := convert.ReadCollectionV1([]byte(testV1Collection))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := convert.CollectionV1ToV21(v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+
+	// Drop the null values written by the generated types, as the CLI does.
+	b, err = json.Marshal(util.ReformatMap(m, true, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if errs := validate.Collection(b); errs != nil {
+		t.Error(errs)
+	}
+}
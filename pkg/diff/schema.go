@@ -0,0 +1,366 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package diff compares versions of an API and classifies the changes as
+// breaking or non-breaking for existing clients.
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+)
+
+// maxDepth bounds the comparison of recursive schemas.
+const maxDepth = 8
+
+// Change is a difference between two versions of an API.
+type Change struct {
+	// Breaking is set when existing clients may stop working.
+	Breaking bool `json:"breaking"`
+
+	// Location is the path or operation changed, such as "GET /users/{id}".
+	Location string `json:"location"`
+	Message  string `json:"message"`
+}
+
+// Schemas compares two OpenAPI documents. Changes are sorted by location,
+// with breaking changes first.
+func Schemas(from, to *convert.OpenAPI) []Change {
+	d := &differ{from: from, to: to}
+
+	for _, p := range unionKeys(from.Paths, to.Paths) {
+		a, b := from.Paths[p], to.Paths[p]
+		switch {
+		case b == nil:
+			d.add(true, p, "path removed")
+		case a == nil:
+			d.add(false, p, "path added")
+		default:
+			d.path(p, a, b)
+		}
+	}
+
+	sort.SliceStable(d.changes, func(i, j int) bool {
+		if d.changes[i].Location != d.changes[j].Location {
+			return d.changes[i].Location < d.changes[j].Location
+		}
+		return d.changes[i].Breaking && !d.changes[j].Breaking
+	})
+
+	return d.changes
+}
+
+// HasBreaking reports whether any change is breaking.
+func HasBreaking(changes []Change) bool {
+	for _, c := range changes {
+		if c.Breaking {
+			return true
+		}
+	}
+
+	return false
+}
+
+type differ struct {
+	from, to *convert.OpenAPI
+	changes  []Change
+}
+
+func (d *differ) add(breaking bool, location, format string, args ...interface{}) {
+	d.changes = append(d.changes, Change{
+		Breaking: breaking,
+		Location: location,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (d *differ) path(p string, a, b *convert.PathItem) {
+	aOps, bOps := a.Operations(), b.Operations()
+
+	for _, m := range unionKeys(aOps, bOps) {
+		location := m + " " + p
+		switch {
+		case bOps[m] == nil:
+			d.add(true, location, "operation removed")
+		case aOps[m] == nil:
+			d.add(false, location, "operation added")
+		default:
+			d.operation(location, a, aOps[m], b, bOps[m])
+		}
+	}
+}
+
+func (d *differ) operation(location string, aItem *convert.PathItem, a *convert.Operation, bItem *convert.PathItem, b *convert.Operation) {
+	if !a.Deprecated && b.Deprecated {
+		d.add(false, location, "operation deprecated")
+	}
+
+	d.parameters(location, d.params(d.from, aItem, a), d.params(d.to, bItem, b))
+	d.requestBody(location, a.RequestBody, b.RequestBody)
+	d.responses(location, a.Responses, b.Responses)
+}
+
+// params returns the resolved parameters of an operation, including those
+// of its path, keyed by location and name.
+func (d *differ) params(doc *convert.OpenAPI, item *convert.PathItem, o *convert.Operation) map[string]*convert.Parameter {
+	params := make(map[string]*convert.Parameter)
+	for _, list := range [][]*convert.Parameter{item.Parameters, o.Parameters} {
+		for _, p := range list {
+			if p, err := doc.ResolveParameter(p); err == nil {
+				params[p.In+" "+p.Name] = p
+			}
+		}
+	}
+
+	return params
+}
+
+func (d *differ) parameters(location string, a, b map[string]*convert.Parameter) {
+	for _, key := range unionKeys(a, b) {
+		pa, pb := a[key], b[key]
+		var name string
+		if pa != nil {
+			name = fmt.Sprintf("%s parameter %q", pa.In, pa.Name)
+		} else {
+			name = fmt.Sprintf("%s parameter %q", pb.In, pb.Name)
+		}
+
+		switch {
+		case pb == nil:
+			d.add(false, location, "%s removed", name)
+		case pa == nil:
+			if pb.Required {
+				d.add(true, location, "required %s added", name)
+			} else {
+				d.add(false, location, "optional %s added", name)
+			}
+		default:
+			if !pa.Required && pb.Required {
+				d.add(true, location, "%s is now required", name)
+			} else if pa.Required && !pb.Required {
+				d.add(false, location, "%s is now optional", name)
+			}
+			d.schema(location, name, d.from.ResolveSchema(pa.Schema), d.to.ResolveSchema(pb.Schema), true, 0)
+		}
+	}
+}
+
+func (d *differ) requestBody(location string, a, b *convert.RequestBody) {
+	if a != nil {
+		a, _ = d.from.ResolveRequestBody(a)
+	}
+	if b != nil {
+		b, _ = d.to.ResolveRequestBody(b)
+	}
+
+	switch {
+	case a == nil && b == nil:
+		return
+	case b == nil:
+		d.add(false, location, "request body removed")
+		return
+	case a == nil:
+		d.add(b.Required, location, "request body added")
+		return
+	}
+
+	if !a.Required && b.Required {
+		d.add(true, location, "request body is now required")
+	}
+
+	for _, mt := range unionKeys(a.Content, b.Content) {
+		ma, mb := a.Content[mt], b.Content[mt]
+		switch {
+		case mb == nil:
+			d.add(true, location, "request body media type %s removed", mt)
+		case ma == nil:
+			d.add(false, location, "request body media type %s added", mt)
+		default:
+			d.schema(location, "request body "+mt, d.from.ResolveSchema(ma.Schema), d.to.ResolveSchema(mb.Schema), true, 0)
+		}
+	}
+}
+
+func (d *differ) responses(location string, a, b map[string]*convert.Response) {
+	for _, code := range unionKeys(a, b) {
+		ra, rb := a[code], b[code]
+		if ra != nil {
+			ra, _ = d.from.ResolveResponse(ra)
+		}
+		if rb != nil {
+			rb, _ = d.to.ResolveResponse(rb)
+		}
+
+		switch {
+		case ra == nil && rb == nil:
+		case rb == nil:
+			d.add(isSuccess(code), location, "response %s removed", code)
+		case ra == nil:
+			d.add(false, location, "response %s added", code)
+		default:
+			for _, mt := range unionKeys(ra.Content, rb.Content) {
+				ma, mb := ra.Content[mt], rb.Content[mt]
+				name := "response " + code + " " + mt
+				switch {
+				case mb == nil:
+					d.add(isSuccess(code), location, "%s removed", name)
+				case ma == nil:
+					d.add(false, location, "%s added", name)
+				default:
+					d.schema(location, name, d.from.ResolveSchema(ma.Schema), d.to.ResolveSchema(mb.Schema), false, 0)
+				}
+			}
+		}
+	}
+}
+
+// schema compares the schemas of a value sent by clients, when request is
+// set, or received by them.
+func (d *differ) schema(location, name string, a, b *convert.Schema, request bool, depth int) {
+	if a == nil || b == nil || depth > maxDepth {
+		return
+	}
+
+	if a.Type != b.Type && a.Type != "" && b.Type != "" {
+		widened := request && a.Type == "integer" && b.Type == "number"
+		d.add(!widened, location, "%s type changed from %s to %s", name, a.Type, b.Type)
+	} else if a.Type == "" && b.Type != "" && request {
+		d.add(true, location, "%s type narrowed to %s", name, b.Type)
+	}
+
+	if a.Format != b.Format && a.Format != "" && b.Format != "" {
+		d.add(true, location, "%s format changed from %s to %s", name, a.Format, b.Format)
+	}
+
+	d.enum(location, name, a.Enum, b.Enum, request)
+
+	if a.Items != nil && b.Items != nil {
+		d.schema(location, name+" items", d.from.ResolveSchema(a.Items), d.to.ResolveSchema(b.Items), request, depth+1)
+	}
+
+	aReq, bReq := toSet(a.Required), toSet(b.Required)
+	for _, p := range unionKeys(a.Properties, b.Properties) {
+		pa, pb := a.Properties[p], b.Properties[p]
+		prop := fmt.Sprintf("%s property %q", name, p)
+
+		switch {
+		case pb == nil:
+			// Clients reading a response may rely on the property.
+			d.add(!request, location, "%s removed", prop)
+		case pa == nil:
+			d.add(request && bReq[p], location, "%s added", prop)
+		default:
+			if request && !aReq[p] && bReq[p] {
+				d.add(true, location, "%s is now required", prop)
+			} else if !request && aReq[p] && !bReq[p] {
+				d.add(true, location, "%s is now optional", prop)
+			}
+			d.schema(location, prop, d.from.ResolveSchema(pa), d.to.ResolveSchema(pb), request, depth+1)
+		}
+	}
+}
+
+// enum compares allowed values. Fewer values break clients sending them and
+// more values break clients receiving them.
+func (d *differ) enum(location, name string, a, b []interface{}, request bool) {
+	if len(a) == 0 && len(b) == 0 {
+		return
+	}
+
+	if len(a) == 0 {
+		d.add(request, location, "%s is now limited to %s", name, formatValues(b))
+		return
+	}
+	if len(b) == 0 {
+		d.add(!request, location, "%s is no longer limited to a set of values", name)
+		return
+	}
+
+	as, bs := valueSet(a), valueSet(b)
+	var removed, added []string
+	for v := range as {
+		if !bs[v] {
+			removed = append(removed, v)
+		}
+	}
+	for v := range bs {
+		if !as[v] {
+			added = append(added, v)
+		}
+	}
+	sort.Strings(removed)
+	sort.Strings(added)
+
+	if len(removed) > 0 {
+		d.add(request, location, "%s values removed: %s", name, strings.Join(removed, ", "))
+	}
+	if len(added) > 0 {
+		d.add(!request, location, "%s values added: %s", name, strings.Join(added, ", "))
+	}
+}
+
+func isSuccess(code string) bool {
+	return strings.HasPrefix(code, "2") || strings.HasPrefix(code, "3")
+}
+
+func toSet(s []string) map[string]bool {
+	m := make(map[string]bool, len(s))
+	for _, v := range s {
+		m[v] = true
+	}
+
+	return m
+}
+
+func valueSet(values []interface{}) map[string]bool {
+	m := make(map[string]bool, len(values))
+	for _, v := range values {
+		m[fmt.Sprint(v)] = true
+	}
+
+	return m
+}
+
+func formatValues(values []interface{}) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = fmt.Sprint(v)
+	}
+
+	return strings.Join(s, ", ")
+}
+
+// unionKeys returns the keys of two maps with string keys, sorted.
+func unionKeys(a, b interface{}) []string {
+	seen := make(map[string]bool)
+	for _, m := range []interface{}{a, b} {
+		for _, k := range reflect.ValueOf(m).MapKeys() {
+			seen[k.String()] = true
+		}
+	}
+
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
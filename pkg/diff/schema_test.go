@@ -0,0 +1,131 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+	"github.com/kevinswiber/postmanctl/pkg/diff"
+)
+
+const fromSchema = `openapi: 3.0.0
+info: {title: Users, version: "1"}
+paths:
+  /users:
+    get:
+      parameters:
+        - {name: limit, in: query, schema: {type: integer}}
+        - {name: status, in: query, schema: {type: string, enum: [active, inactive]}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {type: array, items: {$ref: "#/components/schemas/User"}}
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {type: object, properties: {name: {type: string}, age: {type: integer}}}
+      responses: {"201": {description: created}}
+  /users/{id}:
+    delete:
+      responses: {"204": {description: gone}}
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        id: {type: integer}
+        email: {type: string}
+`
+
+const toSchema = `openapi: 3.0.0
+info: {title: Users, version: "2"}
+paths:
+  /users:
+    get:
+      parameters:
+        - {name: limit, in: query, required: true, schema: {type: integer}}
+        - {name: status, in: query, schema: {type: string, enum: [active, pending]}}
+        - {name: q, in: query, schema: {type: string}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {type: array, items: {$ref: "#/components/schemas/User"}}
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {type: object, required: [name], properties: {name: {type: string}, age: {type: number}}}
+      responses: {"201": {description: created}}
+  /health:
+    get:
+      responses: {"200": {description: ok}}
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        id: {type: string}
+        name: {type: string}
+`
+
+func parse(t *testing.T, s string) *convert.OpenAPI {
+	doc, err := convert.ParseOpenAPI([]byte(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return doc
+}
+
+func TestSchemas(t *testing.T) {
+	changes := diff.Schemas(parse(t, fromSchema), parse(t, toSchema))
+
+	expected := []diff.Change{
+		{Breaking: false, Location: "/health", Message: "path added"},
+		{Breaking: true, Location: "/users/{id}", Message: "path removed"},
+		{Breaking: true, Location: "GET /users", Message: `query parameter "limit" is now required`},
+		{Breaking: true, Location: "GET /users", Message: `query parameter "status" values removed: inactive`},
+		{Breaking: true, Location: "GET /users", Message: `response 200 application/json items property "email" removed`},
+		{Breaking: true, Location: "GET /users", Message: `response 200 application/json items property "id" type changed from integer to string`},
+		{Breaking: false, Location: "GET /users", Message: `optional query parameter "q" added`},
+		{Breaking: false, Location: "GET /users", Message: `query parameter "status" values added: pending`},
+		{Breaking: false, Location: "GET /users", Message: `response 200 application/json items property "name" added`},
+		{Breaking: true, Location: "POST /users", Message: `request body application/json property "name" is now required`},
+		{Breaking: false, Location: "POST /users", Message: `request body application/json property "age" type changed from integer to number`},
+	}
+
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Changes are incorrect, have:\n%v\nwant:\n%v", changes, expected)
+	}
+
+	if !diff.HasBreaking(changes) {
+		t.Error("Expected breaking changes")
+	}
+}
+
+func TestSchemasUnchanged(t *testing.T) {
+	if changes := diff.Schemas(parse(t, fromSchema), parse(t, fromSchema)); len(changes) != 0 {
+		t.Errorf("Expected no changes, have: %v", changes)
+	}
+}
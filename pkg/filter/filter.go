@@ -0,0 +1,266 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package filter selects Postman resources with label selectors. Postman
+// has no labels, so labels are written as tags such as [team:payments] in
+// names and descriptions.
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var tagPattern = regexp.MustCompile(`\[([A-Za-z0-9_.-]+):([^\]]*)\]`)
+
+// Tags returns the tags written as [key:value] in texts. Later tags with
+// the same key win.
+func Tags(texts ...string) map[string]string {
+	tags := make(map[string]string)
+	for _, t := range texts {
+		for _, m := range tagPattern.FindAllStringSubmatch(t, -1) {
+			tags[m[1]] = strings.TrimSpace(m[2])
+		}
+	}
+
+	return tags
+}
+
+// Object is a resource as seen by selectors.
+type Object struct {
+	Name        string
+	Owner       string
+	Description string
+	Fork        bool
+	// Workspaces holds the IDs and names of the workspaces containing the
+	// resource.
+	Workspaces []string
+}
+
+// Operators of requirements.
+const (
+	Exists     = ""
+	NotExists  = "!"
+	Equals     = "="
+	NotEquals  = "!="
+	Matches    = "=~"
+	NotMatches = "!~"
+)
+
+// Keys of the fields of objects. Other keys are tags.
+const (
+	fieldName  = "name"
+	fieldOwner = "owner"
+	fieldWs    = "workspace"
+	fieldFork  = "fork"
+)
+
+func isField(key string) bool {
+	return key == fieldName || key == fieldOwner || key == fieldWs || key == fieldFork
+}
+
+// Requirement is a condition on a field or tag, such as "team=payments".
+type Requirement struct {
+	Key      string
+	Operator string
+	Value    string
+
+	re *regexp.Regexp
+}
+
+// Selector is a list of requirements that must all be met.
+type Selector []Requirement
+
+// Parse parses a selector. Requirements are separated by commas:
+//
+//	name=~<regex>, name!~<regex>   the name matches a regular expression
+//	name=<name>, name!=<name>      the name is or is not equal to a value
+//	owner=<id>, owner!=<id>        the resource is owned by a user
+//	workspace=<id or name>         the resource is in a workspace
+//	fork, !fork                    the resource is a fork
+//	<tag>=<value>, <tag>!=<value>  the resource has a tag with a value
+//	<tag>, !<tag>                  the resource has a tag
+func Parse(s string) (Selector, error) {
+	var sel Selector
+	for _, part := range splitRequirements(s) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		r, err := parseRequirement(part)
+		if err != nil {
+			return nil, err
+		}
+		sel = append(sel, r)
+	}
+
+	return sel, nil
+}
+
+// splitRequirements splits at commas outside of brackets, so that regular
+// expressions such as a{1,3} are kept whole.
+func splitRequirements(s string) []string {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+	for i, c := range s {
+		switch c {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+func parseRequirement(s string) (Requirement, error) {
+	r := Requirement{Key: s}
+	if strings.HasPrefix(s, "!") {
+		r.Key, r.Operator = strings.TrimSpace(s[1:]), NotExists
+	} else if i := strings.IndexAny(s, "=!"); i >= 0 {
+		op := s[i : i+1]
+		if i+1 < len(s) && (s[i+1] == '~' || s[i+1] == '=' && op == "!") {
+			op = s[i : i+2]
+		}
+		if op == "!" {
+			return r, fmt.Errorf("invalid operator in requirement: %s", s)
+		}
+
+		r = Requirement{Key: strings.TrimSpace(s[:i]), Operator: op, Value: strings.TrimSpace(s[i+len(op):])}
+	}
+
+	if r.Key == "" {
+		return r, fmt.Errorf("missing key in requirement: %s", s)
+	}
+
+	switch r.Operator {
+	case Matches, NotMatches:
+		if r.Key != fieldName {
+			return r, fmt.Errorf("only the name can be matched with %s: %s", r.Operator, s)
+		}
+
+		re, err := regexp.Compile(r.Value)
+		if err != nil {
+			return r, fmt.Errorf("invalid regular expression in %s: %s", s, err)
+		}
+		r.re = re
+	case Exists, NotExists:
+		if r.Key == fieldName || r.Key == fieldOwner || r.Key == fieldWs {
+			return r, fmt.Errorf("a value is required for %s: %s", r.Key, s)
+		}
+	default:
+		if r.Key == fieldFork && r.Value != "true" && r.Value != "false" {
+			return r, errors.New("fork can only be true or false")
+		}
+	}
+
+	return r, nil
+}
+
+// Matches reports whether an object meets all requirements.
+func (s Selector) Matches(o Object) bool {
+	var tags map[string]string
+	for _, r := range s {
+		if !isField(r.Key) && tags == nil {
+			tags = Tags(o.Name, o.Description)
+		}
+
+		if !r.matches(o, tags) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r Requirement) matches(o Object, tags map[string]string) bool {
+	switch r.Key {
+	case fieldName:
+		switch r.Operator {
+		case Matches:
+			return r.re.MatchString(o.Name)
+		case NotMatches:
+			return !r.re.MatchString(o.Name)
+		}
+		return (o.Name == r.Value) == (r.Operator == Equals)
+	case fieldOwner:
+		return (o.Owner == r.Value) == (r.Operator == Equals)
+	case fieldWs:
+		in := false
+		for _, w := range o.Workspaces {
+			if w == r.Value {
+				in = true
+			}
+		}
+		return in == (r.Operator == Equals)
+	case fieldFork:
+		want := r.Operator != NotExists
+		if r.Operator == Equals || r.Operator == NotEquals {
+			want = (r.Value == "true") == (r.Operator == Equals)
+		}
+		return o.Fork == want
+	}
+
+	v, ok := tags[r.Key]
+	switch r.Operator {
+	case Exists:
+		return ok
+	case NotExists:
+		return !ok
+	case Equals:
+		return ok && v == r.Value
+	default:
+		return !ok || v != r.Value
+	}
+}
+
+// UsesWorkspaces reports whether workspace membership is needed to match
+// objects.
+func (s Selector) UsesWorkspaces() bool {
+	for _, r := range s {
+		if r.Key == fieldWs {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UsesTags reports whether tags are needed to match objects, and so
+// descriptions.
+func (s Selector) UsesTags() bool {
+	for _, r := range s {
+		if !isField(r.Key) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,101 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/filter"
+)
+
+func TestTags(t *testing.T) {
+	tags := filter.Tags("Orders [team:payments]", "Owned by [team: billing] [env:prod]")
+	expected := map[string]string{"team": "billing", "env": "prod"}
+
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("Tags are incorrect, have: %v, want: %v", tags, expected)
+	}
+}
+
+func TestSelector(t *testing.T) {
+	orders := filter.Object{
+		Name:        "Orders API [team:payments]",
+		Owner:       "1234",
+		Description: "[env:staging]",
+		Workspaces:  []string{"w-1", "Payments"},
+	}
+	fork := filter.Object{
+		Name:  "Orders API (fork) [team:payments] [env:prod]",
+		Owner: "5678",
+		Fork:  true,
+	}
+	users := filter.Object{Name: "Users API"}
+
+	tests := []struct {
+		selector string
+		expected []filter.Object
+	}{
+		{"team=payments,env!=prod", []filter.Object{orders}},
+		{"team", []filter.Object{orders, fork}},
+		{"!team", []filter.Object{users}},
+		{"name=~^Orders, !fork", []filter.Object{orders}},
+		{"fork=true", []filter.Object{fork}},
+		{"name!~(fork|Users)", []filter.Object{orders}},
+		{"name=Users API", []filter.Object{users}},
+		{"owner=5678", []filter.Object{fork}},
+		{"workspace=Payments", []filter.Object{orders}},
+		{"workspace!=w-1", []filter.Object{fork, users}},
+		{"name=~^[A-Za-z]{1,5} API$", []filter.Object{users}},
+		{"", []filter.Object{orders, fork, users}},
+	}
+
+	for _, tt := range tests {
+		sel, err := filter.Parse(tt.selector)
+		if err != nil {
+			t.Errorf("%s: %s", tt.selector, err)
+			continue
+		}
+
+		var have []filter.Object
+		for _, o := range []filter.Object{orders, fork, users} {
+			if sel.Matches(o) {
+				have = append(have, o)
+			}
+		}
+
+		if !reflect.DeepEqual(have, tt.expected) {
+			t.Errorf("%s: matches are incorrect, have: %v, want: %v", tt.selector, have, tt.expected)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, s := range []string{"=x", "team=~x", "name=~(", "owner", "fork=maybe", "team!"} {
+		if _, err := filter.Parse(s); err == nil {
+			t.Errorf("Expected an error for %q", s)
+		}
+	}
+
+	sel, err := filter.Parse("name=~a,workspace=w")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sel.UsesWorkspaces() || sel.UsesTags() {
+		t.Errorf("Selector needs are incorrect: %+v", sel)
+	}
+}
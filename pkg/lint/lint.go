@@ -0,0 +1,296 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lint checks collections and OpenAPI schemas against a set of
+// rules.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"gopkg.in/yaml.v2"
+)
+
+// Severity is how serious a finding is.
+type Severity int
+
+// Severities, from most to least serious. Off disables a rule.
+const (
+	Error Severity = iota
+	Warning
+	Info
+	Off
+)
+
+var severityNames = []string{"error", "warning", "info", "off"}
+
+func (s Severity) String() string {
+	if s < Error || s > Off {
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+
+	return severityNames[s]
+}
+
+// ParseSeverity parses a severity name.
+func ParseSeverity(s string) (Severity, error) {
+	for i, name := range severityNames {
+		if strings.EqualFold(s, name) {
+			return Severity(i), nil
+		}
+	}
+
+	return Off, fmt.Errorf("unknown severity: %s", s)
+}
+
+// MarshalJSON writes the severity by name.
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalYAML parses a severity name.
+func (s *Severity) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var name string
+	if err := unmarshal(&name); err != nil {
+		return err
+	}
+
+	v, err := ParseSeverity(name)
+	if err != nil {
+		return err
+	}
+	*s = v
+
+	return nil
+}
+
+// Finding is a problem reported by a rule.
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+
+	// Path is the folder path and name of the request or folder, separated
+	// by slashes, or the operation or path in a schema, such as
+	// "GET /users/{id}". It is empty for the collection or schema itself.
+	Path string `json:"path"`
+}
+
+// Reporter records the findings of a rule.
+type Reporter func(path, format string, args ...interface{})
+
+// Rule checks a collection or a schema for one kind of problem.
+type Rule struct {
+	ID          string
+	Description string
+
+	// Severity is used when the configuration does not set one.
+	Severity Severity
+
+	// Check is set for collection rules.
+	Check func(c *resources.Collection, report Reporter)
+
+	// CheckSchema is set for schema rules.
+	CheckSchema func(doc *convert.OpenAPI, report Reporter)
+}
+
+var registry []*Rule
+
+// Register adds a rule to the rules run by Lint. Rules with the same ID
+// replace earlier ones.
+func Register(r *Rule) {
+	for i, existing := range registry {
+		if existing.ID == r.ID {
+			registry[i] = r
+			return
+		}
+	}
+
+	registry = append(registry, r)
+}
+
+// Rules returns the registered rules.
+func Rules() []*Rule {
+	rules := make([]*Rule, len(registry))
+	copy(rules, registry)
+
+	return rules
+}
+
+// Config sets the severity of rules by ID.
+type Config struct {
+	Rules map[string]Severity `yaml:"rules"`
+}
+
+// ReadConfig reads a YAML configuration such as:
+//
+//	rules:
+//	  request-tests: off
+//	  missing-description: warning
+func ReadConfig(r io.Reader) (*Config, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Config
+	if err := yaml.UnmarshalStrict(b, &c); err != nil {
+		return nil, err
+	}
+
+	for id := range c.Rules {
+		if find(id) == nil {
+			return nil, fmt.Errorf("unknown rule: %s", id)
+		}
+	}
+
+	return &c, nil
+}
+
+// Severity returns the severity of a rule.
+func (c *Config) Severity(r *Rule) Severity {
+	if c != nil {
+		if s, ok := c.Rules[r.ID]; ok {
+			return s
+		}
+	}
+
+	return r.Severity
+}
+
+// Lint runs the registered rules against a collection. Findings are sorted
+// by path, then by rule. cfg may be nil.
+func Lint(c *resources.Collection, cfg *Config) []Finding {
+	return run(cfg, func(r *Rule, report Reporter) {
+		if r.Check != nil {
+			r.Check(c, report)
+		}
+	})
+}
+
+// LintSchema runs the registered schema rules against an OpenAPI document.
+func LintSchema(doc *convert.OpenAPI, cfg *Config) []Finding {
+	return run(cfg, func(r *Rule, report Reporter) {
+		if r.CheckSchema != nil {
+			r.CheckSchema(doc, report)
+		}
+	})
+}
+
+func run(cfg *Config, check func(r *Rule, report Reporter)) []Finding {
+	var findings []Finding
+	for _, r := range registry {
+		severity := cfg.Severity(r)
+		if severity == Off {
+			continue
+		}
+
+		id := r.ID
+		check(r, func(path, format string, args ...interface{}) {
+			findings = append(findings, Finding{
+				Rule:     id,
+				Severity: severity,
+				Message:  fmt.Sprintf(format, args...),
+				Path:     path,
+			})
+		})
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Path != findings[j].Path {
+			return findings[i].Path < findings[j].Path
+		}
+		return findings[i].Rule < findings[j].Rule
+	})
+
+	return findings
+}
+
+func find(id string) *Rule {
+	for _, r := range registry {
+		if r.ID == id {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// Node is a folder or request visited by Walk.
+type Node struct {
+	// Path is the folder path and name, separated by slashes.
+	Path string
+
+	// Parents are the enclosing folders, outermost first.
+	Parents []*resources.ItemGroup
+
+	// Folder is set for folders, and for the collection itself with an
+	// empty Path.
+	Folder *resources.ItemTreeNode
+
+	// Item is set for requests.
+	Item *resources.Item
+}
+
+// Walk calls fn for the collection, then for each folder and request in
+// order.
+func Walk(c *resources.Collection, fn func(n *Node)) {
+	if c.Items == nil {
+		return
+	}
+
+	walk(&c.Items.Root, "", nil, fn)
+}
+
+func walk(t *resources.ItemTreeNode, path string, parents []*resources.ItemGroup, fn func(n *Node)) {
+	if t.ItemGroup != nil {
+		path = joinPath(path, t.ItemGroup.Name)
+	}
+
+	fn(&Node{Path: path, Parents: parents, Folder: t})
+
+	if t.ItemGroup != nil {
+		parents = append(parents[:len(parents):len(parents)], t.ItemGroup)
+	}
+
+	if t.Branches != nil {
+		for i := range *t.Branches {
+			walk(&(*t.Branches)[i], path, parents, fn)
+		}
+	}
+
+	if t.Items != nil {
+		for i := range *t.Items {
+			it := &(*t.Items)[i]
+			fn(&Node{Path: joinPath(path, it.Name), Parents: parents, Item: it})
+		}
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "/" + name
+}
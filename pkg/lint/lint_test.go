@@ -0,0 +1,195 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+	"github.com/kevinswiber/postmanctl/pkg/lint"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+const lintCollection = `{
+  "info": {"name": "Test", "description": "Users API", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+  "item": [
+    {
+      "name": "Users",
+      "description": "Users",
+      "protocolProfileBehavior": {"strictSSL": false},
+      "event": [{"listen": "test", "script": {"exec": ["pm.test('status', () => pm.response.to.be.ok);"]}}],
+      "item": [
+        {
+          "name": "Login",
+          "request": {
+            "method": "POST",
+            "url": "https://api.example.com/login",
+            "description": "Logs in",
+            "header": [{"key": "X-API-Key", "value": "abc123"}, {"key": "Authorization", "value": "Bearer {{token}}"}],
+            "auth": {"type": "basic", "basic": [{"key": "username", "value": "bob"}, {"key": "password", "value": "hunter2"}]}
+          }
+        },
+        {"name": "Login", "request": {"url": "{{baseUrl}}/login", "description": "Logs in again"}}
+      ]
+    },
+    {"name": "Health", "request": "{{baseUrl}}/health"}
+  ]
+}`
+
+func readLintCollection(t *testing.T) *resources.Collection {
+	var c resources.Collection
+	if err := json.Unmarshal([]byte(lintCollection), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	return &c
+}
+
+type finding struct {
+	rule, path string
+}
+
+func TestLint(t *testing.T) {
+	findings := lint.Lint(readLintCollection(t), nil)
+
+	have := make([]finding, len(findings))
+	for i, f := range findings {
+		have[i] = finding{f.Rule, f.Path}
+	}
+
+	expected := []finding{
+		{"missing-description", "Health"},
+		{"request-tests", "Health"},
+		{"ssl-verification", "Users"},
+		{"duplicate-name", "Users/Login"},
+		{"hardcoded-host", "Users/Login"},
+		{"plaintext-credentials", "Users/Login"},
+		{"plaintext-credentials", "Users/Login"},
+	}
+
+	if !reflect.DeepEqual(have, expected) {
+		t.Errorf("Findings are incorrect, have: %v, want: %v", have, expected)
+	}
+}
+
+func TestLintConfig(t *testing.T) {
+	cfg, err := lint.ReadConfig(strings.NewReader("rules:\n  missing-description: off\n  request-tests: error\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range lint.Lint(readLintCollection(t), cfg) {
+		if f.Rule == "missing-description" {
+			t.Errorf("Expected rule to be off, have: %v", f)
+		}
+		if f.Rule == "request-tests" && f.Severity != lint.Error {
+			t.Errorf("Severity is incorrect, have: %s, want: error", f.Severity)
+		}
+	}
+
+	if _, err := lint.ReadConfig(strings.NewReader("rules:\n  no-such-rule: error\n")); err == nil {
+		t.Error("Expected an error for an unknown rule")
+	}
+
+	if _, err := lint.ReadConfig(strings.NewReader("rules:\n  request-tests: fatal\n")); err == nil {
+		t.Error("Expected an error for an unknown severity")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	lint.Register(&lint.Rule{
+		ID:       "test-custom",
+		Severity: lint.Info,
+		Check: func(c *resources.Collection, report lint.Reporter) {
+			lint.Walk(c, func(n *lint.Node) {
+				if n.Item != nil && n.Item.Name == "Health" {
+					report(n.Path, "custom finding")
+				}
+			})
+		},
+	})
+
+	var found bool
+	for _, f := range lint.Lint(readLintCollection(t), nil) {
+		if f.Rule == "test-custom" && f.Path == "Health" && f.Message == "custom finding" {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Error("Expected a finding from the registered rule")
+	}
+}
+
+func TestSARIF(t *testing.T) {
+	log := lint.SARIF(lint.Lint(readLintCollection(t), nil), nil, "test.json")
+
+	results := log.Runs[0].Results
+	if len(results) == 0 {
+		t.Fatal("Expected results")
+	}
+
+	r := results[0]
+	if r.Level != "note" || r.Locations[0].PhysicalLocation.ArtifactLocation.URI != "test.json" ||
+		r.Locations[0].LogicalLocations[0].FullyQualifiedName != "/Health" {
+		t.Errorf("Result is incorrect: %+v", r)
+	}
+}
+
+func TestLintSchema(t *testing.T) {
+	doc, err := convert.ParseOpenAPI([]byte(`openapi: 3.0.0
+info: {title: Users, version: "1", description: Users API}
+servers: [{url: "https://api.example.com"}]
+paths:
+  /users/:
+    get:
+      operationId: listUsers
+      summary: List users
+      tags: [users]
+      responses: {"200": {description: ok}}
+  /users/{id}:
+    get:
+      operationId: listUsers
+      summary: Get a user
+      tags: [users]
+      parameters: [{name: userId, in: path, required: true}]
+      responses: {"404": {description: not found}}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var have []finding
+	for _, f := range lint.LintSchema(doc, nil) {
+		have = append(have, finding{f.Rule, f.Path})
+	}
+
+	expected := []finding{
+		{"path-trailing-slash", "/users/"},
+		{"operation-id-unique", "GET /users/{id}"},
+		{"operation-success-response", "GET /users/{id}"},
+		{"path-parameters", "GET /users/{id}"},
+		{"path-parameters", "GET /users/{id}"},
+	}
+
+	if !reflect.DeepEqual(have, expected) {
+		t.Errorf("Findings are incorrect, have: %v, want: %v", have, expected)
+	}
+}
@@ -0,0 +1,276 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+)
+
+func init() {
+	Register(&Rule{
+		ID:          "request-tests",
+		Description: "Requests should have a test script, or inherit one from a folder or the collection.",
+		Severity:    Warning,
+		Check:       checkRequestTests,
+	})
+	Register(&Rule{
+		ID:          "hardcoded-host",
+		Description: "Request URLs should use a variable for the host.",
+		Severity:    Warning,
+		Check:       checkHardcodedHost,
+	})
+	Register(&Rule{
+		ID:          "missing-description",
+		Description: "The collection, folders and requests should have a description.",
+		Severity:    Info,
+		Check:       checkMissingDescription,
+	})
+	Register(&Rule{
+		ID:          "duplicate-name",
+		Description: "Requests in the same folder should have different names.",
+		Severity:    Error,
+		Check:       checkDuplicateName,
+	})
+	Register(&Rule{
+		ID:          "plaintext-credentials",
+		Description: "Credentials in headers and auth should come from variables.",
+		Severity:    Error,
+		Check:       checkPlaintextCredentials,
+	})
+	Register(&Rule{
+		ID:          "ssl-verification",
+		Description: "SSL certificate verification should not be disabled.",
+		Severity:    Warning,
+		Check:       checkSSLVerification,
+	})
+}
+
+func checkRequestTests(c *resources.Collection, report Reporter) {
+	inherited := hasTest(c.Event)
+
+	Walk(c, func(n *Node) {
+		if n.Item == nil || inherited {
+			return
+		}
+
+		for _, p := range n.Parents {
+			if hasTest(p.Event) {
+				return
+			}
+		}
+
+		if !hasTest(n.Item.Event) {
+			report(n.Path, "request has no tests")
+		}
+	})
+}
+
+func checkHardcodedHost(c *resources.Collection, report Reporter) {
+	Walk(c, func(n *Node) {
+		if n.Item == nil || n.Item.Request == nil || n.Item.Request.URL == nil {
+			return
+		}
+
+		u := n.Item.Request.URL
+		host := strings.Join(u.Host, ".")
+		if host == "" && u.Raw != "" {
+			host = strings.Join(resources.ParseURL(u.Raw).Host, ".")
+		}
+
+		if host != "" && !strings.Contains(host, "{{") {
+			report(n.Path, "URL uses the hardcoded host %q instead of a variable", host)
+		}
+	})
+}
+
+func checkMissingDescription(c *resources.Collection, report Reporter) {
+	if c.Info == nil || isEmpty(c.Info.Description) {
+		report("", "collection has no description")
+	}
+
+	Walk(c, func(n *Node) {
+		switch {
+		case n.Folder != nil && n.Folder.ItemGroup != nil:
+			if isEmpty(n.Folder.ItemGroup.Description) {
+				report(n.Path, "folder has no description")
+			}
+		case n.Item != nil:
+			if isEmpty(n.Item.Description) && (n.Item.Request == nil || isEmpty(n.Item.Request.Description)) {
+				report(n.Path, "request has no description")
+			}
+		}
+	})
+}
+
+func checkDuplicateName(c *resources.Collection, report Reporter) {
+	Walk(c, func(n *Node) {
+		if n.Folder == nil || n.Folder.Items == nil {
+			return
+		}
+
+		seen := make(map[string]bool)
+		for _, it := range *n.Folder.Items {
+			if seen[it.Name] {
+				report(joinPath(n.Path, it.Name), "another request in the folder is named %q", it.Name)
+			}
+			seen[it.Name] = true
+		}
+	})
+}
+
+// secretHeaders are header names whose values are credentials.
+var secretHeaders = []string{"authorization", "cookie", "token", "secret", "password", "apikey", "api-key", "api_key"}
+
+// secretAttributes are the auth attributes that hold credentials, by type.
+var secretAttributes = map[string][]string{
+	"apikey": {"value"},
+	"awsv4":  {"secretKey", "sessionToken"},
+	"basic":  {"password"},
+	"bearer": {"token"},
+	"digest": {"password"},
+	"hawk":   {"authKey"},
+	"ntlm":   {"password"},
+	"oauth1": {"consumerSecret", "tokenSecret"},
+	"oauth2": {"accessToken", "clientSecret", "password", "refreshToken"},
+}
+
+func checkPlaintextCredentials(c *resources.Collection, report Reporter) {
+	checkAuth("", c.Auth, report)
+
+	Walk(c, func(n *Node) {
+		if n.Folder != nil && n.Folder.ItemGroup != nil {
+			checkAuth(n.Path, n.Folder.ItemGroup.Auth, report)
+		}
+
+		if n.Item == nil || n.Item.Request == nil {
+			return
+		}
+
+		for _, h := range n.Item.Request.Header {
+			if h.Disabled || !isLiteral(h.Value) {
+				continue
+			}
+
+			name := strings.ToLower(h.Key)
+			for _, s := range secretHeaders {
+				if strings.Contains(name, s) {
+					report(n.Path, "header %q has a plain-text value", h.Key)
+					break
+				}
+			}
+		}
+
+		if n.Item.Request.Auth != nil {
+			checkAuth(n.Path, n.Item.Request.Auth, report)
+		}
+	})
+}
+
+func checkAuth(path string, auth interface{}, report Reporter) {
+	if auth == nil {
+		return
+	}
+
+	b, err := json.Marshal(auth)
+	if err != nil {
+		return
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return
+	}
+
+	t, _ := m["type"].(string)
+	attrs, _ := m[t].([]interface{})
+	for _, key := range secretAttributes[t] {
+		for _, a := range attrs {
+			attr, _ := a.(map[string]interface{})
+			if attr["key"] != key {
+				continue
+			}
+			if v, ok := attr["value"].(string); ok && isLiteral(v) {
+				report(path, "%s auth has a plain-text %s", t, key)
+			}
+		}
+	}
+}
+
+func checkSSLVerification(c *resources.Collection, report Reporter) {
+	if strictSSLDisabled(c.ProtocolProfileBehavior) {
+		report("", "SSL certificate verification is disabled for the collection")
+	}
+
+	Walk(c, func(n *Node) {
+		var ppb map[string]interface{}
+		switch {
+		case n.Folder != nil && n.Folder.ItemGroup != nil:
+			ppb = n.Folder.ItemGroup.ProtocolProfileBehavior
+		case n.Item != nil:
+			ppb = n.Item.ProtocolProfileBehavior
+		}
+
+		if strictSSLDisabled(ppb) {
+			report(n.Path, "SSL certificate verification is disabled")
+		}
+	})
+}
+
+func strictSSLDisabled(ppb map[string]interface{}) bool {
+	v, ok := ppb["strictSSL"].(bool)
+	return ok && !v
+}
+
+func hasTest(events []*gen.Event) bool {
+	for _, e := range events {
+		if e != nil && !e.Disabled && e.Listen == "test" && e.Script != nil && !isEmpty(e.Script.Exec) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isEmpty reports whether a description or script is missing or blank.
+func isEmpty(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(t) == ""
+	case []interface{}:
+		for _, l := range t {
+			if !isEmpty(l) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		return isEmpty(t["content"])
+	}
+
+	return false
+}
+
+// isLiteral reports whether a value is set without using a variable.
+func isLiteral(s string) bool {
+	return strings.TrimSpace(s) != "" && !strings.Contains(s, "{{")
+}
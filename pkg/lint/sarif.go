@@ -0,0 +1,149 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+// SARIFLog is a Static Analysis Results Interchange Format (SARIF) 2.1.0
+// log, as read by code scanning tools.
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun is a single run of the linter.
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+// SARIFTool describes the linter and its rules.
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver describes the linter and its rules.
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+// SARIFRule describes a rule.
+type SARIFRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     SARIFMessage       `json:"shortDescription"`
+	DefaultConfiguration SARIFConfiguration `json:"defaultConfiguration"`
+}
+
+// SARIFConfiguration is the configured level of a rule.
+type SARIFConfiguration struct {
+	Level string `json:"level"`
+}
+
+// SARIFMessage is a plain-text message.
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFResult is a finding.
+type SARIFResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   SARIFMessage    `json:"message"`
+	Locations []SARIFLocation `json:"locations"`
+}
+
+// SARIFLocation locates a finding in the collection file, and by path in
+// the collection.
+type SARIFLocation struct {
+	PhysicalLocation *SARIFPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []SARIFLogicalLocation `json:"logicalLocations"`
+}
+
+// SARIFPhysicalLocation names the linted file.
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+}
+
+// SARIFArtifactLocation is the URI of the linted file.
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SARIFLogicalLocation is the path of a folder or request.
+type SARIFLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// SARIF converts findings to a SARIF log. uri is the linted file, and may be
+// empty for collections read from the Postman API.
+func SARIF(findings []Finding, cfg *Config, uri string) *SARIFLog {
+	driver := SARIFDriver{
+		Name:           "postmanctl",
+		InformationURI: "https://github.com/kevinswiber/postmanctl",
+		Rules:          []SARIFRule{},
+	}
+	for _, r := range registry {
+		driver.Rules = append(driver.Rules, SARIFRule{
+			ID:                   r.ID,
+			ShortDescription:     SARIFMessage{Text: r.Description},
+			DefaultConfiguration: SARIFConfiguration{Level: sarifLevel(cfg.Severity(r))},
+		})
+	}
+
+	results := make([]SARIFResult, len(findings))
+	for i, f := range findings {
+		location := SARIFLocation{
+			LogicalLocations: []SARIFLogicalLocation{{FullyQualifiedName: "/" + f.Path, Kind: "object"}},
+		}
+		if uri != "" {
+			location.PhysicalLocation = &SARIFPhysicalLocation{
+				ArtifactLocation: SARIFArtifactLocation{URI: uri},
+			}
+		}
+
+		results[i] = SARIFResult{
+			RuleID:    f.Rule,
+			Level:     sarifLevel(f.Severity),
+			Message:   SARIFMessage{Text: f.Message},
+			Locations: []SARIFLocation{location},
+		}
+	}
+
+	return &SARIFLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []SARIFRun{{
+			Tool:    SARIFTool{Driver: driver},
+			Results: results,
+		}},
+	}
+}
+
+func sarifLevel(s Severity) string {
+	switch s {
+	case Error:
+		return "error"
+	case Warning:
+		return "warning"
+	case Info:
+		return "note"
+	}
+
+	return "none"
+}
@@ -0,0 +1,232 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/convert"
+)
+
+func init() {
+	Register(&Rule{
+		ID:          "info-description",
+		Description: "The API should have a description.",
+		Severity:    Info,
+		CheckSchema: checkInfoDescription,
+	})
+	Register(&Rule{
+		ID:          "api-servers",
+		Description: "The API should define at least one server.",
+		Severity:    Warning,
+		CheckSchema: checkServers,
+	})
+	Register(&Rule{
+		ID:          "path-trailing-slash",
+		Description: "Paths should not end with a slash.",
+		Severity:    Warning,
+		CheckSchema: checkTrailingSlash,
+	})
+	Register(&Rule{
+		ID:          "path-parameters",
+		Description: "Path templates and path parameters should match.",
+		Severity:    Error,
+		CheckSchema: checkPathParameters,
+	})
+	Register(&Rule{
+		ID:          "operation-id",
+		Description: "Operations should have an operationId.",
+		Severity:    Warning,
+		CheckSchema: checkOperationID,
+	})
+	Register(&Rule{
+		ID:          "operation-id-unique",
+		Description: "Operation IDs should be unique.",
+		Severity:    Error,
+		CheckSchema: checkOperationIDUnique,
+	})
+	Register(&Rule{
+		ID:          "operation-description",
+		Description: "Operations should have a summary or description.",
+		Severity:    Info,
+		CheckSchema: checkOperationDescription,
+	})
+	Register(&Rule{
+		ID:          "operation-tags",
+		Description: "Operations should have at least one tag.",
+		Severity:    Warning,
+		CheckSchema: checkOperationTags,
+	})
+	Register(&Rule{
+		ID:          "operation-success-response",
+		Description: "Operations should define a 2xx or 3xx response.",
+		Severity:    Warning,
+		CheckSchema: checkSuccessResponse,
+	})
+}
+
+var pathTemplatePattern = regexp.MustCompile(`{([^{}]+)}`)
+
+func checkInfoDescription(doc *convert.OpenAPI, report Reporter) {
+	if strings.TrimSpace(doc.Info.Description) == "" {
+		report("", "info has no description")
+	}
+}
+
+func checkServers(doc *convert.OpenAPI, report Reporter) {
+	if len(doc.Servers) == 0 {
+		report("", "no servers are defined")
+	}
+}
+
+func checkTrailingSlash(doc *convert.OpenAPI, report Reporter) {
+	for _, p := range sortedPaths(doc) {
+		if len(p) > 1 && strings.HasSuffix(p, "/") {
+			report(p, "path ends with a slash")
+		}
+	}
+}
+
+func checkPathParameters(doc *convert.OpenAPI, report Reporter) {
+	for _, p := range sortedPaths(doc) {
+		item := doc.Paths[p]
+
+		var template []string
+		for _, m := range pathTemplatePattern.FindAllStringSubmatch(p, -1) {
+			template = append(template, m[1])
+		}
+
+		walkOperations(doc, p, func(location string, o *convert.Operation) {
+			declared := make(map[string]bool)
+			for _, params := range [][]*convert.Parameter{item.Parameters, o.Parameters} {
+				for _, param := range params {
+					if param, err := doc.ResolveParameter(param); err == nil && param.In == "path" {
+						declared[param.Name] = true
+					}
+				}
+			}
+
+			for _, name := range template {
+				if !declared[name] {
+					report(location, "path parameter %q is not declared", name)
+				}
+				delete(declared, name)
+			}
+
+			for _, name := range sortedKeys(declared) {
+				report(location, "path parameter %q is not in the path", name)
+			}
+		})
+	}
+}
+
+func checkOperationID(doc *convert.OpenAPI, report Reporter) {
+	walkAllOperations(doc, func(location string, o *convert.Operation) {
+		if o.OperationID == "" {
+			report(location, "operation has no operationId")
+		}
+	})
+}
+
+func checkOperationIDUnique(doc *convert.OpenAPI, report Reporter) {
+	seen := make(map[string]string)
+	walkAllOperations(doc, func(location string, o *convert.Operation) {
+		if o.OperationID == "" {
+			return
+		}
+
+		if first, ok := seen[o.OperationID]; ok {
+			report(location, "operationId %q is also used by %s", o.OperationID, first)
+			return
+		}
+		seen[o.OperationID] = location
+	})
+}
+
+func checkOperationDescription(doc *convert.OpenAPI, report Reporter) {
+	walkAllOperations(doc, func(location string, o *convert.Operation) {
+		if strings.TrimSpace(o.Summary) == "" && strings.TrimSpace(o.Description) == "" {
+			report(location, "operation has no summary or description")
+		}
+	})
+}
+
+func checkOperationTags(doc *convert.OpenAPI, report Reporter) {
+	walkAllOperations(doc, func(location string, o *convert.Operation) {
+		if len(o.Tags) == 0 {
+			report(location, "operation has no tags")
+		}
+	})
+}
+
+func checkSuccessResponse(doc *convert.OpenAPI, report Reporter) {
+	walkAllOperations(doc, func(location string, o *convert.Operation) {
+		for code := range o.Responses {
+			if strings.HasPrefix(code, "2") || strings.HasPrefix(code, "3") || code == "default" {
+				return
+			}
+		}
+		report(location, "operation has no success response")
+	})
+}
+
+// walkAllOperations calls fn for each operation, in path order.
+func walkAllOperations(doc *convert.OpenAPI, fn func(location string, o *convert.Operation)) {
+	for _, p := range sortedPaths(doc) {
+		walkOperations(doc, p, fn)
+	}
+}
+
+// walkOperations calls fn for each operation of a path, located as
+// "GET /users/{id}".
+func walkOperations(doc *convert.OpenAPI, path string, fn func(location string, o *convert.Operation)) {
+	ops := doc.Paths[path].Operations()
+
+	methods := make([]string, 0, len(ops))
+	for m := range ops {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+
+	for _, m := range methods {
+		fn(m+" "+path, ops[m])
+	}
+}
+
+func sortedPaths(doc *convert.OpenAPI) []string {
+	paths := make([]string, 0, len(doc.Paths))
+	for p, item := range doc.Paths {
+		if item != nil {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,174 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package names resolves the names of Postman resources to IDs, with an
+// on-disk cache of resource lists.
+package names
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Entry is the name and ID of a resource.
+type Entry struct {
+	Name string `json:"name"`
+	ID   string `json:"id"`
+}
+
+// Index maps the names of resources of one type to IDs.
+type Index struct {
+	Fetched time.Time `json:"fetched"`
+	Entries []Entry   `json:"entries"`
+}
+
+// NewIndex returns an index of list items, such as
+// resources.EnvironmentListItems. The ID of an item is its UID, or its ID
+// when it has no UID.
+func NewIndex(items interface{}) (*Index, error) {
+	b, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []struct {
+		ID   string `json:"id"`
+		UID  string `json:"uid"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(b, &list); err != nil {
+		return nil, err
+	}
+
+	ix := &Index{Fetched: time.Now(), Entries: make([]Entry, len(list))}
+	for i, item := range list {
+		id := item.UID
+		if id == "" {
+			id = item.ID
+		}
+		ix.Entries[i] = Entry{Name: item.Name, ID: id}
+	}
+
+	return ix, nil
+}
+
+// AmbiguousError is returned when several resources have the same name.
+type AmbiguousError struct {
+	Name       string
+	Candidates []Entry
+}
+
+func (e *AmbiguousError) Error() string {
+	ids := make([]string, len(e.Candidates))
+	for i, c := range e.Candidates {
+		ids[i] = c.ID
+	}
+
+	return fmt.Sprintf("name %q is ambiguous, use one of the IDs: %s", e.Name, strings.Join(ids, ", "))
+}
+
+// Lookup returns the ID of the resource with an ID or name. found is false
+// when no resource matches.
+func (ix *Index) Lookup(name string) (id string, found bool, err error) {
+	var matches []Entry
+	for _, e := range ix.Entries {
+		if e.ID == name {
+			return e.ID, true, nil
+		}
+		if e.Name == name {
+			matches = append(matches, e)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", false, nil
+	case 1:
+		return matches[0].ID, true, nil
+	default:
+		return "", false, &AmbiguousError{Name: name, Candidates: matches}
+	}
+}
+
+// Cache stores indexes in a directory. Indexes older than TTL are stale.
+type Cache struct {
+	Dir string
+	TTL time.Duration
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.Dir, url.PathEscape(key)+".json")
+}
+
+// Load returns the index stored with a key, unless it is missing or stale.
+func (c *Cache) Load(key string) (*Index, bool) {
+	if c.TTL <= 0 {
+		return nil, false
+	}
+
+	b, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var ix Index
+	if err := json.Unmarshal(b, &ix); err != nil || time.Since(ix.Fetched) > c.TTL {
+		return nil, false
+	}
+
+	return &ix, true
+}
+
+// Store writes an index with a key.
+func (c *Cache) Store(key string, ix *Index) error {
+	if c.TTL <= 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(c.Dir, 0700); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(ix)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(c.path(key), b, 0600)
+}
+
+// Forget removes the index stored with a key and those with keys starting
+// with key followed by a slash, such as "apis/1234" for "apis".
+func (c *Cache) Forget(key string) error {
+	paths, err := filepath.Glob(filepath.Join(c.Dir, url.PathEscape(key+"/")+"*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, p := range append(paths, c.path(key)) {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package names_test
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kevinswiber/postmanctl/pkg/names"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func TestIndexLookup(t *testing.T) {
+	ix, err := names.NewIndex(resources.EnvironmentListItems{
+		{ID: "1", UID: "u-1", Name: "staging"},
+		{ID: "2", UID: "u-2", Name: "qa"},
+		{ID: "3", UID: "u-3", Name: "qa"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		id    string
+		found bool
+	}{
+		{"staging", "u-1", true},
+		{"u-2", "u-2", true},
+		{"production", "", false},
+	}
+
+	for _, tt := range tests {
+		id, found, err := ix.Lookup(tt.name)
+		if err != nil || id != tt.id || found != tt.found {
+			t.Errorf("Lookup of %s is incorrect, have: %s, %t, %v", tt.name, id, found, err)
+		}
+	}
+
+	_, _, err = ix.Lookup("qa")
+	amb, ok := err.(*names.AmbiguousError)
+	if !ok {
+		t.Fatalf("Expected an ambiguous name error, have: %v", err)
+	}
+
+	expected := []names.Entry{{Name: "qa", ID: "u-2"}, {Name: "qa", ID: "u-3"}}
+	if !reflect.DeepEqual(amb.Candidates, expected) {
+		t.Errorf("Candidates are incorrect, have: %+v, want: %+v", amb.Candidates, expected)
+	}
+}
+
+func TestIndexWithoutUIDs(t *testing.T) {
+	ix, err := names.NewIndex(resources.APIListItems{{ID: "a-1", Name: "Orders"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id, found, _ := ix.Lookup("Orders"); !found || id != "a-1" {
+		t.Errorf("Expected the ID to be used, have: %s", id)
+	}
+}
+
+func TestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "names")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := &names.Cache{Dir: dir, TTL: time.Minute}
+	ix := &names.Index{Fetched: time.Now(), Entries: []names.Entry{{Name: "Orders", ID: "a-1"}}}
+
+	for _, key := range []string{"apis", "apis/workspace=w-1", "api-versions/a-1"} {
+		if err := c.Store(key, ix); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	have, ok := c.Load("apis/workspace=w-1")
+	if !ok || !reflect.DeepEqual(have.Entries, ix.Entries) {
+		t.Errorf("Loaded index is incorrect, have: %+v", have)
+	}
+
+	if err := c.Forget("apis"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := c.Load("apis"); ok {
+		t.Error("Expected apis to be forgotten")
+	}
+	if _, ok := c.Load("apis/workspace=w-1"); ok {
+		t.Error("Expected apis in a workspace to be forgotten")
+	}
+	if _, ok := c.Load("api-versions/a-1"); !ok {
+		t.Error("Expected API versions to be kept")
+	}
+
+	stale := &names.Index{Fetched: time.Now().Add(-time.Hour)}
+	if err := c.Store("mocks", stale); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Load("mocks"); ok {
+		t.Error("Expected a stale index to be ignored")
+	}
+}
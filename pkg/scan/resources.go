@@ -0,0 +1,141 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scan
+
+import (
+	"encoding/json"
+
+	"github.com/kevinswiber/postmanctl/pkg/lint"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+// Environment finds the secrets in the values of an environment.
+func Environment(env *resources.Environment) []Finding {
+	s := &scanner{resource: "environment", id: env.ID, name: env.Name}
+	for _, v := range env.Values {
+		s.value("values/"+v.Key, v.Key, v.Value)
+	}
+
+	return s.findings
+}
+
+// Collection finds the secrets in the variables, auth, headers, URLs and
+// bodies of a collection.
+func Collection(c *resources.Collection) []Finding {
+	s := &scanner{resource: "collection"}
+	if c.Info != nil {
+		s.id, s.name = c.Info.PostmanID, c.Info.Name
+	}
+
+	for _, v := range c.Variable {
+		if value, ok := v.Value.(string); ok {
+			s.value("variable/"+v.Key, v.Key, value)
+		}
+	}
+	s.auth("auth", c.Auth)
+
+	lint.Walk(c, func(n *lint.Node) {
+		if n.Folder != nil && n.Folder.ItemGroup != nil {
+			s.auth(n.Path+"/auth", n.Folder.ItemGroup.Auth)
+		}
+
+		if n.Item == nil || n.Item.Request == nil {
+			return
+		}
+
+		r := n.Item.Request
+		if r.URL != nil {
+			s.value(n.Path+"/url", "", r.URL.String())
+		}
+		for _, h := range r.Header {
+			s.value(n.Path+"/header/"+h.Key, h.Key, h.Value)
+		}
+		if r.Auth != nil {
+			s.auth(n.Path+"/auth", r.Auth)
+		}
+		if b := r.Body; b != nil {
+			s.value(n.Path+"/body", "", b.Raw)
+			for _, p := range b.URLEncoded {
+				s.value(n.Path+"/body/"+p.Key, p.Key, p.Value)
+			}
+			for _, p := range b.FormData {
+				s.value(n.Path+"/body/"+p.Key, p.Key, p.Value)
+			}
+		}
+	})
+
+	return s.findings
+}
+
+type scanner struct {
+	resource, id, name string
+	findings           []Finding
+}
+
+func (s *scanner) value(path, key, value string) {
+	for _, m := range Detect(key, value) {
+		s.findings = append(s.findings, Finding{
+			Resource:    s.resource,
+			ID:          s.id,
+			Name:        s.name,
+			Path:        path,
+			Rule:        m.Rule,
+			Value:       Mask(m.Secret),
+			Fingerprint: Fingerprint(m.Secret),
+		})
+	}
+}
+
+// auth scans the attributes of an auth helper, which may be a *gen.Auth or
+// decoded JSON.
+func (s *scanner) auth(path string, auth interface{}) {
+	if auth == nil {
+		return
+	}
+
+	b, err := json.Marshal(auth)
+	if err != nil {
+		return
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return
+	}
+
+	t, _ := m["type"].(string)
+	attrs, _ := m[t].([]interface{})
+	for _, a := range attrs {
+		attr, _ := a.(map[string]interface{})
+		key, _ := attr["key"].(string)
+		if value, ok := attr["value"].(string); ok {
+			s.value(path+"/"+t+"/"+key, key, value)
+		}
+	}
+}
+
+// Filter returns the findings that are not allowlisted.
+func (a *Allowlist) Filter(findings []Finding) []Finding {
+	var out []Finding
+	for i := range findings {
+		if !a.Allowed(&findings[i]) {
+			out = append(out, findings[i])
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,241 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scan finds secrets such as API keys and tokens in environments
+// and collections.
+package scan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Detector matches one kind of secret.
+type Detector struct {
+	Rule    string
+	Pattern *regexp.Regexp
+
+	// Group is the submatch holding the secret, or 0 for the whole match.
+	Group int
+}
+
+// Detectors are the well-known token patterns.
+var Detectors = []*Detector{
+	{Rule: "aws-access-key-id", Pattern: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{Rule: "github-token", Pattern: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{Rule: "slack-token", Pattern: regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`)},
+	{Rule: "private-key", Pattern: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+	{Rule: "jwt", Pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`)},
+	{Rule: "bearer-token", Pattern: regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9\-._~+/]{20,}=*)`), Group: 1},
+}
+
+// secretKeys are words in a key name that suggest its value is a secret.
+var secretKeys = []string{"key", "token", "secret", "password", "passwd", "auth", "credential", "signature"}
+
+// Entropy settings for values whose key names suggest a secret.
+const (
+	minEntropyLength = 16
+	minEntropy       = 3.5
+)
+
+// Match is a secret found in a value.
+type Match struct {
+	Rule   string
+	Secret string
+}
+
+// Detect finds the secrets in a value. key is the name the value is stored
+// under, such as a variable or header name, and may be empty. Values of
+// keys that suggest a secret are also checked for high entropy.
+func Detect(key, value string) []Match {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	var matches []Match
+	seen := make(map[string]bool)
+	for _, d := range Detectors {
+		for _, m := range d.Pattern.FindAllStringSubmatch(value, -1) {
+			secret := m[d.Group]
+			if strings.Contains(secret, "{{") || seen[secret] {
+				continue
+			}
+			seen[secret] = true
+			matches = append(matches, Match{Rule: d.Rule, Secret: secret})
+		}
+	}
+
+	if len(matches) == 0 && isSecretKey(key) {
+		v := strings.TrimSpace(value)
+		if len(v) >= minEntropyLength && !strings.ContainsAny(v, " \t\n") &&
+			!strings.Contains(v, "{{") && Entropy(v) >= minEntropy {
+			matches = append(matches, Match{Rule: "high-entropy", Secret: v})
+		}
+	}
+
+	return matches
+}
+
+// Entropy returns the Shannon entropy of s in bits per character.
+func Entropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	counts := make(map[rune]int)
+	n := 0
+	for _, r := range s {
+		counts[r]++
+		n++
+	}
+
+	var e float64
+	for _, c := range counts {
+		p := float64(c) / float64(n)
+		e -= p * math.Log2(p)
+	}
+
+	return e
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range secretKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Mask hides all but the ends of a secret.
+func Mask(s string) string {
+	if len(s) <= 12 {
+		return strings.Repeat("*", len(s))
+	}
+
+	return s[:4] + strings.Repeat("*", 8) + s[len(s)-4:]
+}
+
+// Fingerprint identifies a secret without revealing it, for use in an
+// allowlist.
+func Fingerprint(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:6])
+}
+
+// Finding is a secret found in a resource.
+type Finding struct {
+	// Resource is the resource type, "collection" or "environment".
+	Resource string `json:"resource"`
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name"`
+
+	// Path locates the value in the resource, such as
+	// "Users/Login/header/X-API-Key" or "values/apiKey".
+	Path        string `json:"path"`
+	Rule        string `json:"rule"`
+	Value       string `json:"value"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Location returns the resource type, name and path of the finding,
+// separated by slashes.
+func (f *Finding) Location() string {
+	return f.Resource + "/" + f.Name + "/" + f.Path
+}
+
+// Allowlist lists findings to ignore.
+type Allowlist struct {
+	// Fingerprints are the fingerprints of secrets to ignore.
+	Fingerprints []string `yaml:"fingerprints"`
+
+	// Rules are detectors to turn off.
+	Rules []string `yaml:"rules"`
+
+	// Paths are patterns matched against the location of a finding, where
+	// "*" matches any characters.
+	Paths []string `yaml:"paths"`
+
+	paths []*regexp.Regexp
+}
+
+// ReadAllowlist reads a YAML allowlist such as:
+//
+//	fingerprints:
+//	  - 9f86d081884c
+//	rules:
+//	  - high-entropy
+//	paths:
+//	  - environment/Local/*
+func ReadAllowlist(r io.Reader) (*Allowlist, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var a Allowlist
+	if err := yaml.UnmarshalStrict(b, &a); err != nil {
+		return nil, err
+	}
+
+	for _, p := range a.Paths {
+		parts := strings.Split(p, "*")
+		for i, part := range parts {
+			parts[i] = regexp.QuoteMeta(part)
+		}
+		a.paths = append(a.paths, regexp.MustCompile("^"+strings.Join(parts, ".*")+"$"))
+	}
+
+	return &a, nil
+}
+
+// Allowed reports whether a finding is allowlisted. A nil allowlist allows
+// nothing.
+func (a *Allowlist) Allowed(f *Finding) bool {
+	if a == nil {
+		return false
+	}
+
+	for _, fp := range a.Fingerprints {
+		if fp == f.Fingerprint {
+			return true
+		}
+	}
+
+	for _, r := range a.Rules {
+		if r == f.Rule {
+			return true
+		}
+	}
+
+	location := f.Location()
+	for _, p := range a.paths {
+		if p.MatchString(location) {
+			return true
+		}
+	}
+
+	return false
+}
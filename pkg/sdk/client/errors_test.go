@@ -0,0 +1,73 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+)
+
+func TestRequestErrorIs(t *testing.T) {
+	tests := []struct {
+		code     int
+		expected error
+	}{
+		{http.StatusNotFound, client.ErrNotFound},
+		{http.StatusUnauthorized, client.ErrUnauthorized},
+		{http.StatusForbidden, client.ErrUnauthorized},
+		{http.StatusTooManyRequests, client.ErrRateLimited},
+		{http.StatusConflict, client.ErrConflict},
+		{http.StatusBadRequest, client.ErrValidation},
+		{http.StatusInternalServerError, nil},
+	}
+	kinds := []error{client.ErrNotFound, client.ErrUnauthorized, client.ErrRateLimited, client.ErrConflict, client.ErrValidation}
+
+	for _, tt := range tests {
+		err := fmt.Errorf("wrapped: %w", client.NewRequestError(tt.code, "", "", nil))
+		for _, kind := range kinds {
+			if errors.Is(err, kind) != (kind == tt.expected) {
+				t.Errorf("%d: errors.Is(%v) is incorrect, want: %t", tt.code, kind, kind == tt.expected)
+			}
+		}
+
+		var reqErr *client.RequestError
+		if !errors.As(err, &reqErr) || reqErr.StatusCode != tt.code {
+			t.Errorf("%d: errors.As is incorrect, have: %v", tt.code, reqErr)
+		}
+	}
+}
+
+func TestDoNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"name":"instanceNotFoundError","message":"not found"}}`))
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	_, err := client.NewRequest(client.NewOptions(u, "", http.DefaultClient)).Get().Do()
+
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Error is incorrect, have: %v, want: %v", err, client.ErrNotFound)
+	}
+}
@@ -24,9 +24,10 @@ import (
 
 // Options allows for storing a base URL and containing common functionality.
 type Options struct {
-	base   *url.URL
-	APIKey string
-	Client *http.Client
+	base    *url.URL
+	APIKey  string
+	Client  *http.Client
+	Limiter RateLimiter
 }
 
 // NewOptions creates a new instance of the Postman API client options.
@@ -181,6 +181,12 @@ func (r *Request) URL() *url.URL {
 func (r *Request) Do() (*http.Response, error) {
 	url := r.URL().String()
 
+	if r.options.Limiter != nil {
+		if err := r.options.Limiter.Wait(r.ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	req, err := http.NewRequestWithContext(r.ctx, r.method, url, r.requestReader)
 	if err != nil {
 		return nil, err
@@ -596,3 +596,25 @@ func TestContext(t *testing.T) {
 		t.Errorf("Unexpected error, have: %s, want: context deadline exceeded", err)
 	}
 }
+
+func TestRateLimiter(t *testing.T) {
+	limiter := client.NewRateLimiter(2, 100*time.Millisecond)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("Requests were not delayed, elapsed: %s", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	limiter.Wait(ctx)
+	if err := limiter.Wait(ctx); err != context.Canceled {
+		t.Errorf("Error is incorrect, have: %v, want: %v", err, context.Canceled)
+	}
+}
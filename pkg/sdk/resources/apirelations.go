@@ -20,6 +20,22 @@ import (
 	"time"
 )
 
+func init() {
+	Register(&ResourceInfo{
+		Type:      APIRelationsType,
+		Kind:      "APIRelations",
+		Title:     "API relations",
+		Name:      "api-relations",
+		Plural:    "api-relations",
+		Path:      "apis/{apiID}/versions/{apiVersionID}/relations",
+		Singleton: true,
+		Parents:   []Parent{{Type: APIType, Param: "apiID"}, {Type: APIVersionType, Param: "apiVersionID"}},
+		Key:       "relations",
+		Item:      APIRelations{},
+		Verbs:     VerbGet,
+	})
+}
+
 // FormattedAPIRelationItems is a slice of FormattedAPIRelationItem
 type FormattedAPIRelationItems []FormattedAPIRelationItem
 
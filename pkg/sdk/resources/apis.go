@@ -18,6 +18,24 @@ package resources
 
 import "time"
 
+func init() {
+	Register(&ResourceInfo{
+		Type:    APIType,
+		Kind:    "API",
+		Title:   "API",
+		Name:    "api",
+		Plural:  "apis",
+		Path:    "apis",
+		Key:     "api",
+		ListKey: "apis",
+		Item:    API{},
+		List:    APIListItems{},
+		Verbs:   VerbList | VerbGet | VerbCreate | VerbReplace | VerbDelete,
+
+		WorkspaceFilter: true,
+	})
+}
+
 // APIListResponse represents the top-level APIs response from the Postman API.
 type APIListResponse struct {
 	APIs APIListItems `json:"apis"`
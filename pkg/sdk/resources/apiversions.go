@@ -18,6 +18,23 @@ package resources
 
 import "time"
 
+func init() {
+	Register(&ResourceInfo{
+		Type:    APIVersionType,
+		Kind:    "APIVersion",
+		Title:   "API version",
+		Name:    "api-version",
+		Plural:  "api-versions",
+		Path:    "apis/{apiID}/versions",
+		Parents: []Parent{{Type: APIType, Param: "apiID"}},
+		Key:     "version",
+		ListKey: "versions",
+		Item:    APIVersion{},
+		List:    APIVersionListItems{},
+		Verbs:   VerbList | VerbGet | VerbCreate | VerbReplace | VerbDelete,
+	})
+}
+
 // APIVersionListResponse represents the top-level API Versions response from the Postman API.
 type APIVersionListResponse struct {
 	APIVersions APIVersionListItems `json:"versions"`
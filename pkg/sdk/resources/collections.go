@@ -18,17 +18,39 @@ package resources
 
 import (
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
 )
 
+func init() {
+	Register(&ResourceInfo{
+		Type:    CollectionType,
+		Kind:    "Collection",
+		Title:   "collection",
+		Name:    "collection",
+		Plural:  "collections",
+		Aliases: []string{"co"},
+		Path:    "collections",
+		Key:     "collection",
+		ListKey: "collections",
+		Item:    Collection{},
+		List:    CollectionListItems{},
+		Verbs:   VerbList | VerbGet | VerbCreate | VerbReplace | VerbDelete,
+	})
+}
+
 //go:generate sh -c "schema-generate -p gen ../../../schema/collection.schema.json  | sed 's/Id/ID/g' > ./gen/collection.go"
 
 // Collection represents a Postman Collection.
 type Collection struct {
 	*gen.Collection
 	Items *ItemTree
+
+	// ProtocolProfileBehavior holds settings such as strictSSL, which the
+	// generated type does not keep.
+	ProtocolProfileBehavior map[string]interface{}
 }
 
 func (c Collection) String() string {
@@ -37,12 +59,22 @@ func (c Collection) String() string {
 
 // UnmarshalJSON converts JSON to a struct.
 func (c *Collection) UnmarshalJSON(b []byte) error {
+	var v1 struct {
+		Info     json.RawMessage `json:"info"`
+		Requests json.RawMessage `json:"requests"`
+	}
+	if err := json.Unmarshal(b, &v1); err == nil && v1.Info == nil && v1.Requests != nil {
+		return errors.New("collection is in Collection Format v1, convert it to v2.1 first")
+	}
+
 	var genC gen.Collection
 	if err := json.Unmarshal(b, &genC); err != nil {
 		return err
 	}
 
 	c.Collection = &genC
+	c.ProtocolProfileBehavior = protocolProfileBehavior(b)
+
 	node := ItemTreeNode{}
 	if err := populateItemGroup(&node, c.Collection.Item); err != nil {
 		return err
@@ -120,13 +152,17 @@ func (r CollectionSlice) Format() ([]string, []interface{}) {
 // Item represents an item (request) in a Collection.
 type Item struct {
 	*gen.Item
-	Events []Event
+	Request                 *Request
+	Responses               []*Response
+	Events                  []Event
+	ProtocolProfileBehavior map[string]interface{}
 }
 
 // ItemGroup represents a folder in a Collection.
 type ItemGroup struct {
 	*gen.ItemGroup
-	Events []Event
+	Events                  []Event
+	ProtocolProfileBehavior map[string]interface{}
 }
 
 // Event represents an item event.
@@ -142,6 +178,42 @@ func (item *Item) UnmarshalJSON(b []byte) error {
 	}
 
 	item.Item = &genItem
+	item.ProtocolProfileBehavior = protocolProfileBehavior(b)
+
+	// The request and responses are decoded from their own JSON, which
+	// they are marshaled back to when unchanged.
+	var raw struct {
+		Request  json.RawMessage   `json:"request"`
+		Response []json.RawMessage `json:"response"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	if genItem.Request != nil {
+		r := &Request{}
+		if err := json.Unmarshal(raw.Request, r); err != nil {
+			return err
+		}
+		if r.URL == nil {
+			r.URL = &URL{}
+		}
+		item.Request = r
+		genItem.Request = r
+	}
+
+	item.Responses = make([]*Response, len(genItem.Response))
+	for i, genResponse := range genItem.Response {
+		r := &Response{}
+		if err := json.Unmarshal(raw.Response[i], r); err != nil {
+			return err
+		}
+		item.Responses[i] = r
+		if r.OriginalRequest != nil {
+			genResponse.OriginalRequest = r.OriginalRequest
+		}
+	}
+
 	item.Events = make([]Event, len(item.Item.Event))
 
 	for i, genEvent := range item.Item.Event {
@@ -173,8 +245,10 @@ func populateItemGroup(b *ItemTreeNode, item []interface{}) error {
 					return err
 				}
 
+				ppb, _ := m["protocolProfileBehavior"].(map[string]interface{})
 				branch.MakeGroup(ItemGroup{
-					ItemGroup: &ig,
+					ItemGroup:               &ig,
+					ProtocolProfileBehavior: ppb,
 				})
 			}
 
@@ -210,3 +284,14 @@ func populateItem(name string, item map[string]interface{}) (*Item, error) {
 
 	return &it, nil
 }
+
+func protocolProfileBehavior(b []byte) map[string]interface{} {
+	var v struct {
+		ProtocolProfileBehavior map[string]interface{} `json:"protocolProfileBehavior"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil
+	}
+
+	return v.ProtocolProfileBehavior
+}
@@ -16,6 +16,23 @@ limitations under the License.
 
 package resources
 
+func init() {
+	Register(&ResourceInfo{
+		Type:    EnvironmentType,
+		Kind:    "Environment",
+		Title:   "environment",
+		Name:    "environment",
+		Plural:  "environments",
+		Aliases: []string{"env"},
+		Path:    "environments",
+		Key:     "environment",
+		ListKey: "environments",
+		Item:    Environment{},
+		List:    EnvironmentListItems{},
+		Verbs:   VerbList | VerbGet | VerbCreate | VerbReplace | VerbDelete,
+	})
+}
+
 // EnvironmentListResponse represents the top-level environments response from the
 // Postman API.
 type EnvironmentListResponse struct {
@@ -83,4 +100,40 @@ type KeyValuePair struct {
 	Key     string `json:"key"`
 	Value   string `json:"value"`
 	Enabled bool   `json:"enabled"`
+	Type    string `json:"type,omitempty"`
+}
+
+// Set adds a value, or replaces the value with the same key.
+func (r *Environment) Set(v KeyValuePair) {
+	for i := range r.Values {
+		if r.Values[i].Key == v.Key {
+			r.Values[i] = v
+			return
+		}
+	}
+
+	r.Values = append(r.Values, v)
+}
+
+// Unset removes the value with a key. It reports whether the key was found.
+func (r *Environment) Unset(key string) bool {
+	for i, v := range r.Values {
+		if v.Key == key {
+			r.Values = append(r.Values[:i], r.Values[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Value returns the value with a key.
+func (r *Environment) Value(key string) (KeyValuePair, bool) {
+	for _, v := range r.Values {
+		if v.Key == key {
+			return v, true
+		}
+	}
+
+	return KeyValuePair{}, false
 }
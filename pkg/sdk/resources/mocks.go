@@ -16,6 +16,22 @@ limitations under the License.
 
 package resources
 
+func init() {
+	Register(&ResourceInfo{
+		Type:    MockType,
+		Kind:    "Mock",
+		Title:   "mock",
+		Name:    "mock",
+		Plural:  "mocks",
+		Path:    "mocks",
+		Key:     "mock",
+		ListKey: "mocks",
+		Item:    Mock{},
+		List:    MockListItems{},
+		Verbs:   VerbList | VerbGet | VerbCreate | VerbReplace | VerbDelete,
+	})
+}
+
 // MockListResponse represents the top-level mocks response from the
 // Postman API.
 type MockListResponse struct {
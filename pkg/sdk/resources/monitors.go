@@ -22,6 +22,23 @@ import (
 	"time"
 )
 
+func init() {
+	Register(&ResourceInfo{
+		Type:    MonitorType,
+		Kind:    "Monitor",
+		Title:   "monitor",
+		Name:    "monitor",
+		Plural:  "monitors",
+		Aliases: []string{"mon"},
+		Path:    "monitors",
+		Key:     "monitor",
+		ListKey: "monitors",
+		Item:    Monitor{},
+		List:    MonitorListItems{},
+		Verbs:   VerbList | VerbGet | VerbCreate | VerbReplace | VerbDelete,
+	})
+}
+
 // MonitorListResponse represents the top-level monitors response from the
 // Postman API.
 type MonitorListResponse struct {
@@ -0,0 +1,167 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Lookup returns the resource type with a name, plural name or alias, such
+// as "collection", "collections" or "co".
+func Lookup(name string) (*ResourceInfo, bool) {
+	name = strings.ToLower(name)
+	for _, info := range Registered() {
+		if name == info.Name || name == info.Plural {
+			return info, true
+		}
+		for _, a := range info.Aliases {
+			if name == a {
+				return info, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// Ref addresses resources of a type. Name is an ID or name, or empty to
+// address all resources of the type.
+type Ref struct {
+	Info *ResourceInfo
+	Name string
+}
+
+// String returns the ref as type/name.
+func (r Ref) String() string {
+	if r.Name == "" {
+		return r.Info.Plural
+	}
+
+	return r.Info.Name + "/" + r.Name
+}
+
+// ParseRefs parses resource arguments in the style of kubectl. Arguments
+// are either type/name pairs, such as "co/Orders API" and "env/staging", a
+// comma separated list of types, such as "co,env,mon", or a single type
+// followed by names.
+func ParseRefs(args []string) ([]Ref, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no resources given, use type/name or a list of types such as co,env")
+	}
+
+	if !strings.Contains(args[0], "/") {
+		var infos []*ResourceInfo
+		for _, t := range strings.Split(args[0], ",") {
+			info, ok := Lookup(t)
+			if !ok {
+				return nil, fmt.Errorf("unknown resource type: %s", t)
+			}
+			infos = append(infos, info)
+		}
+
+		if len(args) == 1 {
+			refs := make([]Ref, len(infos))
+			for i, info := range infos {
+				refs[i] = Ref{Info: info}
+			}
+			return refs, nil
+		}
+
+		if len(infos) > 1 {
+			return nil, errors.New("names can only follow a single resource type")
+		}
+
+		refs := make([]Ref, len(args)-1)
+		for i, name := range args[1:] {
+			refs[i] = Ref{Info: infos[0], Name: name}
+		}
+		return refs, nil
+	}
+
+	refs := make([]Ref, len(args))
+	for i, arg := range args {
+		slash := strings.IndexByte(arg, '/')
+		if slash < 0 {
+			return nil, fmt.Errorf("expected type/name, have: %s", arg)
+		}
+
+		info, ok := Lookup(arg[:slash])
+		if !ok {
+			return nil, fmt.Errorf("unknown resource type: %s", arg[:slash])
+		}
+
+		name := arg[slash+1:]
+		if name == "" {
+			return nil, fmt.Errorf("missing name in %s", arg)
+		}
+
+		refs[i] = Ref{Info: info, Name: name}
+	}
+
+	return refs, nil
+}
+
+// TypedRow is a row of a table listing resources of several types.
+type TypedRow struct {
+	Type string
+	ID   string
+	Name string
+}
+
+// TypedRows is a table listing resources of several types.
+type TypedRows []TypedRow
+
+// Format returns column headers and values for the resource.
+func (r TypedRows) Format() ([]string, []interface{}) {
+	s := make([]interface{}, len(r))
+	for i, v := range r {
+		s[i] = v
+	}
+
+	return []string{"Type", "ID", "Name"}, s
+}
+
+// Append adds the rows of a formatted resource. The first column is taken
+// as the ID. Rows must be structs with a field named after that column.
+func (r TypedRows) Append(info *ResourceInfo, f Formatter) (TypedRows, error) {
+	cols, objs := f.Format()
+	for _, obj := range objs {
+		v := reflect.Indirect(reflect.ValueOf(obj))
+		if v.Kind() != reflect.Struct {
+			return r, fmt.Errorf("unable to list %s rows of type %T", info.Name, obj)
+		}
+
+		row := TypedRow{Type: info.Name}
+		if len(cols) > 0 {
+			id := v.FieldByName(cols[0])
+			if !id.IsValid() {
+				return r, fmt.Errorf("unable to list %s rows without a %s field", info.Name, cols[0])
+			}
+			row.ID = fmt.Sprint(id.Interface())
+		}
+		if name := v.FieldByName("Name"); name.IsValid() {
+			row.Name = fmt.Sprint(name.Interface())
+		}
+
+		r = append(r, row)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func TestParseRefs(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected []string
+	}{
+		{[]string{"co,env,mon"}, []string{"collections", "environments", "monitors"}},
+		{[]string{"Environments", "staging", "production"}, []string{"environment/staging", "environment/production"}},
+		{[]string{"co/Orders API", "env/staging", "mock/abc"}, []string{"collection/Orders API", "environment/staging", "mock/abc"}},
+		{[]string{"ws/a/b"}, []string{"workspace/a/b"}},
+	}
+
+	for _, tt := range tests {
+		refs, err := resources.ParseRefs(tt.args)
+		if err != nil {
+			t.Errorf("%v: %s", tt.args, err)
+			continue
+		}
+
+		have := make([]string, len(refs))
+		for i, r := range refs {
+			have[i] = r.String()
+		}
+
+		if !reflect.DeepEqual(have, tt.expected) {
+			t.Errorf("Refs are incorrect, have: %v, want: %v", have, tt.expected)
+		}
+	}
+
+	invalid := [][]string{
+		nil,
+		{"nope"},
+		{"co,env", "staging"},
+		{"co/a", "env"},
+		{"env/"},
+		{"nope/a"},
+	}
+
+	for _, args := range invalid {
+		if _, err := resources.ParseRefs(args); err == nil {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+}
+
+func TestTypedRowsAppend(t *testing.T) {
+	info, _ := resources.Lookup("env")
+
+	rows, err := resources.TypedRows{}.Append(info, resources.EnvironmentListItems{
+		{ID: "1", UID: "u-1", Name: "staging"},
+		{ID: "2", UID: "u-2", Name: "production"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := resources.TypedRows{
+		{Type: "environment", ID: "u-1", Name: "staging"},
+		{Type: "environment", ID: "u-2", Name: "production"},
+	}
+
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Rows are incorrect, have: %+v, want: %+v", rows, expected)
+	}
+
+	if _, err := (resources.TypedRows{}).Append(info, mapRows{{"ID": "1"}}); err == nil {
+		t.Error("Expected an error for rows that are not structs")
+	}
+}
+
+// mapRows is a formatter of rows that are not structs.
+type mapRows []map[string]string
+
+func (r mapRows) Format() ([]string, []interface{}) {
+	s := make([]interface{}, len(r))
+	for i, v := range r {
+		s[i] = v
+	}
+
+	return []string{"ID"}, s
+}
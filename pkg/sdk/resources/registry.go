@@ -0,0 +1,157 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Verb is a set of operations supported by a resource type.
+type Verb int
+
+// Verbs.
+const (
+	VerbList Verb = 1 << iota
+	VerbGet
+	VerbCreate
+	VerbReplace
+	VerbDelete
+)
+
+// Parent is a resource that scopes another, such as the API of an API
+// version.
+type Parent struct {
+	Type ResourceType
+	// Param is the URL parameter of the parent ID in path templates.
+	Param string
+}
+
+// ResourceInfo describes a resource type in the Postman API.
+type ResourceInfo struct {
+	Type ResourceType
+	// Kind is the Go-style type name, such as "APIVersion".
+	Kind string
+	// Title is the name shown to people, such as "API version".
+	Title string
+	// Name and Plural name the type on the command line, such as
+	// "api-version" and "api-versions".
+	Name    string
+	Plural  string
+	Aliases []string
+
+	// Path is the template of the resource collection path, such as
+	// "apis/{apiID}/versions". Single resources are at Path/{ID}, unless
+	// Singleton is set.
+	Path      string
+	Singleton bool
+	Parents   []Parent
+
+	// WorkspaceFilter is set when lists can be limited to a workspace with
+	// the workspace query parameter.
+	WorkspaceFilter bool
+
+	// Key and ListKey are the envelope keys of single resources and lists.
+	Key     string
+	ListKey string
+
+	// Item and List are zero values of the Go types decoded from single
+	// resources and lists.
+	Item interface{}
+	List interface{}
+
+	Verbs Verb
+}
+
+// Supports reports whether all of the verbs are supported.
+func (r *ResourceInfo) Supports(v Verb) bool {
+	return r.Verbs&v == v
+}
+
+// NewItem returns a pointer to a new value of the Item type.
+func (r *ResourceInfo) NewItem() interface{} {
+	return reflect.New(reflect.TypeOf(r.Item)).Interface()
+}
+
+// NewList returns a pointer to a new value of the List type.
+func (r *ResourceInfo) NewList() interface{} {
+	return reflect.New(reflect.TypeOf(r.List)).Interface()
+}
+
+// CollectionPath returns the path of the resource collection with the
+// parent IDs in params.
+func (r *ResourceInfo) CollectionPath(params map[string]string) ([]string, error) {
+	for _, p := range r.Parents {
+		if params[p.Param] == "" {
+			title := p.Param
+			if info, ok := Info(p.Type); ok {
+				title = info.Title
+			}
+			return nil, fmt.Errorf("missing %s ID for %s", title, r.Title)
+		}
+	}
+
+	segments := strings.Split(r.Path, "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			segments[i] = params[s[1:len(s)-1]]
+		}
+	}
+
+	return segments, nil
+}
+
+// ItemPath returns the path of a single resource.
+func (r *ResourceInfo) ItemPath(params map[string]string, id string) ([]string, error) {
+	path, err := r.CollectionPath(params)
+	if err != nil || r.Singleton {
+		return path, err
+	}
+
+	if id == "" {
+		return nil, fmt.Errorf("missing %s ID", r.Title)
+	}
+
+	return append(path, id), nil
+}
+
+var registry = make(map[ResourceType]*ResourceInfo)
+
+// Register adds a resource type to the registry, replacing any with the
+// same type.
+func Register(info *ResourceInfo) {
+	registry[info.Type] = info
+}
+
+// Info returns the description of a resource type.
+func Info(t ResourceType) (*ResourceInfo, bool) {
+	info, ok := registry[t]
+	return info, ok
+}
+
+// Registered returns the registered resource types in type order.
+func Registered() []*ResourceInfo {
+	infos := make([]*ResourceInfo, 0, len(registry))
+	for _, info := range registry {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Type < infos[j].Type })
+
+	return infos
+}
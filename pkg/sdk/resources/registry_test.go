@@ -0,0 +1,75 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func TestRegistry(t *testing.T) {
+	infos := resources.Registered()
+	if len(infos) != 10 {
+		t.Fatalf("Registered type count is incorrect, have: %d, want: 10", len(infos))
+	}
+
+	for i, info := range infos {
+		if int(info.Type) != i || info.Type.String() != info.Kind {
+			t.Errorf("Registration is incorrect: %+v", info)
+		}
+		if info.Supports(resources.VerbList) && info.List == nil {
+			t.Errorf("%s supports list without a list type", info.Kind)
+		}
+	}
+
+	if have := resources.ResourceType(99).String(); have != "ResourceType(99)" {
+		t.Errorf("String is incorrect, have: %s", have)
+	}
+}
+
+func TestResourceInfoPaths(t *testing.T) {
+	info, ok := resources.Info(resources.SchemaType)
+	if !ok {
+		t.Fatal("Expected schemas to be registered")
+	}
+
+	path, err := info.ItemPath(map[string]string{"apiID": "a", "apiVersionID": "v"}, "s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"apis", "a", "versions", "v", "schemas", "s"}; !reflect.DeepEqual(path, expected) {
+		t.Errorf("Path is incorrect, have: %v, want: %v", path, expected)
+	}
+
+	_, err = info.CollectionPath(map[string]string{"apiID": "a"})
+	if err == nil || !strings.Contains(err.Error(), "API version") {
+		t.Errorf("Expected a missing API version error, have: %v", err)
+	}
+
+	user, _ := resources.Info(resources.UserType)
+	if path, err := user.ItemPath(nil, ""); err != nil || !reflect.DeepEqual(path, []string{"me"}) {
+		t.Errorf("Singleton path is incorrect, have: %v, %v", path, err)
+	}
+
+	if _, ok := info.NewItem().(*resources.Schema); !ok {
+		t.Errorf("NewItem type is incorrect, have: %T", info.NewItem())
+	}
+}
@@ -0,0 +1,689 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+)
+
+// Request represents a request in a Collection. The schema allows a request
+// to be a plain URL string; such requests marshal back to a string as long
+// as only the URL is set. Decoded requests marshal back to the JSON they were
+// decoded from, with the parts that were not changed in their original form.
+type Request struct {
+	Method      string      `json:"method,omitempty"`
+	URL         *URL        `json:"url,omitempty"`
+	Header      []*KeyValue `json:"header,omitempty"`
+	Body        *Body       `json:"body,omitempty"`
+	Auth        *gen.Auth   `json:"auth,omitempty"`
+	Description interface{} `json:"description,omitempty"`
+	Proxy       interface{} `json:"proxy,omitempty"`
+	Certificate interface{} `json:"certificate,omitempty"`
+
+	shorthand     bool
+	defaultMethod bool
+	// method is the method as it was decoded, before it was uppercased.
+	method   string
+	header   original
+	original original
+}
+
+type requestAlias Request
+
+// UnmarshalJSON converts JSON to a struct, accepting the string shorthand.
+// The method defaults to GET.
+func (r *Request) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*r = Request{Method: "GET", URL: shorthandURL(s), shorthand: true}
+		return nil
+	}
+
+	var v struct {
+		requestAlias
+		Header json.RawMessage `json:"header,omitempty"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*r = Request(v.requestAlias)
+	r.method = r.Method
+	if r.Method == "" {
+		r.Method = "GET"
+		r.defaultMethod = true
+	}
+	r.Method = strings.ToUpper(r.Method)
+
+	headers, err := unmarshalHeaders(v.Header)
+	if err != nil {
+		return err
+	}
+	r.Header = headers
+	r.header = keepOriginal(v.Header, r.Header)
+
+	if r.URL == nil {
+		r.URL = &URL{}
+	}
+
+	r.original = keepOriginal(b, (*requestJSON)(r))
+
+	return nil
+}
+
+// MarshalJSON converts a struct to JSON.
+func (r *Request) MarshalJSON() ([]byte, error) {
+	return r.original.marshal((*requestJSON)(r))
+}
+
+// requestJSON marshals a Request without its original JSON.
+type requestJSON Request
+
+func (r *requestJSON) MarshalJSON() ([]byte, error) {
+	if r.shorthand && r.URL != nil && r.URL.compact() && r.Method == "GET" && len(r.Header) == 0 && r.Body == nil &&
+		r.Auth == nil && r.Description == nil && r.Proxy == nil && r.Certificate == nil {
+		return json.Marshal(r.URL)
+	}
+
+	method := r.Method
+	if strings.EqualFold(r.method, method) {
+		method = r.method
+	} else if r.defaultMethod && method == "GET" {
+		method = ""
+	}
+
+	url := r.URL
+	if url != nil && url.Raw == "" && len(url.Host) == 0 && len(url.Path) == 0 {
+		url = nil
+	}
+
+	header, err := marshalField(r.header, r.Header, len(r.Header) == 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(struct {
+		Method      string          `json:"method,omitempty"`
+		URL         *URL            `json:"url,omitempty"`
+		Header      json.RawMessage `json:"header,omitempty"`
+		Body        *Body           `json:"body,omitempty"`
+		Auth        *gen.Auth       `json:"auth,omitempty"`
+		Description interface{}     `json:"description,omitempty"`
+		Proxy       interface{}     `json:"proxy,omitempty"`
+		Certificate interface{}     `json:"certificate,omitempty"`
+	}{method, url, header, r.Body, r.Auth, r.Description, r.Proxy, r.Certificate})
+}
+
+// URL represents the URL of a Request. Host and Path are split into
+// segments, e.g. ["{{baseUrl}}"] and ["users", ":id"].
+type URL struct {
+	Raw      string          `json:"raw,omitempty"`
+	Protocol string          `json:"protocol,omitempty"`
+	Host     []string        `json:"host,omitempty"`
+	Port     string          `json:"port,omitempty"`
+	Path     []string        `json:"path,omitempty"`
+	Query    []*KeyValue     `json:"query,omitempty"`
+	Hash     string          `json:"hash,omitempty"`
+	Variable []*gen.Variable `json:"variable,omitempty"`
+
+	shorthand bool
+	// parsed is set when the parts were derived from Raw while decoding,
+	// and holds the query as it was decoded. derived holds those parts, so
+	// that the compact forms are only written while they are unchanged.
+	parsed   *[]*KeyValue
+	derived  []byte
+	host     original
+	path     original
+	original original
+}
+
+type urlAlias URL
+
+// UnmarshalJSON converts JSON to a struct, accepting the string shorthand
+// and the string forms of host and path.
+func (u *URL) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*u = *shorthandURL(s)
+		return nil
+	}
+
+	var v struct {
+		urlAlias
+		Host json.RawMessage `json:"host"`
+		Path json.RawMessage `json:"path"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*u = URL(v.urlAlias)
+
+	if len(v.Host) == 0 && len(v.Path) == 0 && u.Raw != "" {
+		query := u.Query
+		parsed := ParseURL(u.Raw)
+		parsed.Variable = u.Variable
+		if query != nil {
+			parsed.Query = query
+		}
+		parsed.parsed = &query
+		*u = *parsed
+		u.derived = (*urlJSON)(u).parts()
+		u.original = keepOriginal(b, (*urlJSON)(u))
+		return nil
+	}
+
+	var err error
+	if u.Host, err = stringOrSlice(v.Host, "."); err != nil {
+		return err
+	}
+	if u.Path, err = stringOrSlice(v.Path, "/"); err != nil {
+		return err
+	}
+	u.host = keepOriginal(v.Host, u.Host)
+	u.path = keepOriginal(v.Path, u.Path)
+	u.original = keepOriginal(b, (*urlJSON)(u))
+
+	return nil
+}
+
+// MarshalJSON converts a struct to JSON.
+func (u *URL) MarshalJSON() ([]byte, error) {
+	return u.original.marshal((*urlJSON)(u))
+}
+
+// urlJSON marshals a URL without its original JSON.
+type urlJSON URL
+
+func (u *urlJSON) MarshalJSON() ([]byte, error) {
+	if (*URL)(u).compact() {
+		return json.Marshal((*URL)(u).String())
+	}
+
+	if u.parsed != nil && u.Raw != "" && u.derivedParts() {
+		return json.Marshal(&urlAlias{Raw: u.Raw, Query: *u.parsed, Variable: u.Variable})
+	}
+
+	host, err := marshalField(u.host, u.Host, len(u.Host) == 0)
+	if err != nil {
+		return nil, err
+	}
+	path, err := marshalField(u.path, u.Path, len(u.Path) == 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(struct {
+		Raw      string          `json:"raw,omitempty"`
+		Protocol string          `json:"protocol,omitempty"`
+		Host     json.RawMessage `json:"host,omitempty"`
+		Port     string          `json:"port,omitempty"`
+		Path     json.RawMessage `json:"path,omitempty"`
+		Query    []*KeyValue     `json:"query,omitempty"`
+		Hash     string          `json:"hash,omitempty"`
+		Variable []*gen.Variable `json:"variable,omitempty"`
+	}{u.Raw, u.Protocol, host, u.Port, path, u.Query, u.Hash, u.Variable})
+}
+
+// shorthandURL returns the URL of a string shorthand.
+func shorthandURL(raw string) *URL {
+	u := ParseURL(raw)
+	u.shorthand = true
+	u.derived = (*urlJSON)(u).parts()
+
+	return u
+}
+
+// compact reports whether a URL decoded from a string is written as one,
+// which is the case while its parts are unchanged.
+func (u *URL) compact() bool {
+	return u.shorthand && (*urlJSON)(u).derivedParts()
+}
+
+// derivedParts reports whether the parts of a URL are still those derived
+// from Raw while decoding.
+func (u *urlJSON) derivedParts() bool {
+	return u.derived != nil && bytes.Equal(u.parts(), u.derived)
+}
+
+// parts returns the JSON of the parts of a URL that can be derived from Raw.
+func (u *urlJSON) parts() []byte {
+	b, _ := json.Marshal(struct {
+		Protocol string
+		Host     []string
+		Port     string
+		Path     []string
+		Query    []*KeyValue
+		Hash     string
+	}{u.Protocol, u.Host, u.Port, u.Path, u.Query, u.Hash})
+
+	return b
+}
+
+// String returns the URL in its raw form, building it from its parts when
+// no raw form is set.
+func (u *URL) String() string {
+	if u.Raw != "" {
+		return u.Raw
+	}
+
+	var b strings.Builder
+	if u.Protocol != "" {
+		b.WriteString(u.Protocol + "://")
+	}
+	b.WriteString(strings.Join(u.Host, "."))
+	if u.Port != "" {
+		b.WriteString(":" + u.Port)
+	}
+	if len(u.Path) > 0 {
+		b.WriteString("/" + strings.Join(u.Path, "/"))
+	}
+
+	var query []string
+	for _, q := range u.Query {
+		if q.Disabled {
+			continue
+		}
+		query = append(query, q.Key+"="+q.Value)
+	}
+	if len(query) > 0 {
+		b.WriteString("?" + strings.Join(query, "&"))
+	}
+	if u.Hash != "" {
+		b.WriteString("#" + u.Hash)
+	}
+
+	return b.String()
+}
+
+// ParseURL splits a raw URL such as {{baseUrl}}/users/:id?page=1 into its
+// parts. Variables are kept as they are.
+func ParseURL(raw string) *URL {
+	u := &URL{Raw: raw}
+
+	rest := raw
+	if i := strings.Index(rest, "#"); i >= 0 {
+		u.Hash = rest[i+1:]
+		rest = rest[:i]
+	}
+	if i := strings.Index(rest, "?"); i >= 0 {
+		for _, pair := range strings.Split(rest[i+1:], "&") {
+			if pair == "" {
+				continue
+			}
+			kv := strings.SplitN(pair, "=", 2)
+			q := &KeyValue{Key: kv[0]}
+			if len(kv) > 1 {
+				q.Value = kv[1]
+			}
+			u.Query = append(u.Query, q)
+		}
+		rest = rest[:i]
+	}
+
+	if i := strings.Index(rest, "://"); i >= 0 {
+		u.Protocol = rest[:i]
+		rest = rest[i+3:]
+	}
+
+	segments := strings.Split(rest, "/")
+	host := segments[0]
+	if i := strings.LastIndex(host, ":"); i >= 0 && !strings.Contains(host[i:], "}}") {
+		u.Port = host[i+1:]
+		host = host[:i]
+	}
+	if host != "" {
+		u.Host = strings.Split(host, ".")
+	}
+
+	u.Path = append(u.Path, segments[1:]...)
+
+	return u
+}
+
+// KeyValue represents a header, query parameter or form parameter.
+type KeyValue struct {
+	Key         string      `json:"key"`
+	Value       string      `json:"value"`
+	Type        string      `json:"type,omitempty"`
+	Src         interface{} `json:"src,omitempty"`
+	ContentType string      `json:"contentType,omitempty"`
+	Disabled    bool        `json:"disabled,omitempty"`
+	Description interface{} `json:"description,omitempty"`
+
+	original original
+}
+
+type keyValueAlias KeyValue
+
+// UnmarshalJSON converts JSON to a struct, tolerating non-string values.
+func (kv *KeyValue) UnmarshalJSON(b []byte) error {
+	var v struct {
+		keyValueAlias
+		Key   interface{} `json:"key"`
+		Value interface{} `json:"value"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*kv = KeyValue(v.keyValueAlias)
+	kv.Key = valueString(v.Key)
+	kv.Value = valueString(v.Value)
+	kv.original = keepOriginal(b, (*keyValueAlias)(kv))
+
+	return nil
+}
+
+// MarshalJSON converts a struct to JSON, keeping non-string values that
+// were not changed.
+func (kv *KeyValue) MarshalJSON() ([]byte, error) {
+	return kv.original.marshal((*keyValueAlias)(kv))
+}
+
+// Body represents the body of a Request. Mode is one of raw, urlencoded,
+// formdata, file or graphql.
+type Body struct {
+	Mode       string                 `json:"mode,omitempty"`
+	Raw        string                 `json:"raw,omitempty"`
+	URLEncoded []*KeyValue            `json:"urlencoded,omitempty"`
+	FormData   []*KeyValue            `json:"formdata,omitempty"`
+	File       map[string]interface{} `json:"file,omitempty"`
+	GraphQL    map[string]interface{} `json:"graphql,omitempty"`
+	Options    map[string]interface{} `json:"options,omitempty"`
+	Disabled   bool                   `json:"disabled,omitempty"`
+}
+
+// Language returns the language hint of a raw body, e.g. json or xml.
+func (b *Body) Language() string {
+	raw, ok := b.Options["raw"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	l, _ := raw["language"].(string)
+	return l
+}
+
+// Response represents a saved example response of an Item.
+type Response struct {
+	ID              string        `json:"id,omitempty"`
+	Name            string        `json:"name,omitempty"`
+	OriginalRequest *Request      `json:"originalRequest,omitempty"`
+	Status          string        `json:"status,omitempty"`
+	Code            int           `json:"code,omitempty"`
+	PreviewLanguage string        `json:"_postman_previewlanguage,omitempty"`
+	Header          []*KeyValue   `json:"header,omitempty"`
+	Cookie          []*gen.Cookie `json:"cookie,omitempty"`
+	Body            string        `json:"body,omitempty"`
+	ResponseTime    interface{}   `json:"responseTime,omitempty"`
+	Timings         interface{}   `json:"timings,omitempty"`
+
+	header   original
+	body     original
+	original original
+}
+
+type responseAlias Response
+
+// UnmarshalJSON converts JSON to a struct, accepting headers in their
+// string form and bodies of any type.
+func (r *Response) UnmarshalJSON(b []byte) error {
+	var v struct {
+		responseAlias
+		Header json.RawMessage `json:"header,omitempty"`
+		Body   json.RawMessage `json:"body"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*r = Response(v.responseAlias)
+
+	var body interface{}
+	if len(v.Body) > 0 {
+		if err := json.Unmarshal(v.Body, &body); err != nil {
+			return err
+		}
+	}
+	r.Body = valueString(body)
+	r.body = keepOriginal(v.Body, r.Body)
+
+	headers, err := unmarshalHeaders(v.Header)
+	if err != nil {
+		return err
+	}
+	r.Header = headers
+	r.header = keepOriginal(v.Header, r.Header)
+	r.original = keepOriginal(b, (*responseJSON)(r))
+
+	return nil
+}
+
+// MarshalJSON converts a struct to JSON.
+func (r *Response) MarshalJSON() ([]byte, error) {
+	return r.original.marshal((*responseJSON)(r))
+}
+
+// responseJSON marshals a Response without its original JSON.
+type responseJSON Response
+
+func (r *responseJSON) MarshalJSON() ([]byte, error) {
+	header, err := marshalField(r.header, r.Header, len(r.Header) == 0)
+	if err != nil {
+		return nil, err
+	}
+	body, err := marshalField(r.body, r.Body, r.Body == "")
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(struct {
+		ID              string          `json:"id,omitempty"`
+		Name            string          `json:"name,omitempty"`
+		OriginalRequest *Request        `json:"originalRequest,omitempty"`
+		Status          string          `json:"status,omitempty"`
+		Code            int             `json:"code,omitempty"`
+		PreviewLanguage string          `json:"_postman_previewlanguage,omitempty"`
+		Header          json.RawMessage `json:"header,omitempty"`
+		Cookie          []*gen.Cookie   `json:"cookie,omitempty"`
+		Body            json.RawMessage `json:"body,omitempty"`
+		ResponseTime    interface{}     `json:"responseTime,omitempty"`
+		Timings         interface{}     `json:"timings,omitempty"`
+	}{r.ID, r.Name, r.OriginalRequest, r.Status, r.Code, r.PreviewLanguage, header, r.Cookie, body, r.ResponseTime, r.Timings})
+}
+
+// DecodeRequest converts the untyped request of a gen.Item. The URL of the
+// returned request is never nil.
+func DecodeRequest(v interface{}) (*Request, error) {
+	if r, ok := v.(*Request); ok {
+		return r, nil
+	}
+
+	var r Request
+	if err := remarshal(v, &r); err != nil {
+		return nil, err
+	}
+
+	if r.URL == nil {
+		r.URL = &URL{}
+	}
+
+	return &r, nil
+}
+
+// DecodeResponse converts a gen.Response.
+func DecodeResponse(v *gen.Response) (*Response, error) {
+	var r Response
+	if err := remarshal(v, &r); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// original is the JSON a value was decoded from, kept with the JSON the
+// decoded value marshals to. While the value still marshals to the same
+// JSON, it is marshaled in its original form instead.
+type original struct {
+	raw        json.RawMessage
+	normalized []byte
+}
+
+// keepOriginal returns the original form raw of the decoded value v.
+func keepOriginal(raw []byte, v interface{}) original {
+	if len(raw) == 0 {
+		return original{}
+	}
+
+	normalized, err := json.Marshal(v)
+	if err != nil {
+		return original{}
+	}
+
+	return original{raw: append(json.RawMessage(nil), raw...), normalized: normalized}
+}
+
+// marshal returns the JSON of v, or the original JSON when v is unchanged.
+func (o original) marshal(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || o.raw == nil || !bytes.Equal(b, o.normalized) {
+		return b, err
+	}
+
+	return o.raw, nil
+}
+
+// marshalField marshals a field like original.marshal. Empty fields are
+// left out unless they are unchanged.
+func marshalField(o original, v interface{}, empty bool) (json.RawMessage, error) {
+	b, err := o.marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if empty && (o.raw == nil || !bytes.Equal(b, o.raw)) {
+		return nil, nil
+	}
+
+	return b, nil
+}
+
+func remarshal(in, out interface{}) error {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, out)
+}
+
+// unmarshalHeaders accepts a list of headers, which may include strings, or
+// a string of "Key: Value" lines.
+func unmarshalHeaders(b json.RawMessage) ([]*KeyValue, error) {
+	if len(b) == 0 || string(b) == "null" {
+		return nil, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		return parseHeaderLines(strings.Split(s, "\n")), nil
+	}
+
+	var list []json.RawMessage
+	if err := json.Unmarshal(b, &list); err != nil {
+		return nil, err
+	}
+
+	headers := make([]*KeyValue, 0, len(list))
+	for _, e := range list {
+		if err := json.Unmarshal(e, &s); err == nil {
+			headers = append(headers, parseHeaderLines([]string{s})...)
+			continue
+		}
+
+		var h KeyValue
+		if err := json.Unmarshal(e, &h); err != nil {
+			return nil, err
+		}
+		headers = append(headers, &h)
+	}
+
+	return headers, nil
+}
+
+func parseHeaderLines(lines []string) []*KeyValue {
+	var headers []*KeyValue
+	for _, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		kv := strings.SplitN(l, ":", 2)
+		h := &KeyValue{Key: strings.TrimSpace(kv[0])}
+		if len(kv) > 1 {
+			h.Value = strings.TrimSpace(kv[1])
+		}
+		headers = append(headers, h)
+	}
+
+	return headers
+}
+
+func stringOrSlice(b json.RawMessage, sep string) ([]string, error) {
+	if len(b) == 0 || string(b) == "null" {
+		return nil, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		return strings.Split(strings.TrimPrefix(s, sep), sep), nil
+	}
+
+	var list []interface{}
+	if err := json.Unmarshal(b, &list); err != nil {
+		return nil, err
+	}
+
+	r := make([]string, 0, len(list))
+	for _, v := range list {
+		// Path segments may also be objects of the form {"type", "value"}.
+		if m, ok := v.(map[string]interface{}); ok {
+			v = m["value"]
+		}
+		r = append(r, valueString(v))
+	}
+
+	return r, nil
+}
+
+func valueString(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	default:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+}
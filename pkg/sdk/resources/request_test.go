@@ -0,0 +1,348 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func TestRequestShorthandRoundTrip(t *testing.T) {
+	in := `"https://{{host}}:8080/users/:id?page=1#top"`
+
+	var r resources.Request
+	if err := json.Unmarshal([]byte(in), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Method != "GET" {
+		t.Errorf("expected method GET, got %s", r.Method)
+	}
+	if !reflect.DeepEqual(r.URL.Host, []string{"{{host}}"}) || r.URL.Port != "8080" {
+		t.Errorf("unexpected host %v and port %s", r.URL.Host, r.URL.Port)
+	}
+	if !reflect.DeepEqual(r.URL.Path, []string{"users", ":id"}) {
+		t.Errorf("unexpected path %v", r.URL.Path)
+	}
+	if len(r.URL.Query) != 1 || r.URL.Query[0].Key != "page" || r.URL.Query[0].Value != "1" {
+		t.Errorf("unexpected query %v", r.URL.Query)
+	}
+
+	out, err := json.Marshal(&r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != in {
+		t.Errorf("expected %s, got %s", in, out)
+	}
+
+	r.Method = "DELETE"
+	out, err = json.Marshal(&r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"method":"DELETE","url":"https://{{host}}:8080/users/:id?page=1#top"}`
+	if string(out) != expected {
+		t.Errorf("expected %s, got %s", expected, out)
+	}
+}
+
+func TestRequestObjectRoundTrip(t *testing.T) {
+	in := `{"url":{"raw":"{{baseUrl}}/users?limit=10","query":[{"key":"limit","value":"10"}]},` +
+		`"header":[{"key":"Accept","value":"application/json"}],` +
+		`"body":{"mode":"raw","raw":"{}","options":{"raw":{"language":"json"}}}}`
+
+	var r resources.Request
+	if err := json.Unmarshal([]byte(in), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Method != "GET" {
+		t.Errorf("expected method GET, got %s", r.Method)
+	}
+	if !reflect.DeepEqual(r.URL.Host, []string{"{{baseUrl}}"}) {
+		t.Errorf("unexpected host %v", r.URL.Host)
+	}
+	if r.Body.Language() != "json" {
+		t.Errorf("expected language json, got %s", r.Body.Language())
+	}
+
+	out, err := json.Marshal(&r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != in {
+		t.Errorf("expected %s, got %s", in, out)
+	}
+}
+
+func TestURLParts(t *testing.T) {
+	in := `{"host":"api.example.com","path":"/v1/users","query":[{"key":"page","value":2},{"key":"q","value":"x","disabled":true}]}`
+
+	var u resources.URL
+	if err := json.Unmarshal([]byte(in), &u); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(u.Host, []string{"api", "example", "com"}) {
+		t.Errorf("unexpected host %v", u.Host)
+	}
+	if got := u.String(); got != "api.example.com/v1/users?page=2" {
+		t.Errorf("unexpected URL %s", got)
+	}
+}
+
+func TestResponseHeaders(t *testing.T) {
+	in := `{"name":"OK","code":200,"header":"Content-Type: application/json\nX-Id: 1","body":"{}",` +
+		`"originalRequest":"{{baseUrl}}/users"}`
+
+	var r resources.Response
+	if err := json.Unmarshal([]byte(in), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r.Header) != 2 || r.Header[0].Key != "Content-Type" || r.Header[1].Value != "1" {
+		t.Errorf("unexpected headers %v", r.Header)
+	}
+	if r.OriginalRequest == nil || r.OriginalRequest.URL.String() != "{{baseUrl}}/users" {
+		t.Errorf("unexpected original request %v", r.OriginalRequest)
+	}
+
+	out, err := json.Marshal(&r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != in {
+		t.Errorf("expected %s, got %s", in, out)
+	}
+
+	r.Code = 201
+	out, err = json.Marshal(&r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"name":"OK","originalRequest":"{{baseUrl}}/users","code":201,` +
+		`"header":"Content-Type: application/json\nX-Id: 1","body":"{}"}`
+	if string(out) != expected {
+		t.Errorf("expected %s, got %s", expected, out)
+	}
+}
+
+func TestRequestGoldenRoundTrip(t *testing.T) {
+	tests := map[string]string{
+		"lowercase method": `{"method":"post","url":"{{baseUrl}}/users"}`,
+		"header string":    `{"method":"GET","url":"{{baseUrl}}","header":"Accept: application/json\nX-Id: 1"}`,
+		"header list with strings": `{"method":"GET","url":"{{baseUrl}}",` +
+			`"header":["Accept: application/json",{"key":"X-Id","value":"1"}]}`,
+		"host string":    `{"url":{"raw":"https://api.example.com/v1","host":"api.example.com","path":"/v1"}}`,
+		"typed segments": `{"url":{"raw":"{{baseUrl}}/users/:id","host":["{{baseUrl}}"],"path":["users",{"type":"string","value":":id"}]}}`,
+		"non-string values": `{"url":{"raw":"{{baseUrl}}?page=2","host":["{{baseUrl}}"],"query":[{"key":"page","value":2}]},` +
+			`"header":[{"key":"X-Large","value":12345678901234567890},{"key":"X-Flag","value":true}]}`,
+		"unknown fields": `{"method":"GET","url":"{{baseUrl}}","x-custom":{"a":1}}`,
+	}
+
+	for name, in := range tests {
+		var r resources.Request
+		if err := json.Unmarshal([]byte(in), &r); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		out, err := json.Marshal(&r)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if string(out) != in {
+			t.Errorf("%s: expected %s, got %s", name, in, out)
+		}
+	}
+}
+
+func TestResponseGoldenRoundTrip(t *testing.T) {
+	tests := map[string]string{
+		"object body": `{"code":200,"body":{"id":1,"tags":["a"]}}`,
+		"number body": `{"code":200,"body":12345678901234567890}`,
+		"header list": `{"code":200,"header":[{"key":"X-Id","value":1}],"body":"ok"}`,
+	}
+
+	for name, in := range tests {
+		var r resources.Response
+		if err := json.Unmarshal([]byte(in), &r); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		out, err := json.Marshal(&r)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if string(out) != in {
+			t.Errorf("%s: expected %s, got %s", name, in, out)
+		}
+	}
+}
+
+func TestRequestChangedKeepsOriginalParts(t *testing.T) {
+	in := `{"method":"post","url":{"raw":"https://api.example.com/v1","host":"api.example.com","path":["v1",{"type":"string","value":"x"}]},` +
+		`"header":"Accept: application/json","body":{"mode":"raw","raw":"{}"}}`
+
+	var r resources.Request
+	if err := json.Unmarshal([]byte(in), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	r.Body.Raw = `{"id":1}`
+	r.URL.Port = "8080"
+
+	out, err := json.Marshal(&r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"method":"post","url":{"raw":"https://api.example.com/v1","host":"api.example.com","port":"8080",` +
+		`"path":["v1",{"type":"string","value":"x"}]},"header":"Accept: application/json","body":{"mode":"raw","raw":"{\"id\":1}"}}`
+	if string(out) != expected {
+		t.Errorf("expected %s, got %s", expected, out)
+	}
+
+	r.Method = "PUT"
+	r.Header[0].Value = "text/plain"
+	out, err = json.Marshal(&r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = `{"method":"PUT","url":{"raw":"https://api.example.com/v1","host":"api.example.com","port":"8080",` +
+		`"path":["v1",{"type":"string","value":"x"}]},"header":[{"key":"Accept","value":"text/plain"}],"body":{"mode":"raw","raw":"{\"id\":1}"}}`
+	if string(out) != expected {
+		t.Errorf("expected %s, got %s", expected, out)
+	}
+}
+
+func TestURLChangedParts(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected string
+	}{
+		{
+			`{"raw":"https://api.example.com/search?q={{v}}"}`,
+			`{"raw":"https://api.example.com/search?q={{v}}","protocol":"https","host":["api","example","com"],` +
+				`"path":["search"],"query":[{"key":"q","value":"changed"}]}`,
+		},
+		{
+			`{"raw":"https://api.example.com/search?q={{v}}","query":[{"key":"q","value":"{{v}}"}]}`,
+			`{"raw":"https://api.example.com/search?q={{v}}","protocol":"https","host":["api","example","com"],` +
+				`"path":["search"],"query":[{"key":"q","value":"changed"}]}`,
+		},
+		{
+			`"https://api.example.com/search?q={{v}}"`,
+			`{"raw":"https://api.example.com/search?q={{v}}","protocol":"https","host":["api","example","com"],` +
+				`"path":["search"],"query":[{"key":"q","value":"changed"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		var u resources.URL
+		if err := json.Unmarshal([]byte(tt.in), &u); err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := json.Marshal(&u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != tt.in {
+			t.Errorf("expected %s, got %s", tt.in, out)
+		}
+
+		u.Query[0].Value = "changed"
+		if out, err = json.Marshal(&u); err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != tt.expected {
+			t.Errorf("expected %s, got %s", tt.expected, out)
+		}
+	}
+
+	var r resources.Request
+	if err := json.Unmarshal([]byte(`"https://api.example.com/search?q={{v}}"`), &r); err != nil {
+		t.Fatal(err)
+	}
+	r.URL.Query[0].Value = "changed"
+	out, err := json.Marshal(&r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"method":"GET","url":{"raw":"https://api.example.com/search?q={{v}}","protocol":"https","host":["api","example","com"],` +
+		`"path":["search"],"query":[{"key":"q","value":"changed"}]}}`
+	if string(out) != expected {
+		t.Errorf("expected %s, got %s", expected, out)
+	}
+}
+
+func TestItemTypedRequest(t *testing.T) {
+	in := `{"name":"Get user","request":"{{baseUrl}}/users/1","response":[{"code":404,"originalRequest":{"method":"get","url":"{{baseUrl}}/users/2"}}]}`
+
+	var item resources.Item
+	if err := json.Unmarshal([]byte(in), &item); err != nil {
+		t.Fatal(err)
+	}
+
+	if item.Request == nil || item.Request.URL.String() != "{{baseUrl}}/users/1" {
+		t.Fatalf("unexpected request %v", item.Request)
+	}
+	if len(item.Responses) != 1 || item.Responses[0].Code != 404 || item.Responses[0].OriginalRequest.Method != "GET" {
+		t.Errorf("unexpected responses %v", item.Responses)
+	}
+
+	out, err := json.Marshal(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(out, &m); err != nil {
+		t.Fatal(err)
+	}
+	if m["request"] != "{{baseUrl}}/users/1" {
+		t.Errorf("expected the string shorthand, got %v", m["request"])
+	}
+}
+
+func TestItemKeepsOriginalRequest(t *testing.T) {
+	in := `{"name":"Create user","request":{"method":"post","url":{"raw":"{{baseUrl}}/users","host":"{{baseUrl}}","path":"/users"},` +
+		`"header":[{"key":"X-Large","value":12345678901234567890}]}}`
+
+	var item resources.Item
+	if err := json.Unmarshal([]byte(in), &item); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := json.Marshal(item.Item)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var v struct{ Request json.RawMessage }
+	if err := json.Unmarshal(out, &v); err != nil {
+		t.Fatal(err)
+	}
+	expected := in[strings.Index(in, `{"method"`) : len(in)-1]
+	if string(v.Request) != expected {
+		t.Errorf("expected %s, got %s", expected, v.Request)
+	}
+}
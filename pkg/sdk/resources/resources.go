@@ -16,6 +16,8 @@ limitations under the License.
 
 package resources
 
+import "strconv"
+
 // Formatter represents a resource that provides print formatting information.
 type Formatter interface {
 	// Format returns column headers and values for the resource.
@@ -41,28 +43,9 @@ const (
 
 // String returns a string version of the ResourceType.
 func (r ResourceType) String() string {
-	switch r {
-	case CollectionType:
-		return "Collection"
-	case EnvironmentType:
-		return "Environment"
-	case MockType:
-		return "Mock"
-	case MonitorType:
-		return "Monitor"
-	case APIType:
-		return "API"
-	case APIVersionType:
-		return "APIVersion"
-	case APIRelationsType:
-		return "APIRelations"
-	case SchemaType:
-		return "Schema"
-	case WorkspaceType:
-		return "Workspace"
-	case UserType:
-		return "User"
+	if info, ok := Info(r); ok {
+		return info.Kind
 	}
 
-	return ""
+	return "ResourceType(" + strconv.Itoa(int(r)) + ")"
 }
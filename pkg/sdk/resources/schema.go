@@ -18,6 +18,21 @@ package resources
 
 import "time"
 
+func init() {
+	Register(&ResourceInfo{
+		Type:    SchemaType,
+		Kind:    "Schema",
+		Title:   "schema",
+		Name:    "schema",
+		Plural:  "schemas",
+		Path:    "apis/{apiID}/versions/{apiVersionID}/schemas",
+		Parents: []Parent{{Type: APIType, Param: "apiID"}, {Type: APIVersionType, Param: "apiVersionID"}},
+		Key:     "schema",
+		Item:    Schema{},
+		Verbs:   VerbGet | VerbCreate | VerbReplace | VerbDelete,
+	})
+}
+
 // SchemaResponse represents the top-level schema response in the Postman API.
 type SchemaResponse struct {
 	Schema Schema `json:"schema"`
@@ -21,6 +21,21 @@ import (
 	"strconv"
 )
 
+func init() {
+	Register(&ResourceInfo{
+		Type:      UserType,
+		Kind:      "User",
+		Title:     "user",
+		Name:      "user",
+		Plural:    "user",
+		Path:      "me",
+		Singleton: true,
+		Key:       "user",
+		Item:      User{},
+		Verbs:     VerbGet,
+	})
+}
+
 // UserResponse represents the top-level struct of a user response in the
 // Postman API.
 type UserResponse struct {
@@ -16,6 +16,23 @@ limitations under the License.
 
 package resources
 
+func init() {
+	Register(&ResourceInfo{
+		Type:    WorkspaceType,
+		Kind:    "Workspace",
+		Title:   "workspace",
+		Name:    "workspace",
+		Plural:  "workspaces",
+		Aliases: []string{"ws"},
+		Path:    "workspaces",
+		Key:     "workspace",
+		ListKey: "workspaces",
+		Item:    Workspace{},
+		List:    WorkspaceListItems{},
+		Verbs:   VerbList | VerbGet | VerbCreate | VerbReplace | VerbDelete,
+	})
+}
+
 // WorkspaceListResponse represents the top-level workspaces response from the
 // Postman API.
 type WorkspaceListResponse struct {
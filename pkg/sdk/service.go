@@ -19,6 +19,7 @@ package sdk
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 
 	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
@@ -27,6 +28,10 @@ import (
 // Service is used by Postman API consumers.
 type Service struct {
 	Options *client.Options
+	// SkipCollectionValidation disables checking collections against the
+	// collection schema in CreateFromReader and ReplaceFromReader, before
+	// they are sent to the Postman API.
+	SkipCollectionValidation bool
 }
 
 // NewService returns a new instance of the Postman API service client.
@@ -82,3 +87,31 @@ func (s *Service) delete(ctx context.Context, output interface{}, path ...string
 
 	return res, err
 }
+
+// wrapEnvelope wraps a JSON object in {"<key>": ...} as expected by the
+// Postman API.
+func wrapEnvelope(key string, b []byte) ([]byte, error) {
+	// The resource is checked to be an object but kept as is, so numbers
+	// and key order are not changed.
+	var v map[string]json.RawMessage
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]json.RawMessage{key: b})
+}
+
+// responseID makes a best attempt at returning the UID or ID of the
+// resource in a response.
+func responseID(responseBody interface{}, key string) string {
+	responseValue, _ := responseBody.(map[string]interface{})
+	vMap, _ := responseValue[key].(map[string]interface{})
+	if v, ok := vMap["uid"].(string); ok {
+		return v
+	}
+	if v, ok := vMap["id"].(string); ok {
+		return v
+	}
+
+	return ""
+}
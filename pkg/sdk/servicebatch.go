@@ -0,0 +1,214 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+// BatchOptions configures the fetching of several resources at once.
+type BatchOptions struct {
+	// Concurrency is the number of requests made at the same time. Values
+	// below 1 mean one request at a time.
+	Concurrency int
+}
+
+// ItemError is the error of a single resource in a batch.
+type ItemError struct {
+	Index int
+	ID    string
+	Err   error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("%s: %s", e.ID, e.Err)
+}
+
+// Unwrap returns the error of the item.
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// BatchError holds the errors of the resources of a batch that could not be
+// fetched, in the order of their IDs.
+type BatchError []*ItemError
+
+func (e BatchError) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+
+	return fmt.Sprintf("%d resources could not be fetched: %s", len(e), strings.Join(s, "; "))
+}
+
+// Is reports whether the errors of all items match target, such as
+// client.ErrNotFound.
+func (e BatchError) Is(target error) bool {
+	for _, err := range e {
+		if !errors.Is(err, target) {
+			return false
+		}
+	}
+
+	return len(e) > 0
+}
+
+// batch calls fn for the index of each ID with at most opts.Concurrency
+// calls running at once. The errors of all calls are returned as a
+// BatchError.
+func batch(ctx context.Context, ids []string, opts *BatchOptions, fn func(ctx context.Context, i int) error) error {
+	workers := 1
+	if opts != nil && opts.Concurrency > 1 {
+		workers = opts.Concurrency
+	}
+	if workers > len(ids) {
+		workers = len(ids)
+	}
+
+	errs := make([]error, len(ids))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				errs[i] = fn(ctx, i)
+			}
+		}()
+	}
+
+	for i := range ids {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var batchErr BatchError
+	for i, err := range errs {
+		if err != nil {
+			batchErr = append(batchErr, &ItemError{Index: i, ID: ids[i], Err: err})
+		}
+	}
+	if batchErr != nil {
+		return batchErr
+	}
+
+	return nil
+}
+
+// GetByIDs returns resources of a type in the order of their IDs, as
+// pointers to the Item type of the resource. Resources that could not be
+// fetched are nil and their errors are returned as a BatchError.
+func (s *Service) GetByIDs(ctx context.Context, t resources.ResourceType, urlParams map[string]string, ids []string, opts *BatchOptions) ([]interface{}, error) {
+	r := make([]interface{}, len(ids))
+	err := batch(ctx, ids, opts, func(ctx context.Context, i int) (err error) {
+		r[i], err = s.Get(ctx, t, urlParams, ids[i])
+		return err
+	})
+
+	return r, err
+}
+
+// CollectionsByID returns collections in the order of their IDs.
+func (s *Service) CollectionsByID(ctx context.Context, ids []string, opts *BatchOptions) (resources.CollectionSlice, error) {
+	r := make(resources.CollectionSlice, len(ids))
+	err := batch(ctx, ids, opts, func(ctx context.Context, i int) (err error) {
+		r[i], err = s.Collection(ctx, ids[i])
+		return err
+	})
+
+	return r, err
+}
+
+// EnvironmentsByID returns environments in the order of their IDs.
+func (s *Service) EnvironmentsByID(ctx context.Context, ids []string, opts *BatchOptions) (resources.EnvironmentSlice, error) {
+	r := make(resources.EnvironmentSlice, len(ids))
+	err := batch(ctx, ids, opts, func(ctx context.Context, i int) (err error) {
+		r[i], err = s.Environment(ctx, ids[i])
+		return err
+	})
+
+	return r, err
+}
+
+// MocksByID returns mocks in the order of their IDs.
+func (s *Service) MocksByID(ctx context.Context, ids []string, opts *BatchOptions) (resources.MockSlice, error) {
+	r := make(resources.MockSlice, len(ids))
+	err := batch(ctx, ids, opts, func(ctx context.Context, i int) (err error) {
+		r[i], err = s.Mock(ctx, ids[i])
+		return err
+	})
+
+	return r, err
+}
+
+// MonitorsByID returns monitors in the order of their IDs.
+func (s *Service) MonitorsByID(ctx context.Context, ids []string, opts *BatchOptions) (resources.MonitorSlice, error) {
+	r := make(resources.MonitorSlice, len(ids))
+	err := batch(ctx, ids, opts, func(ctx context.Context, i int) (err error) {
+		r[i], err = s.Monitor(ctx, ids[i])
+		return err
+	})
+
+	return r, err
+}
+
+// WorkspacesByID returns workspaces in the order of their IDs.
+func (s *Service) WorkspacesByID(ctx context.Context, ids []string, opts *BatchOptions) (resources.WorkspaceSlice, error) {
+	r := make(resources.WorkspaceSlice, len(ids))
+	err := batch(ctx, ids, opts, func(ctx context.Context, i int) (err error) {
+		r[i], err = s.Workspace(ctx, ids[i])
+		return err
+	})
+
+	return r, err
+}
+
+// APIsByID returns APIs in the order of their IDs.
+func (s *Service) APIsByID(ctx context.Context, ids []string, opts *BatchOptions) (resources.APISlice, error) {
+	r := make(resources.APISlice, len(ids))
+	err := batch(ctx, ids, opts, func(ctx context.Context, i int) (err error) {
+		r[i], err = s.API(ctx, ids[i])
+		return err
+	})
+
+	return r, err
+}
+
+// APIVersionsByID returns versions of an API in the order of their IDs.
+func (s *Service) APIVersionsByID(ctx context.Context, apiID string, ids []string, opts *BatchOptions) (resources.APIVersionSlice, error) {
+	r := make(resources.APIVersionSlice, len(ids))
+	err := batch(ctx, ids, opts, func(ctx context.Context, i int) (err error) {
+		r[i], err = s.APIVersion(ctx, apiID, ids[i])
+		return err
+	})
+
+	return r, err
+}
@@ -0,0 +1,112 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func TestCollectionsByID(t *testing.T) {
+	var mux *http.ServeMux
+	var service *sdk.Service
+	teardown := setupService(&mux, &service)
+	defer teardown()
+
+	var (
+		mu               sync.Mutex
+		running, highest int
+	)
+	mux.HandleFunc("/collections/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		running++
+		if running > highest {
+			highest = running
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+
+		id := strings.TrimPrefix(r.URL.Path, "/collections/")
+		if strings.HasPrefix(id, "missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"collection":{"info":{"_postman_id":"%s","name":"%s","schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},"item":[]}}`, id, id)
+	})
+
+	ids := []string{"a", "missing-1", "b", "c", "missing-2", "d"}
+	r, err := service.CollectionsByID(context.Background(), ids, &sdk.BatchOptions{Concurrency: 3})
+
+	for i, id := range ids {
+		if strings.HasPrefix(id, "missing") {
+			if r[i] != nil {
+				t.Errorf("Collection %d should be nil, have: %+v", i, r[i])
+			}
+		} else if r[i] == nil || r[i].Info.Name != id {
+			t.Errorf("Collection %d is incorrect, have: %+v, want: %s", i, r[i], id)
+		}
+	}
+
+	errs, ok := err.(sdk.BatchError)
+	if !ok || len(errs) != 2 || errs[0].ID != "missing-1" || errs[1].Index != 4 {
+		t.Errorf("Errors are incorrect, have: %v", err)
+	}
+
+	if !errors.Is(err, client.ErrNotFound) || !errors.Is(errs[0], client.ErrNotFound) {
+		t.Errorf("Errors should match %v", client.ErrNotFound)
+	}
+
+	if highest < 2 || highest > 3 {
+		t.Errorf("Concurrent requests are incorrect, have: %d, want: 2 to 3", highest)
+	}
+}
+
+func TestGetByIDs(t *testing.T) {
+	var mux *http.ServeMux
+	var service *sdk.Service
+	teardown := setupService(&mux, &service)
+	defer teardown()
+
+	mux.HandleFunc("/environments/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/environments/")
+		fmt.Fprintf(w, `{"environment":{"id":"%s"}}`, id)
+	})
+
+	r, err := service.GetByIDs(context.Background(), resources.EnvironmentType, nil, []string{"x", "y"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r) != 2 || r[1].(*resources.Environment).ID != "y" {
+		t.Errorf("Environments are incorrect, have: %+v", r)
+	}
+}
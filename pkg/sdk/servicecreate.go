@@ -18,13 +18,13 @@ package sdk
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 
 	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/validate"
 )
 
 // CreateCollectionFromReader creates a new collection.
@@ -128,7 +128,8 @@ func (s *Service) CreateSchemaFromReader(ctx context.Context, reader io.Reader,
 	return s.CreateFromReader(ctx, resources.SchemaType, reader, queryParams, urlParams)
 }
 
-// CreateFromReader posts a new resource to the Postman API.
+// CreateFromReader posts a new resource to the Postman API. Parent IDs are
+// given in urlParams.
 func (s *Service) CreateFromReader(ctx context.Context, t resources.ResourceType, reader io.Reader, queryParams, urlParams map[string]string) (string, error) {
 	b, err := ioutil.ReadAll(reader)
 
@@ -136,100 +137,25 @@ func (s *Service) CreateFromReader(ctx context.Context, t resources.ResourceType
 		return "", err
 	}
 
-	var v map[string]interface{}
-	if err := json.Unmarshal(b, &v); err != nil {
-		return "", err
+	if t == resources.CollectionType && !s.SkipCollectionValidation {
+		if errs := validate.Collection(b); errs != nil {
+			return "", errs
+		}
 	}
 
-	var (
-		path             []string
-		requestBody      []byte
-		responseValueKey string
-	)
+	info, ok := resources.Info(t)
+	if !ok || !info.Supports(resources.VerbCreate) {
+		return "", fmt.Errorf("unable to create resource, %+v not supported", t)
+	}
 
-	switch t {
-	case resources.CollectionType:
-		path = []string{"collections"}
-		responseValueKey = "collection"
+	path, err := info.CollectionPath(urlParams)
+	if err != nil {
+		return "", err
+	}
 
-		c := struct {
-			Collection map[string]interface{} `json:"collection,omitempty"`
-		}{
-			Collection: v,
-		}
-		requestBody, _ = json.Marshal(c) // already been unmarshalled, no error
-	case resources.EnvironmentType:
-		path = []string{"environments"}
-		responseValueKey = "environment"
-
-		c := struct {
-			Environment map[string]interface{} `json:"environment,omitempty"`
-		}{
-			Environment: v,
-		}
-		requestBody, _ = json.Marshal(c)
-	case resources.MockType:
-		path = []string{"mocks"}
-		responseValueKey = "mock"
-
-		c := struct {
-			Mock map[string]interface{} `json:"mock,omitempty"`
-		}{
-			Mock: v,
-		}
-		requestBody, _ = json.Marshal(c)
-	case resources.MonitorType:
-		path = []string{"monitors"}
-		responseValueKey = "monitor"
-
-		c := struct {
-			Monitor map[string]interface{} `json:"monitor,omitempty"`
-		}{
-			Monitor: v,
-		}
-		requestBody, _ = json.Marshal(c)
-	case resources.WorkspaceType:
-		path = []string{"workspaces"}
-		responseValueKey = "workspace"
-
-		c := struct {
-			Workspace map[string]interface{} `json:"workspace,omitempty"`
-		}{
-			Workspace: v,
-		}
-		requestBody, _ = json.Marshal(c)
-	case resources.APIType:
-		path = []string{"apis"}
-		responseValueKey = "api"
-
-		c := struct {
-			API map[string]interface{} `json:"api,omitempty"`
-		}{
-			API: v,
-		}
-		requestBody, _ = json.Marshal(c)
-	case resources.APIVersionType:
-		path = []string{"apis", urlParams["apiID"], "versions"}
-		responseValueKey = "version"
-
-		c := struct {
-			Version map[string]interface{} `json:"version,omitempty"`
-		}{
-			Version: v,
-		}
-		requestBody, _ = json.Marshal(c)
-	case resources.SchemaType:
-		path = []string{"apis", urlParams["apiID"], "versions", urlParams["apiVersionID"], "schemas"}
-		responseValueKey = "schema"
-
-		c := struct {
-			Schema map[string]interface{} `json:"schema,omitempty"`
-		}{
-			Schema: v,
-		}
-		requestBody, _ = json.Marshal(c)
-	default:
-		return "", fmt.Errorf("unable to create resource, %+v not supported", t)
+	requestBody, err := wrapEnvelope(info.Key, b)
+	if err != nil {
+		return "", err
 	}
 
 	var responseBody interface{}
@@ -237,17 +163,5 @@ func (s *Service) CreateFromReader(ctx context.Context, t resources.ResourceType
 		return "", err
 	}
 
-	// Try a best attempt at returning the ID value.
-	responseValue := responseBody.(map[string]interface{})
-	if v, ok := responseValue[responseValueKey]; ok {
-		vMap := v.(map[string]interface{})
-		if v2, ok := vMap["uid"]; ok {
-			return v2.(string), nil
-		}
-		if v2, ok := vMap["id"]; ok {
-			return v2.(string), nil
-		}
-		return "", nil
-	}
-	return "", nil
+	return responseID(responseBody, info.Key), nil
 }
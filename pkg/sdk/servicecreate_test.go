@@ -24,6 +24,7 @@ import (
 
 	"github.com/kevinswiber/postmanctl/pkg/sdk"
 	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/validate"
 )
 
 var (
@@ -56,6 +57,9 @@ func TestCreateCollectionFromReader(t *testing.T) {
 
 	ensurePath(t, createMux, path)
 
+	// The subject is not a valid collection.
+	createService.SkipCollectionValidation = true
+
 	rdr := strings.NewReader(subject)
 	r, err := createService.CreateCollectionFromReader(context.Background(), rdr, "abcdef")
 	if err != nil {
@@ -67,6 +71,45 @@ func TestCreateCollectionFromReader(t *testing.T) {
 	}
 }
 
+func TestCreateCollectionFromReaderValidation(t *testing.T) {
+	teardown := setupCreateTest()
+	defer teardown()
+
+	path := "/collections"
+	subject := "{\"collection\":{\"uid\":\"abcdef\"}}"
+
+	requested := false
+	createMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, createMux, path)
+
+	rdr := strings.NewReader(`{"info": {"name": "Test"}, "item": []}`)
+	_, err := createService.CreateCollectionFromReader(context.Background(), rdr, "abcdef")
+	if _, ok := err.(validate.Errors); !ok {
+		t.Fatalf("Expected validation errors, have: %v", err)
+	}
+
+	if requested {
+		t.Error("Invalid collection was sent to the API.")
+	}
+
+	rdr = strings.NewReader(`{"info": {"name": "Test", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"}, "item": []}`)
+	r, err := createService.CreateCollectionFromReader(context.Background(), rdr, "abcdef")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r != "abcdef" {
+		t.Errorf("Resource UID is incorrect, have: %s, want: %s", r, "abcdef")
+	}
+}
+
 func TestCreateCollectionFromReaderError(t *testing.T) {
 	teardown := setupCreateTest()
 	defer teardown()
@@ -109,6 +152,9 @@ func TestCreateCollectionFromReaderMissingIDCondition(t *testing.T) {
 
 	ensurePath(t, createMux, path)
 
+	// The subject is not a valid collection.
+	createService.SkipCollectionValidation = true
+
 	rdr := strings.NewReader(subject)
 	r, err := createService.CreateCollectionFromReader(context.Background(), rdr, "abcdef")
 	if err != nil {
@@ -139,6 +185,9 @@ func TestCreateCollectionFromReaderMissingResponseValueCondition(t *testing.T) {
 
 	ensurePath(t, createMux, path)
 
+	// The subject is not a valid collection.
+	createService.SkipCollectionValidation = true
+
 	rdr := strings.NewReader(subject)
 	r, err := createService.CreateCollectionFromReader(context.Background(), rdr, "abcdef")
 	if err != nil {
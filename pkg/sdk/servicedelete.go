@@ -103,58 +103,23 @@ func (s *Service) DeleteSchema(ctx context.Context, resourceID, apiID, apiVersio
 	return s.Delete(ctx, resources.SchemaType, urlParams)
 }
 
-// Delete posts a new resource to the Postman API.
+// Delete deletes a resource from the Postman API. The resource ID and
+// parent IDs are given in urlParams.
 func (s *Service) Delete(ctx context.Context, t resources.ResourceType, urlParams map[string]string) (string, error) {
-	var (
-		path             []string
-		responseValueKey string
-	)
-
-	switch t {
-	case resources.CollectionType:
-		path = []string{"collections", urlParams["ID"]}
-		responseValueKey = "collection"
-	case resources.EnvironmentType:
-		path = []string{"environments", urlParams["ID"]}
-		responseValueKey = "environment"
-	case resources.MockType:
-		path = []string{"mocks", urlParams["ID"]}
-		responseValueKey = "mock"
-	case resources.MonitorType:
-		path = []string{"monitors", urlParams["ID"]}
-		responseValueKey = "monitor"
-	case resources.WorkspaceType:
-		path = []string{"workspaces", urlParams["ID"]}
-		responseValueKey = "workspace"
-	case resources.APIType:
-		path = []string{"apis", urlParams["ID"]}
-		responseValueKey = "api"
-	case resources.APIVersionType:
-		path = []string{"apis", urlParams["apiID"], "versions", urlParams["ID"]}
-		responseValueKey = "version"
-	case resources.SchemaType:
-		path = []string{"apis", urlParams["apiID"], "versions", urlParams["apiVersionID"], "schemas", urlParams["ID"]}
-		responseValueKey = "schema"
-	default:
+	info, ok := resources.Info(t)
+	if !ok || !info.Supports(resources.VerbDelete) {
 		return "", fmt.Errorf("unable to delete resource, %+v not supported", t)
 	}
 
+	path, err := info.ItemPath(urlParams, urlParams["ID"])
+	if err != nil {
+		return "", err
+	}
+
 	var responseBody interface{}
 	if _, err := s.delete(ctx, &responseBody, path...); err != nil {
 		return "", err
 	}
 
-	// Try a best attempt at returning the ID value.
-	responseValue := responseBody.(map[string]interface{})
-	if v, ok := responseValue[responseValueKey]; ok {
-		vMap := v.(map[string]interface{})
-		if v2, ok := vMap["uid"]; ok {
-			return v2.(string), nil
-		}
-		if v2, ok := vMap["id"]; ok {
-			return v2.(string), nil
-		}
-		return "", nil
-	}
-	return "", nil
+	return responseID(responseBody, info.Key), nil
 }
@@ -18,114 +18,190 @@ package sdk
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
 )
 
+// List returns all resources of a type, as a pointer to the List type of
+// the resource, such as *resources.CollectionListItems. Parent IDs are
+// given in urlParams.
+func (s *Service) List(ctx context.Context, t resources.ResourceType, queryParams, urlParams map[string]string) (interface{}, error) {
+	info, ok := resources.Info(t)
+	if !ok || !info.Supports(resources.VerbList) {
+		return nil, fmt.Errorf("unable to list resources, %+v not supported", t)
+	}
+
+	path, err := info.CollectionPath(urlParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getEnvelope(ctx, info.ListKey, info.NewList(), queryParams, path)
+}
+
+// Get returns a single resource, as a pointer to the Item type of the
+// resource, such as *resources.Collection. Parent IDs are given in
+// urlParams.
+func (s *Service) Get(ctx context.Context, t resources.ResourceType, urlParams map[string]string, id string) (interface{}, error) {
+	raw, err := s.GetRaw(ctx, t, urlParams, id)
+	if err != nil {
+		return nil, err
+	}
+
+	info, _ := resources.Info(t)
+	v := info.NewItem()
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, v); err != nil {
+			return nil, err
+		}
+	}
+
+	return v, nil
+}
+
+// GetRaw returns the JSON of a single resource as sent by the API, without
+// the envelope.
+func (s *Service) GetRaw(ctx context.Context, t resources.ResourceType, urlParams map[string]string, id string) (json.RawMessage, error) {
+	info, ok := resources.Info(t)
+	if !ok || !info.Supports(resources.VerbGet) {
+		return nil, fmt.Errorf("unable to get resource, %+v not supported", t)
+	}
+
+	path, err := info.ItemPath(urlParams, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw json.RawMessage
+	if _, err := s.getEnvelope(ctx, info.Key, &raw, nil, path); err != nil {
+		return nil, err
+	}
+
+	return raw, nil
+}
+
+// getEnvelope decodes the value of key in the response into v.
+func (s *Service) getEnvelope(ctx context.Context, key string, v interface{}, queryParams map[string]string, path []string) (interface{}, error) {
+	var envelope map[string]json.RawMessage
+	if _, err := s.get(ctx, &envelope, queryParams, path...); err != nil {
+		return nil, err
+	}
+
+	if b, ok := envelope[key]; ok {
+		if err := json.Unmarshal(b, v); err != nil {
+			return nil, err
+		}
+	}
+
+	return v, nil
+}
+
 // Collections returns all collections.
 func (s *Service) Collections(ctx context.Context) (*resources.CollectionListItems, error) {
-	var resource resources.CollectionListResponse
-	if _, err := s.get(ctx, &resource, nil, "collections"); err != nil {
+	r, err := s.List(ctx, resources.CollectionType, nil, nil)
+	if err != nil {
 		return nil, err
 	}
 
-	return &resource.Collections, nil
+	return r.(*resources.CollectionListItems), nil
 }
 
 // Collection returns a single collection.
 func (s *Service) Collection(ctx context.Context, id string) (*resources.Collection, error) {
-	var resource resources.CollectionResponse
-	if _, err := s.get(ctx, &resource, nil, "collections", id); err != nil {
+	r, err := s.Get(ctx, resources.CollectionType, nil, id)
+	if err != nil {
 		return nil, err
 	}
 
-	return &resource.Collection, nil
+	return r.(*resources.Collection), nil
 }
 
 // Environments returns all environments.
 func (s *Service) Environments(ctx context.Context) (*resources.EnvironmentListItems, error) {
-	var resource resources.EnvironmentListResponse
-	if _, err := s.get(ctx, &resource, nil, "environments"); err != nil {
+	r, err := s.List(ctx, resources.EnvironmentType, nil, nil)
+	if err != nil {
 		return nil, err
 	}
 
-	return &resource.Environments, nil
+	return r.(*resources.EnvironmentListItems), nil
 }
 
 // Environment returns a single environment.
 func (s *Service) Environment(ctx context.Context, id string) (*resources.Environment, error) {
-	var resource resources.EnvironmentResponse
-	if _, err := s.get(ctx, &resource, nil, "environments", id); err != nil {
+	r, err := s.Get(ctx, resources.EnvironmentType, nil, id)
+	if err != nil {
 		return nil, err
 	}
 
-	return &resource.Environment, nil
+	return r.(*resources.Environment), nil
 }
 
 // APIs returns all APIs.
 func (s *Service) APIs(ctx context.Context, workspace string) (*resources.APIListItems, error) {
-	var resource resources.APIListResponse
 	var params map[string]string
 	if workspace != "" {
 		params = make(map[string]string)
 		params["workspace"] = workspace
 	}
 
-	if _, err := s.get(ctx, &resource, params, "apis"); err != nil {
+	r, err := s.List(ctx, resources.APIType, params, nil)
+	if err != nil {
 		return nil, err
 	}
 
-	return &resource.APIs, nil
+	return r.(*resources.APIListItems), nil
 }
 
 // API returns a single API.
 func (s *Service) API(ctx context.Context, id string) (*resources.API, error) {
-	var resource resources.APIResponse
-	if _, err := s.get(ctx, &resource, nil, "apis", id); err != nil {
+	r, err := s.Get(ctx, resources.APIType, nil, id)
+	if err != nil {
 		return nil, err
 	}
 
-	return &resource.API, nil
+	return r.(*resources.API), nil
 }
 
 // APIVersions returns all API Versions.
 func (s *Service) APIVersions(ctx context.Context, apiID string) (*resources.APIVersionListItems, error) {
-	var resource resources.APIVersionListResponse
-	if _, err := s.get(ctx, &resource, nil, "apis", apiID, "versions"); err != nil {
+	r, err := s.List(ctx, resources.APIVersionType, nil, map[string]string{"apiID": apiID})
+	if err != nil {
 		return nil, err
 	}
 
-	return &resource.APIVersions, nil
+	return r.(*resources.APIVersionListItems), nil
 }
 
 // APIVersion returns a single API Version.
 func (s *Service) APIVersion(ctx context.Context, apiID, id string) (*resources.APIVersion, error) {
-	var resource resources.APIVersionResponse
-	if _, err := s.get(ctx, &resource, nil, "apis", apiID, "versions", id); err != nil {
+	r, err := s.Get(ctx, resources.APIVersionType, map[string]string{"apiID": apiID}, id)
+	if err != nil {
 		return nil, err
 	}
 
-	return &resource.APIVersion, nil
+	return r.(*resources.APIVersion), nil
 }
 
 // Schema returns a single schema for an API version.
 func (s *Service) Schema(ctx context.Context, apiID, apiVersionID, id string) (*resources.Schema, error) {
-	var resource resources.SchemaResponse
-	if _, err := s.get(ctx, &resource, nil, "apis", apiID, "versions", apiVersionID, "schemas", id); err != nil {
+	r, err := s.Get(ctx, resources.SchemaType, map[string]string{"apiID": apiID, "apiVersionID": apiVersionID}, id)
+	if err != nil {
 		return nil, err
 	}
 
-	return &resource.Schema, nil
+	return r.(*resources.Schema), nil
 }
 
 // APIRelations returns the linked relations of an API
 func (s *Service) APIRelations(ctx context.Context, apiID, apiVersionID string) (*resources.APIRelations, error) {
-	var resource resources.APIRelationsResource
-	if _, err := s.get(ctx, &resource, nil, "apis", apiID, "versions", apiVersionID, "relations"); err != nil {
+	r, err := s.Get(ctx, resources.APIRelationsType, map[string]string{"apiID": apiID, "apiVersionID": apiVersionID}, "")
+	if err != nil {
 		return nil, err
 	}
 
-	return &resource.Relations, nil
+	return r.(*resources.APIRelations), nil
 }
 
 // FormattedAPIRelationItems returns the formatted linked relations of an API
@@ -141,70 +217,70 @@ func (s *Service) FormattedAPIRelationItems(ctx context.Context, apiID, apiVersi
 
 // User returns the current user.
 func (s *Service) User(ctx context.Context) (*resources.User, error) {
-	var resource resources.UserResponse
-	if _, err := s.get(ctx, &resource, nil, "me"); err != nil {
+	r, err := s.Get(ctx, resources.UserType, nil, "")
+	if err != nil {
 		return nil, err
 	}
 
-	return &resource.User, nil
+	return r.(*resources.User), nil
 }
 
 // Workspaces returns the workspaces for the current user.
 func (s *Service) Workspaces(ctx context.Context) (*resources.WorkspaceListItems, error) {
-	var resource resources.WorkspaceListResponse
-	if _, err := s.get(ctx, &resource, nil, "workspaces"); err != nil {
+	r, err := s.List(ctx, resources.WorkspaceType, nil, nil)
+	if err != nil {
 		return nil, err
 	}
 
-	return &resource.Workspaces, nil
+	return r.(*resources.WorkspaceListItems), nil
 }
 
 // Workspace returns a single workspace for the current user.
 func (s *Service) Workspace(ctx context.Context, id string) (*resources.Workspace, error) {
-	var resource resources.WorkspaceResponse
-	if _, err := s.get(ctx, &resource, nil, "workspaces", id); err != nil {
+	r, err := s.Get(ctx, resources.WorkspaceType, nil, id)
+	if err != nil {
 		return nil, err
 	}
 
-	return &resource.Workspace, nil
+	return r.(*resources.Workspace), nil
 }
 
 // Monitors returns the monitors for the current user.
 func (s *Service) Monitors(ctx context.Context) (*resources.MonitorListItems, error) {
-	var resource resources.MonitorListResponse
-	if _, err := s.get(ctx, &resource, nil, "monitors"); err != nil {
+	r, err := s.List(ctx, resources.MonitorType, nil, nil)
+	if err != nil {
 		return nil, err
 	}
 
-	return &resource.Monitors, nil
+	return r.(*resources.MonitorListItems), nil
 }
 
 // Monitor returns a single monitor for the current user.
 func (s *Service) Monitor(ctx context.Context, id string) (*resources.Monitor, error) {
-	var resource resources.MonitorResponse
-	if _, err := s.get(ctx, &resource, nil, "monitors", id); err != nil {
+	r, err := s.Get(ctx, resources.MonitorType, nil, id)
+	if err != nil {
 		return nil, err
 	}
 
-	return &resource.Monitor, nil
+	return r.(*resources.Monitor), nil
 }
 
 // Mocks returns the mocks for the current user.
 func (s *Service) Mocks(ctx context.Context) (*resources.MockListItems, error) {
-	var resource resources.MockListResponse
-	if _, err := s.get(ctx, &resource, nil, "mocks"); err != nil {
+	r, err := s.List(ctx, resources.MockType, nil, nil)
+	if err != nil {
 		return nil, err
 	}
 
-	return &resource.Mocks, nil
+	return r.(*resources.MockListItems), nil
 }
 
 // Mock returns a single mock for the current user.
 func (s *Service) Mock(ctx context.Context, id string) (*resources.Mock, error) {
-	var resource resources.MockResponse
-	if _, err := s.get(ctx, &resource, nil, "mocks", id); err != nil {
+	r, err := s.Get(ctx, resources.MockType, nil, id)
+	if err != nil {
 		return nil, err
 	}
 
-	return &resource.Mock, nil
+	return r.(*resources.Mock), nil
 }
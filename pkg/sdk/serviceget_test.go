@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/kevinswiber/postmanctl/pkg/sdk"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
 )
 
 var (
@@ -211,6 +212,30 @@ func TestEnvironmentsItem(t *testing.T) {
 	}
 }
 
+func TestGetRaw(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	path := "/environments/abcdef"
+	subject := `{"environment":{"uid":"abcdef","unknown":[1,2]}}`
+
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	r, err := getService.GetRaw(context.Background(), resources.EnvironmentType, nil, "abcdef")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := `{"uid":"abcdef","unknown":[1,2]}`; string(r) != expected {
+		t.Errorf("Raw resource is incorrect, have: %s, want: %s", r, expected)
+	}
+}
+
 func TestEnvironmentsItemError(t *testing.T) {
 	teardown := setupGetTest()
 	defer teardown()
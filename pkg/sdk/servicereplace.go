@@ -17,14 +17,18 @@ limitations under the License.
 package sdk
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"reflect"
 
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
 	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/validate"
 )
 
 // ReplaceCollectionFromReader replaces a collection.
@@ -43,6 +47,48 @@ func (s *Service) ReplaceEnvironmentFromReader(ctx context.Context, reader io.Re
 	return s.ReplaceFromReader(ctx, resources.EnvironmentType, reader, urlParams)
 }
 
+// UpdateEnvironment applies fn to an environment and replaces it. Before
+// writing, the environment is fetched again; when it has changed in the
+// meantime, fn is applied to the new content. After retries more attempts
+// an error matching client.ErrConflict is returned and nothing is written.
+func (s *Service) UpdateEnvironment(ctx context.Context, resourceID string, retries int, fn func(*resources.Environment) error) (string, error) {
+	current, err := s.Environment(ctx, resourceID)
+	if err != nil {
+		return "", err
+	}
+
+	for attempt := 0; ; attempt++ {
+		env := *current
+		env.Values = append([]resources.KeyValuePair(nil), current.Values...)
+		if err := fn(&env); err != nil {
+			return "", err
+		}
+
+		latest, err := s.Environment(ctx, resourceID)
+		if err != nil {
+			return "", err
+		}
+
+		if reflect.DeepEqual(latest, current) {
+			b, err := json.Marshal(struct {
+				Name   string                   `json:"name"`
+				Values []resources.KeyValuePair `json:"values"`
+			}{env.Name, env.Values})
+			if err != nil {
+				return "", err
+			}
+
+			return s.ReplaceEnvironmentFromReader(ctx, bytes.NewReader(b), resourceID)
+		}
+
+		if attempt >= retries {
+			return "", fmt.Errorf("the resource was changed while it was being updated: %w", client.ErrConflict)
+		}
+
+		current = latest
+	}
+}
+
 // ReplaceMockFromReader replaces an existing mock.
 func (s *Service) ReplaceMockFromReader(ctx context.Context, reader io.Reader, resourceID string) (string, error) {
 	urlParams := make(map[string]string)
@@ -106,7 +152,8 @@ func (s *Service) ReplaceSchemaFromReader(ctx context.Context, reader io.Reader,
 	return s.ReplaceFromReader(ctx, resources.SchemaType, reader, urlParams)
 }
 
-// ReplaceFromReader posts a new resource to the Postman API.
+// ReplaceFromReader replaces a resource in the Postman API. The resource
+// ID and parent IDs are given in urlParams.
 func (s *Service) ReplaceFromReader(ctx context.Context, t resources.ResourceType, reader io.Reader, urlParams map[string]string) (string, error) {
 	b, err := ioutil.ReadAll(reader)
 
@@ -114,118 +161,31 @@ func (s *Service) ReplaceFromReader(ctx context.Context, t resources.ResourceTyp
 		return "", err
 	}
 
-	var v map[string]interface{}
-	if err := json.Unmarshal(b, &v); err != nil {
-		return "", err
+	if t == resources.CollectionType && !s.SkipCollectionValidation {
+		if errs := validate.Collection(b); errs != nil {
+			return "", errs
+		}
 	}
 
-	var (
-		path             []string
-		requestBody      []byte
-		responseValueKey string
-	)
-
-	switch t {
-	case resources.CollectionType:
-		path = []string{"collections", urlParams["ID"]}
-		responseValueKey = "collection"
-
-		c := struct {
-			Collection map[string]interface{} `json:"collection,omitempty"`
-		}{
-			Collection: v,
-		}
-		requestBody, _ = json.Marshal(c) // already been unmarshalled, no error
-	case resources.EnvironmentType:
-		path = []string{"environments", urlParams["ID"]}
-		responseValueKey = "environment"
-
-		c := struct {
-			Environment map[string]interface{} `json:"environment,omitempty"`
-		}{
-			Environment: v,
-		}
-		requestBody, _ = json.Marshal(c)
-	case resources.MockType:
-		path = []string{"mocks", urlParams["ID"]}
-		responseValueKey = "mock"
-
-		c := struct {
-			Mock map[string]interface{} `json:"mock,omitempty"`
-		}{
-			Mock: v,
-		}
-		requestBody, _ = json.Marshal(c)
-	case resources.MonitorType:
-		path = []string{"monitors", urlParams["ID"]}
-		responseValueKey = "monitor"
-
-		c := struct {
-			Monitor map[string]interface{} `json:"monitor,omitempty"`
-		}{
-			Monitor: v,
-		}
-		requestBody, _ = json.Marshal(c)
-	case resources.WorkspaceType:
-		path = []string{"workspaces", urlParams["ID"]}
-		responseValueKey = "workspace"
-
-		c := struct {
-			Workspace map[string]interface{} `json:"workspace,omitempty"`
-		}{
-			Workspace: v,
-		}
-		requestBody, _ = json.Marshal(c)
-	case resources.APIType:
-		path = []string{"apis", urlParams["ID"]}
-		responseValueKey = "api"
-
-		c := struct {
-			API map[string]interface{} `json:"api,omitempty"`
-		}{
-			API: v,
-		}
-		requestBody, _ = json.Marshal(c)
-	case resources.APIVersionType:
-		path = []string{"apis", urlParams["apiID"], "versions", urlParams["ID"]}
-		responseValueKey = "version"
-
-		c := struct {
-			Version map[string]interface{} `json:"version,omitempty"`
-		}{
-			Version: v,
-		}
-		requestBody, _ = json.Marshal(c)
-	case resources.SchemaType:
-		path = []string{"apis", urlParams["apiID"], "versions", urlParams["apiVersionID"], "schemas", urlParams["ID"]}
-		responseValueKey = "schema"
-
-		c := struct {
-			Schema map[string]interface{} `json:"schema,omitempty"`
-		}{
-			Schema: v,
-		}
-		requestBody, _ = json.Marshal(c)
-	default:
+	info, ok := resources.Info(t)
+	if !ok || !info.Supports(resources.VerbReplace) {
 		return "", fmt.Errorf("unable to replace resource, %+v not supported", t)
 	}
 
+	path, err := info.ItemPath(urlParams, urlParams["ID"])
+	if err != nil {
+		return "", err
+	}
+
+	requestBody, err := wrapEnvelope(info.Key, b)
+	if err != nil {
+		return "", err
+	}
+
 	var responseBody interface{}
 	if _, err := s.put(ctx, requestBody, &responseBody, path...); err != nil {
 		return "", err
 	}
 
-	// Try a best attempt at returning the ID value.
-	responseValue := responseBody.(map[string]interface{})
-	if v, ok := responseValue[responseValueKey]; ok {
-		vMap := v.(map[string]interface{})
-		if v2, ok := vMap["uid"]; ok {
-			return v2.(string), nil
-		}
-		if v2, ok := vMap["id"]; ok {
-			return v2.(string), nil
-		}
-		return "", nil
-	}
-	return "", nil
+	return responseID(responseBody, info.Key), nil
 }
@@ -18,12 +18,17 @@ package sdk_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"strings"
 	"testing"
 
 	"github.com/kevinswiber/postmanctl/pkg/sdk"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
 	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/validate"
 )
 
 var (
@@ -56,6 +61,9 @@ func TestReplaceCollectionFromReader(t *testing.T) {
 
 	ensurePath(t, replaceMux, path)
 
+	// The subject is not a valid collection.
+	replaceService.SkipCollectionValidation = true
+
 	rdr := strings.NewReader(subject)
 	r, err := replaceService.ReplaceCollectionFromReader(context.Background(), rdr, "abcdef")
 	if err != nil {
@@ -67,6 +75,45 @@ func TestReplaceCollectionFromReader(t *testing.T) {
 	}
 }
 
+func TestReplaceCollectionFromReaderValidation(t *testing.T) {
+	teardown := setupReplaceTest()
+	defer teardown()
+
+	path := "/collections/abcdef"
+	subject := "{\"collection\":{\"uid\":\"abcdef\"}}"
+
+	requested := false
+	replaceMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, replaceMux, path)
+
+	rdr := strings.NewReader(`{"info": {"name": "Test"}, "item": []}`)
+	_, err := replaceService.ReplaceCollectionFromReader(context.Background(), rdr, "abcdef")
+	if _, ok := err.(validate.Errors); !ok {
+		t.Fatalf("Expected validation errors, have: %v", err)
+	}
+
+	if requested {
+		t.Error("Invalid collection was sent to the API.")
+	}
+
+	rdr = strings.NewReader(`{"info": {"name": "Test", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"}, "item": []}`)
+	r, err := replaceService.ReplaceCollectionFromReader(context.Background(), rdr, "abcdef")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r != "abcdef" {
+		t.Errorf("Resource UID is incorrect, have: %s, want: %s", r, "abcdef")
+	}
+}
+
 func TestReplaceCollectionFromReaderError(t *testing.T) {
 	teardown := setupReplaceTest()
 	defer teardown()
@@ -109,6 +156,9 @@ func TestReplaceCollectionFromReaderMissingIDCondition(t *testing.T) {
 
 	ensurePath(t, replaceMux, path)
 
+	// The subject is not a valid collection.
+	replaceService.SkipCollectionValidation = true
+
 	rdr := strings.NewReader(subject)
 	r, err := replaceService.ReplaceCollectionFromReader(context.Background(), rdr, "abcdef")
 	if err != nil {
@@ -139,6 +189,9 @@ func TestReplaceCollectionFromReaderMissingResponseValueCondition(t *testing.T)
 
 	ensurePath(t, replaceMux, path)
 
+	// The subject is not a valid collection.
+	replaceService.SkipCollectionValidation = true
+
 	rdr := strings.NewReader(subject)
 	r, err := replaceService.ReplaceCollectionFromReader(context.Background(), rdr, "abcdef")
 	if err != nil {
@@ -416,3 +469,59 @@ func TestReplaceFromReaderInvalidResourceError(t *testing.T) {
 		t.Errorf("Expected error.")
 	}
 }
+
+func TestUpdateEnvironment(t *testing.T) {
+	teardown := setupReplaceTest()
+	defer teardown()
+
+	path := "/environments/abcdef"
+	gets := 0
+	var body string
+
+	replaceMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			gets++
+			value := "1"
+			if gets > 1 {
+				// Changed by someone else after the first read.
+				value = "2"
+			}
+			fmt.Fprintf(w, `{"environment":{"id":"abcdef","name":"Test","values":[{"key":"a","value":"%s","enabled":true}]}}`, value)
+		case http.MethodPut:
+			b, _ := ioutil.ReadAll(r.Body)
+			body = string(b)
+			fmt.Fprint(w, `{"environment":{"uid":"abcdef"}}`)
+		}
+	})
+
+	ensurePath(t, replaceMux, path)
+
+	set := func(env *resources.Environment) error {
+		env.Set(resources.KeyValuePair{Key: "b", Value: "x", Enabled: true})
+		return nil
+	}
+
+	if _, err := replaceService.UpdateEnvironment(context.Background(), "abcdef", 0, set); !errors.Is(err, client.ErrConflict) {
+		t.Fatalf("Expected a conflict, have: %v", err)
+	}
+
+	if body != "" {
+		t.Errorf("Expected nothing to be written, have: %s", body)
+	}
+
+	gets = 0
+	r, err := replaceService.UpdateEnvironment(context.Background(), "abcdef", 1, set)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r != "abcdef" {
+		t.Errorf("Resource UID is incorrect, have: %s, want: %s", r, "abcdef")
+	}
+
+	expected := `{"environment":{"name":"Test","values":[{"key":"a","value":"2","enabled":true},{"key":"b","value":"x","enabled":true}]}}`
+	if body != expected {
+		t.Errorf("Request body is incorrect, have: %s, want: %s", body, expected)
+	}
+}
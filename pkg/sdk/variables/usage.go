@@ -0,0 +1,248 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+)
+
+// scriptPattern matches variables read or written by scripts, such as
+// pm.environment.get("token") or postman.setEnvironmentVariable("token").
+var scriptPattern = regexp.MustCompile(`(?:pm\.(?:environment|variables|collectionVariables|globals|iterationData)\.(get|set|has|unset)|postman\.(get|set)(?:Environment|Global)Variable)\(\s*["'` + "`" + `]([^"'` + "`" + `]+)["'` + "`" + `]`)
+
+// Reference is a use of a variable in a collection.
+type Reference struct {
+	Name string
+
+	// Location is where the variable is used, such as "Users/Get user" or
+	// "collection auth".
+	Location string
+}
+
+// Usage describes the variables a collection uses.
+type Usage struct {
+	// References lists every use of a variable in requests, auth, variable
+	// values and scripts, in collection order. Dynamic variables are left
+	// out.
+	References []Reference
+
+	// Set lists the variables set by scripts, which are defined when the
+	// collection runs.
+	Set []string
+}
+
+// Names returns the names of the variables used, without duplicates.
+func (u *Usage) Names() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, ref := range u.References {
+		if !seen[ref.Name] {
+			seen[ref.Name] = true
+			names = append(names, ref.Name)
+		}
+	}
+
+	return names
+}
+
+// CollectionUsage finds the variables used by a collection.
+func CollectionUsage(c *resources.Collection) *Usage {
+	u := &usageScanner{set: make(map[string]bool)}
+
+	for _, v := range c.Variable {
+		if s, ok := v.Value.(string); ok {
+			u.add("collection variable "+v.Key, References(s))
+		}
+	}
+	u.value("collection auth", c.Auth)
+	u.events("collection", c.Event)
+
+	if c.Items != nil {
+		u.node(c.Items.Root, "")
+	}
+
+	return &Usage{References: u.refs, Set: sortedKeys(u.set)}
+}
+
+type usageScanner struct {
+	refs []Reference
+	set  map[string]bool
+}
+
+func (u *usageScanner) add(location string, names []string) {
+	for _, name := range names {
+		if !IsDynamic(name) {
+			u.refs = append(u.refs, Reference{Name: name, Location: location})
+		}
+	}
+}
+
+func (u *usageScanner) node(n resources.ItemTreeNode, path string) {
+	if n.ItemGroup != nil {
+		path = joinPath(path, n.ItemGroup.Name)
+		u.value(path+" auth", n.ItemGroup.Auth)
+		u.events(path, n.ItemGroup.Event)
+	}
+
+	if n.Branches != nil {
+		for _, b := range *n.Branches {
+			u.node(b, path)
+		}
+	}
+
+	if n.Items != nil {
+		for _, it := range *n.Items {
+			location := joinPath(path, it.Name)
+			if it.Request != nil {
+				_, names := blank.ResolveRequest(it.Request)
+				u.add(location, names)
+			}
+			u.events(location, it.Event)
+		}
+	}
+}
+
+func (u *usageScanner) value(location string, v interface{}) {
+	if v == nil {
+		return
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err == nil {
+		u.add(location, References(buf.String()))
+	}
+}
+
+func (u *usageScanner) events(location string, events []*gen.Event) {
+	for _, e := range events {
+		if e == nil || e.Disabled || e.Script == nil {
+			continue
+		}
+
+		var names []string
+		for _, m := range scriptPattern.FindAllStringSubmatch(scriptSource(e.Script.Exec), -1) {
+			switch m[1] + m[2] {
+			case "get", "has":
+				names = append(names, m[3])
+			case "set":
+				u.set[m[3]] = true
+			}
+		}
+		u.add(location+" "+e.Listen+" script", names)
+	}
+}
+
+// blank resolves nothing, so it reports every variable it is given.
+var blank = &Resolver{}
+
+func scriptSource(exec interface{}) string {
+	switch t := exec.(type) {
+	case string:
+		return t
+	case []interface{}:
+		lines := make([]string, 0, len(t))
+		for _, l := range t {
+			if s, ok := l.(string); ok {
+				lines = append(lines, s)
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	return ""
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "/" + name
+}
+
+// Report lists the problems found by checking a collection's variables
+// against an environment.
+type Report struct {
+	// Undefined lists uses of variables that neither the collection, the
+	// environment nor a script defines.
+	Undefined []Reference
+
+	// Unused lists the environment keys that the collection does not use.
+	Unused []string
+}
+
+// Check compares the variables used by a collection with those defined by
+// the collection and an environment, which may be nil. Environment values
+// that refer to other variables count as uses.
+func Check(c *resources.Collection, env *resources.Environment) *Report {
+	usage := CollectionUsage(c)
+	refs := usage.References
+
+	defined := make(map[string]bool)
+	for _, v := range c.Variable {
+		if !v.Disabled {
+			defined[v.Key] = true
+		}
+	}
+	for _, name := range usage.Set {
+		defined[name] = true
+	}
+
+	if env != nil {
+		for _, v := range env.Values {
+			if v.Enabled {
+				defined[v.Key] = true
+			}
+		}
+		for _, v := range env.Values {
+			for _, name := range References(v.Value) {
+				if !IsDynamic(name) {
+					refs = append(refs, Reference{Name: name, Location: "environment variable " + v.Key})
+				}
+			}
+		}
+	}
+
+	r := &Report{}
+	used := make(map[string]bool)
+	seen := make(map[Reference]bool)
+	for _, ref := range refs {
+		used[ref.Name] = true
+		if !defined[ref.Name] && !seen[ref] {
+			seen[ref] = true
+			r.Undefined = append(r.Undefined, ref)
+		}
+	}
+
+	if env != nil {
+		for _, v := range env.Values {
+			if !used[v.Key] {
+				r.Unused = append(r.Unused, v.Key)
+			}
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,104 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/variables"
+)
+
+const usageCollection = `{
+  "info": {"name": "Test", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+  "variable": [{"key": "baseUrl", "value": "https://{{host}}"}],
+  "item": [
+    {
+      "name": "Users",
+      "auth": {"type": "apikey", "apikey": [{"key": "value", "value": "{{apiKey}}"}]},
+      "item": [
+        {
+          "name": "Login",
+          "request": {"method": "POST", "url": "{{baseUrl}}/login?id={{$guid}}"},
+          "event": [{"listen": "test", "script": {"exec": ["pm.environment.set(\"token\", pm.response.json().token);", "pm.environment.get('tenant');"]}}]
+        },
+        {
+          "name": "Me",
+          "request": {"url": "{{baseUrl}}/me", "header": [{"key": "Authorization", "value": "Bearer {{token}}"}]}
+        }
+      ]
+    }
+  ]
+}`
+
+func readUsageCollection(t *testing.T) *resources.Collection {
+	var c resources.Collection
+	if err := json.Unmarshal([]byte(usageCollection), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	return &c
+}
+
+func TestCollectionUsage(t *testing.T) {
+	u := variables.CollectionUsage(readUsageCollection(t))
+
+	expected := []variables.Reference{
+		{Name: "host", Location: "collection variable baseUrl"},
+		{Name: "apiKey", Location: "Users auth"},
+		{Name: "baseUrl", Location: "Users/Login"},
+		{Name: "tenant", Location: "Users/Login test script"},
+		{Name: "baseUrl", Location: "Users/Me"},
+		{Name: "token", Location: "Users/Me"},
+	}
+
+	if !reflect.DeepEqual(u.References, expected) {
+		t.Errorf("References are incorrect, have: %v, want: %v", u.References, expected)
+	}
+
+	if want := []string{"token"}; !reflect.DeepEqual(u.Set, want) {
+		t.Errorf("Set variables are incorrect, have: %v, want: %v", u.Set, want)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	env := &resources.Environment{
+		Name: "staging",
+		Values: []resources.KeyValuePair{
+			{Key: "host", Value: "{{region}}.example.com", Enabled: true},
+			{Key: "apiKey", Value: "secret", Enabled: false},
+			{Key: "stale", Value: "x", Enabled: true},
+		},
+	}
+
+	r := variables.Check(readUsageCollection(t), env)
+
+	expected := []variables.Reference{
+		{Name: "apiKey", Location: "Users auth"},
+		{Name: "tenant", Location: "Users/Login test script"},
+		{Name: "region", Location: "environment variable host"},
+	}
+	if !reflect.DeepEqual(r.Undefined, expected) {
+		t.Errorf("Undefined variables are incorrect, have: %v, want: %v", r.Undefined, expected)
+	}
+
+	if want := []string{"stale"}; !reflect.DeepEqual(r.Unused, want) {
+		t.Errorf("Unused keys are incorrect, have: %v, want: %v", r.Unused, want)
+	}
+}
@@ -0,0 +1,457 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package variables resolves {{name}} placeholders in requests the way
+// Postman does.
+package variables
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+)
+
+// Scope is a variable scope. When a variable is defined in more than one
+// scope, the value from the later scope is used.
+type Scope int
+
+// Variable scopes, from lowest to highest precedence.
+const (
+	Global Scope = iota
+	Collection
+	Environment
+	Data
+	Local
+)
+
+var scopeNames = []string{"global", "collection", "environment", "data", "local"}
+
+func (s Scope) String() string {
+	if s < Global || s > Local {
+		return "Scope(" + strconv.Itoa(int(s)) + ")"
+	}
+
+	return scopeNames[s]
+}
+
+// maxDepth limits how deep references to other variables are followed, which
+// also stops variables that refer to themselves.
+const maxDepth = 19
+
+var pattern = regexp.MustCompile(`{{\s*([^{}\s]+)\s*}}`)
+
+// Resolver resolves variables from a set of scopes.
+type Resolver struct {
+	scopes [Local + 1]map[string]string
+
+	// Dynamic generates the values of dynamic variables such as $guid. A
+	// variable defined in a scope takes precedence over a dynamic one.
+	Dynamic map[string]func() string
+}
+
+// NewResolver returns a resolver with no variables defined and the default
+// dynamic variables.
+func NewResolver() *Resolver {
+	r := &Resolver{Dynamic: DefaultDynamic()}
+	for i := range r.scopes {
+		r.scopes[i] = make(map[string]string)
+	}
+
+	return r
+}
+
+// DefaultDynamic returns generators for the dynamic variables supported by
+// the resolver: $guid, $randomUUID, $timestamp, $isoTimestamp and
+// $randomInt.
+func DefaultDynamic() map[string]func() string {
+	return map[string]func() string{
+		"$guid":       uuid,
+		"$randomUUID": uuid,
+		"$timestamp": func() string {
+			return strconv.FormatInt(time.Now().Unix(), 10)
+		},
+		"$isoTimestamp": func() string {
+			return time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+		},
+		"$randomInt": func() string {
+			n, err := rand.Int(rand.Reader, big.NewInt(1001))
+			if err != nil {
+				return "0"
+			}
+			return n.String()
+		},
+	}
+}
+
+// Set defines a variable in a scope.
+func (r *Resolver) Set(scope Scope, key, value string) {
+	r.scopes[scope][key] = value
+}
+
+// SetGlobals defines the enabled global variables.
+func (r *Resolver) SetGlobals(values []resources.KeyValuePair) {
+	for _, v := range values {
+		if v.Enabled {
+			r.Set(Global, v.Key, v.Value)
+		}
+	}
+}
+
+// SetCollection defines the enabled variables of a collection.
+func (r *Resolver) SetCollection(vars []*gen.Variable) {
+	for _, v := range vars {
+		if !v.Disabled {
+			r.Set(Collection, v.Key, valueString(v.Value))
+		}
+	}
+}
+
+// SetEnvironment defines the enabled variables of an environment.
+func (r *Resolver) SetEnvironment(env *resources.Environment) {
+	if env == nil {
+		return
+	}
+
+	for _, v := range env.Values {
+		if v.Enabled {
+			r.Set(Environment, v.Key, v.Value)
+		}
+	}
+}
+
+// SetData defines the variables of a row of a data file.
+func (r *Resolver) SetData(row map[string]string) {
+	for k, v := range row {
+		r.Set(Data, k, v)
+	}
+}
+
+// Lookup returns the value of a variable from the scope with the highest
+// precedence that defines it.
+func (r *Resolver) Lookup(name string) (string, Scope, bool) {
+	for s := Local; s >= Global; s-- {
+		if v, ok := r.scopes[s][name]; ok {
+			return v, s, true
+		}
+	}
+
+	return "", 0, false
+}
+
+// Names returns the keys defined in a scope, sorted.
+func (r *Resolver) Names(scope Scope) []string {
+	names := make([]string, 0, len(r.scopes[scope]))
+	for k := range r.scopes[scope] {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Resolve replaces the variables in s. Values that refer to other variables
+// are resolved in turn. Variables that cannot be resolved are left in place
+// and returned, sorted and without duplicates. A dynamic variable has the
+// same value wherever it is used in s.
+func (r *Resolver) Resolve(s string) (string, []string) {
+	res := newResolution()
+	out := r.resolve(s, 0, res)
+
+	return out, sortedKeys(res.unresolved)
+}
+
+// ResolveFunc returns a function resolving the strings of one request. A
+// dynamic variable has the same value in each of them. Unresolved variables
+// are left in place.
+func (r *Resolver) ResolveFunc() func(string) string {
+	res := newResolution()
+	return func(s string) string {
+		return r.resolve(s, 0, res)
+	}
+}
+
+// resolution is the state of a call resolving variables.
+type resolution struct {
+	unresolved map[string]bool
+	// dynamic holds the values generated for dynamic variables, so that
+	// they are generated once per call.
+	dynamic map[string]string
+}
+
+func newResolution() *resolution {
+	return &resolution{unresolved: make(map[string]bool), dynamic: make(map[string]string)}
+}
+
+func (r *Resolver) resolve(s string, depth int, res *resolution) string {
+	return pattern.ReplaceAllStringFunc(s, func(m string) string {
+		name := pattern.FindStringSubmatch(m)[1]
+
+		if v, _, ok := r.Lookup(name); ok {
+			if depth >= maxDepth {
+				for _, n := range References(v) {
+					res.unresolved[n] = true
+				}
+				return v
+			}
+			return r.resolve(v, depth+1, res)
+		}
+
+		if v, ok := res.dynamic[name]; ok {
+			return v
+		}
+		if fn, ok := r.Dynamic[name]; ok {
+			v := fn()
+			res.dynamic[name] = v
+			return v
+		}
+
+		res.unresolved[name] = true
+		return m
+	})
+}
+
+// ResolveRequest returns a copy of a request with the variables in its URL,
+// headers, body and auth resolved, along with the variables that could not
+// be resolved. A dynamic variable has the same value throughout the request,
+// so that the raw URL matches its parts.
+func (r *Resolver) ResolveRequest(req *resources.Request) (*resources.Request, []string) {
+	res := newResolution()
+	resolve := func(s string) string {
+		return r.resolve(s, 0, res)
+	}
+
+	out := *req
+
+	if req.URL != nil {
+		u := *req.URL
+		u.Raw = resolve(u.Raw)
+		u.Protocol = resolve(u.Protocol)
+		u.Host = resolveStrings(u.Host, resolve)
+		u.Port = resolve(u.Port)
+		u.Path = resolveStrings(u.Path, resolve)
+		u.Hash = resolve(u.Hash)
+		u.Query = resolveKeyValues(u.Query, resolve)
+		if u.Variable != nil {
+			u.Variable = make([]*gen.Variable, len(req.URL.Variable))
+			for i, v := range req.URL.Variable {
+				c := *v
+				if s, ok := c.Value.(string); ok {
+					c.Value = resolve(s)
+				}
+				u.Variable[i] = &c
+			}
+		}
+		out.URL = &u
+	}
+
+	out.Header = resolveKeyValues(req.Header, resolve)
+
+	if req.Body != nil {
+		b := *req.Body
+		b.Raw = resolve(b.Raw)
+		b.URLEncoded = resolveKeyValues(b.URLEncoded, resolve)
+		b.FormData = resolveKeyValues(b.FormData, resolve)
+		if b.GraphQL != nil {
+			b.GraphQL, _ = resolveValue(b.GraphQL, resolve).(map[string]interface{})
+		}
+		out.Body = &b
+	}
+
+	if req.Auth != nil {
+		if auth, err := resolveAuth(req.Auth, resolve); err == nil {
+			out.Auth = auth
+		}
+	}
+
+	return &out, sortedKeys(res.unresolved)
+}
+
+// ResolveCollection resolves every request in a collection and returns the
+// variables that could not be resolved.
+func (r *Resolver) ResolveCollection(c *resources.Collection) []string {
+	unresolved := make(map[string]bool)
+	if c.Items != nil {
+		r.resolveNode(c.Items.Root, unresolved)
+	}
+
+	return sortedKeys(unresolved)
+}
+
+func (r *Resolver) resolveNode(n resources.ItemTreeNode, unresolved map[string]bool) {
+	if n.Branches != nil {
+		for _, b := range *n.Branches {
+			r.resolveNode(b, unresolved)
+		}
+	}
+
+	if n.Items != nil {
+		for _, it := range *n.Items {
+			if it.Request == nil {
+				continue
+			}
+			_, names := r.ResolveRequest(it.Request)
+			for _, name := range names {
+				unresolved[name] = true
+			}
+		}
+	}
+}
+
+// References returns the names of the variables used in s, in order of
+// first use.
+func References(s string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range pattern.FindAllStringSubmatch(s, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+
+	return names
+}
+
+// IsDynamic reports whether name is a dynamic variable, such as $guid.
+func IsDynamic(name string) bool {
+	return strings.HasPrefix(name, "$")
+}
+
+func resolveStrings(s []string, resolve func(string) string) []string {
+	if s == nil {
+		return nil
+	}
+
+	out := make([]string, len(s))
+	for i, v := range s {
+		out[i] = resolve(v)
+	}
+
+	return out
+}
+
+func resolveKeyValues(kvs []*resources.KeyValue, resolve func(string) string) []*resources.KeyValue {
+	if kvs == nil {
+		return nil
+	}
+
+	out := make([]*resources.KeyValue, len(kvs))
+	for i, kv := range kvs {
+		c := *kv
+		c.Key = resolve(c.Key)
+		c.Value = resolve(c.Value)
+		if s, ok := c.Src.(string); ok {
+			c.Src = resolve(s)
+		}
+		out[i] = &c
+	}
+
+	return out
+}
+
+// resolveValue resolves the strings in a decoded JSON value.
+func resolveValue(v interface{}, resolve func(string) string) interface{} {
+	switch t := v.(type) {
+	case string:
+		return resolve(t)
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			m[k] = resolveValue(e, resolve)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(t))
+		for i, e := range t {
+			s[i] = resolveValue(e, resolve)
+		}
+		return s
+	}
+
+	return v
+}
+
+func resolveAuth(auth *gen.Auth, resolve func(string) string) (*gen.Auth, error) {
+	b, err := json.Marshal(auth)
+	if err != nil {
+		return nil, err
+	}
+
+	var m interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	if b, err = json.Marshal(resolveValue(m, resolve)); err != nil {
+		return nil, err
+	}
+
+	var out gen.Auth
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	if len(m) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func valueString(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	default:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return fmt.Sprint(t)
+		}
+		return string(b)
+	}
+}
+
+func uuid() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,186 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/variables"
+)
+
+func newTestResolver() *variables.Resolver {
+	r := variables.NewResolver()
+	r.SetGlobals([]resources.KeyValuePair{
+		{Key: "host", Value: "global.example.com", Enabled: true},
+		{Key: "version", Value: "v1", Enabled: true},
+	})
+	r.SetCollection([]*gen.Variable{
+		{Key: "host", Value: "collection.example.com"},
+		{Key: "baseUrl", Value: "https://{{host}}/{{version}}"},
+		{Key: "disabled", Value: "x", Disabled: true},
+	})
+	r.SetEnvironment(&resources.Environment{
+		Values: []resources.KeyValuePair{
+			{Key: "host", Value: "env.example.com", Enabled: true},
+			{Key: "token", Value: "off", Enabled: false},
+		},
+	})
+	r.Dynamic["$guid"] = func() string { return "guid" }
+
+	return r
+}
+
+func TestResolvePrecedence(t *testing.T) {
+	r := newTestResolver()
+
+	have, unresolved := r.Resolve("{{baseUrl}}/users")
+	if want := "https://env.example.com/v1/users"; have != want {
+		t.Errorf("Resolved value is incorrect, have: %s, want: %s", have, want)
+	}
+	if unresolved != nil {
+		t.Errorf("Expected no unresolved variables, have: %v", unresolved)
+	}
+
+	r.SetData(map[string]string{"host": "data.example.com"})
+	r.Set(variables.Local, "version", "v2")
+
+	if have, _ := r.Resolve("{{baseUrl}}"); have != "https://data.example.com/v2" {
+		t.Errorf("Resolved value is incorrect, have: %s", have)
+	}
+
+	if _, scope, _ := r.Lookup("version"); scope != variables.Local {
+		t.Errorf("Scope is incorrect, have: %s, want: local", scope)
+	}
+}
+
+func TestResolveUnresolved(t *testing.T) {
+	r := newTestResolver()
+
+	have, unresolved := r.Resolve("{{ token }}/{{disabled}}/{{$guid}}/{{token}}")
+	if want := "{{ token }}/{{disabled}}/guid/{{token}}"; have != want {
+		t.Errorf("Resolved value is incorrect, have: %s, want: %s", have, want)
+	}
+
+	if want := []string{"disabled", "token"}; !reflect.DeepEqual(unresolved, want) {
+		t.Errorf("Unresolved variables are incorrect, have: %v, want: %v", unresolved, want)
+	}
+}
+
+func TestResolveCycle(t *testing.T) {
+	r := variables.NewResolver()
+	r.Set(variables.Local, "a", "{{b}}")
+	r.Set(variables.Local, "b", "{{a}}")
+
+	_, unresolved := r.Resolve("{{a}}")
+	if len(unresolved) == 0 {
+		t.Error("Expected a cycle to be reported as unresolved")
+	}
+}
+
+func TestDynamicVariables(t *testing.T) {
+	r := variables.NewResolver()
+
+	have, unresolved := r.Resolve("{{$guid}} {{$timestamp}} {{$isoTimestamp}} {{$randomInt}} {{$unknown}}")
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12} \d+ \d{4}-\d\d-\d\dT\d\d:\d\d:\d\d\.\d{3}Z \d+ {{\$unknown}}$`)
+	if !pattern.MatchString(have) {
+		t.Errorf("Dynamic variables are incorrect, have: %s", have)
+	}
+
+	if want := []string{"$unknown"}; !reflect.DeepEqual(unresolved, want) {
+		t.Errorf("Unresolved variables are incorrect, have: %v, want: %v", unresolved, want)
+	}
+}
+
+func TestResolveRequest(t *testing.T) {
+	var req resources.Request
+	if err := json.Unmarshal([]byte(`{
+  "method": "POST",
+  "url": "{{baseUrl}}/users?id={{$guid}}",
+  "header": [{"key": "Authorization", "value": "Bearer {{token}}"}],
+  "body": {"mode": "raw", "raw": "{\"host\": \"{{host}}\"}"},
+  "auth": {"type": "bearer", "bearer": [{"key": "token", "value": "{{host}}", "type": "string"}]}
+}`), &req); err != nil {
+		t.Fatal(err)
+	}
+
+	r := newTestResolver()
+	out, unresolved := r.ResolveRequest(&req)
+
+	if have, want := out.URL.String(), "https://env.example.com/v1/users?id=guid"; have != want {
+		t.Errorf("URL is incorrect, have: %s, want: %s", have, want)
+	}
+	if have, want := out.Header[0].Value, "Bearer {{token}}"; have != want {
+		t.Errorf("Header is incorrect, have: %s, want: %s", have, want)
+	}
+	if have, want := out.Body.Raw, `{"host": "env.example.com"}`; have != want {
+		t.Errorf("Body is incorrect, have: %s, want: %s", have, want)
+	}
+	if have := out.Auth.Bearer[0].Value; have != "env.example.com" {
+		t.Errorf("Auth is incorrect, have: %v", have)
+	}
+	if want := []string{"token"}; !reflect.DeepEqual(unresolved, want) {
+		t.Errorf("Unresolved variables are incorrect, have: %v, want: %v", unresolved, want)
+	}
+
+	if req.Header[0].Value != "Bearer {{token}}" || req.Body.Raw != `{"host": "{{host}}"}` {
+		t.Error("Expected the original request to be unchanged")
+	}
+}
+
+func TestResolveRequestDynamicOnce(t *testing.T) {
+	var req resources.Request
+	if err := json.Unmarshal([]byte(`{
+  "url": {"raw": "https://example.com/{{$guid}}?t={{$timestamp}}", "host": ["example", "com"],
+    "path": ["{{$guid}}"], "query": [{"key": "t", "value": "{{$timestamp}}"}]},
+  "header": [{"key": "X-Id", "value": "{{$guid}}"}]
+}`), &req); err != nil {
+		t.Fatal(err)
+	}
+
+	r := variables.NewResolver()
+	n := 0
+	r.Dynamic["$guid"] = func() string {
+		n++
+		return fmt.Sprintf("guid-%d", n)
+	}
+
+	out, _ := r.ResolveRequest(&req)
+	if have, want := out.URL.Raw, "https://example.com/guid-1?t="+out.URL.Query[0].Value; have != want {
+		t.Errorf("Raw URL is incorrect, have: %s, want: %s", have, want)
+	}
+	if out.URL.Path[0] != "guid-1" || out.Header[0].Value != "guid-1" {
+		t.Errorf("Dynamic values are incorrect, have: %s, %s", out.URL.Path[0], out.Header[0].Value)
+	}
+
+	out, _ = r.ResolveRequest(&req)
+	if out.URL.Path[0] != "guid-2" {
+		t.Errorf("Expected a new value for another request, have: %s", out.URL.Path[0])
+	}
+}
+
+func TestReferences(t *testing.T) {
+	have := variables.References("{{a}}/{{ b }}/{{a}}/{{$guid}}")
+	if want := []string{"a", "b", "$guid"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("References are incorrect, have: %v, want: %v", have, want)
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package trash keeps copies of deleted Postman resources so that they can
+// be restored.
+package trash
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Entry is a deleted resource.
+type Entry struct {
+	ID         string            `json:"id"`
+	Type       string            `json:"type"`
+	ResourceID string            `json:"resourceId"`
+	Name       string            `json:"name"`
+	Params     map[string]string `json:"params,omitempty"`
+	DeletedAt  time.Time         `json:"deletedAt"`
+	Resource   json.RawMessage   `json:"resource"`
+}
+
+// Entries is a list of deleted resources.
+type Entries []*Entry
+
+// Format returns column headers and values for the resource.
+func (r Entries) Format() ([]string, []interface{}) {
+	s := make([]interface{}, len(r))
+	for i, e := range r {
+		s[i] = struct {
+			ID, Type, Name, ResourceID string
+			DeletedAt                  string
+		}{e.ID, e.Type, e.Name, e.ResourceID, e.DeletedAt.Local().Format(time.RFC3339)}
+	}
+
+	return []string{"ID", "Type", "Name", "ResourceID", "DeletedAt"}, s
+}
+
+// Bin stores entries as JSON files in a directory.
+type Bin struct {
+	Dir string
+}
+
+func (b *Bin) path(id string) string {
+	return filepath.Join(b.Dir, id+".json")
+}
+
+// Put stores an entry, setting its ID and deletion time.
+func (b *Bin) Put(e *Entry) error {
+	var suffix [3]byte
+	if _, err := rand.Read(suffix[:]); err != nil {
+		return err
+	}
+
+	e.DeletedAt = time.Now().UTC()
+	e.ID = e.DeletedAt.Format("20060102-150405") + "-" + hex.EncodeToString(suffix[:])
+
+	if err := os.MkdirAll(b.Dir, 0700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(b.path(e.ID), data, 0600)
+}
+
+// Get returns the entry with an ID.
+func (b *Bin) Get(id string) (*Entry, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) {
+		return nil, fmt.Errorf("invalid trash ID: %q", id)
+	}
+
+	data, err := ioutil.ReadFile(b.path(id))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("trash ID not found: %s", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var e Entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("trash entry %s: %s", id, err)
+	}
+
+	return &e, nil
+}
+
+// List returns the entries, the most recently deleted first.
+func (b *Bin) List() (Entries, error) {
+	paths, err := filepath.Glob(filepath.Join(b.Dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := Entries{}
+	for _, p := range paths {
+		e, err := b.Get(strings.TrimSuffix(filepath.Base(p), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].DeletedAt.After(entries[j].DeletedAt)
+	})
+
+	return entries, nil
+}
+
+// Remove deletes the entry with an ID.
+func (b *Bin) Remove(id string) error {
+	if _, err := b.Get(id); err != nil {
+		return err
+	}
+
+	return os.Remove(b.path(id))
+}
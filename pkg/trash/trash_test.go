@@ -0,0 +1,79 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trash_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/trash"
+)
+
+func TestBin(t *testing.T) {
+	dir, err := ioutil.TempDir("", "trash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	bin := &trash.Bin{Dir: dir}
+
+	first := &trash.Entry{Type: "environment", ResourceID: "u-1", Name: "staging", Resource: json.RawMessage(`{"name":"staging"}`)}
+	second := &trash.Entry{Type: "collection", ResourceID: "u-2", Name: "Orders", Resource: json.RawMessage(`{"info":{}}`)}
+	for _, e := range []*trash.Entry{first, second} {
+		if err := bin.Put(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if first.ID == "" || first.ID == second.ID {
+		t.Fatalf("IDs are incorrect: %s, %s", first.ID, second.ID)
+	}
+
+	e, err := bin.Get(first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resource bytes.Buffer
+	if err := json.Compact(&resource, e.Resource); err != nil {
+		t.Fatal(err)
+	}
+	if e.Name != "staging" || resource.String() != `{"name":"staging"}` {
+		t.Errorf("Entry is incorrect: %+v", e)
+	}
+
+	entries, err := bin.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].ID != second.ID {
+		t.Errorf("Entries are incorrect: %+v", entries)
+	}
+
+	if err := bin.Remove(first.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bin.Get(first.ID); err == nil {
+		t.Error("Expected the entry to be removed")
+	}
+	if _, err := bin.Get("../x"); err == nil {
+		t.Error("Expected an error for an invalid ID")
+	}
+}
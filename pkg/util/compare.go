@@ -29,7 +29,7 @@ func CompareMap(path string, old map[string]interface{}, new map[string]interfac
 		if _, ok := old[k]; !ok {
 			tmp := diff{
 				Path: fmt.Sprintf("%s.%s", path, k),
-				New:  old[k],
+				New:  new[k],
 				Old:  nil,
 			}
 			diffList = append(diffList, tmp)
@@ -0,0 +1,51 @@
+//go:build ignore
+// +build ignore
+
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// genschema writes the collection schema into a Go source file, so it is
+// available at runtime without reading from the repository.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+func main() {
+	b, err := ioutil.ReadFile("../../schema/collection.schema.json")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by genschema.go. DO NOT EDIT.\n\n")
+	out.WriteString("package validate\n\n")
+	out.WriteString("// collectionSchema is schema/collection.schema.json.\n")
+	out.WriteString("const collectionSchema = `")
+	out.WriteString(strings.Replace(string(b), "`", "` + \"`\" + `", -1))
+	out.WriteString("`\n")
+
+	if err := ioutil.WriteFile("collectionschema.go", out.Bytes(), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+func escapePointer(s string) string {
+	return pointerEscaper.Replace(s)
+}
+
+// lookupPointer returns the value at a JSON Pointer in a decoded document.
+func lookupPointer(doc interface{}, pointer string) (interface{}, bool) {
+	if pointer == "" {
+		return doc, true
+	}
+
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = pointerUnescaper.Replace(token)
+		switch t := doc.(type) {
+		case map[string]interface{}:
+			v, ok := t[token]
+			if !ok {
+				return nil, false
+			}
+			doc = v
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(t) {
+				return nil, false
+			}
+			doc = t[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return doc, true
+}
+
+// valuePositions returns the byte offset of every value in a JSON document,
+// keyed by JSON Pointer.
+func valuePositions(b []byte) map[string]int {
+	positions := make(map[string]int)
+	dec := json.NewDecoder(bytes.NewReader(b))
+
+	var walk func(pointer string) error
+	walk = func(pointer string) error {
+		positions[pointer] = skipSeparators(b, int(dec.InputOffset()))
+
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch tok {
+		case json.Delim('{'):
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				if err := walk(pointer + "/" + escapePointer(key.(string))); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				if err := walk(pointer + "/" + strconv.Itoa(i)); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		}
+
+		return err
+	}
+
+	// The document has already been decoded, so errors are not expected.
+	_ = walk("")
+
+	return positions
+}
+
+// skipSeparators moves past whitespace, commas and colons to the start of
+// the next token.
+func skipSeparators(b []byte, offset int) int {
+	for offset < len(b) {
+		switch b[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+
+	return offset
+}
+
+// lineColumn converts a byte offset to a line and column, both starting
+// at 1.
+func lineColumn(b []byte, offset int) (int, int) {
+	if offset > len(b) {
+		offset = len(b)
+	}
+
+	line := 1 + bytes.Count(b[:offset], []byte("\n"))
+	column := offset + 1
+	if i := bytes.LastIndexByte(b[:offset], '\n'); i >= 0 {
+		column = offset - i
+	}
+
+	return line, column
+}
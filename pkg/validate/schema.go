@@ -0,0 +1,402 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// failure is a validation error before its position is known.
+type failure struct {
+	pointer string
+	message string
+}
+
+// check validates instance against schema. Failures are reported in a
+// stable order.
+func (v *Validator) check(schema map[string]interface{}, instance interface{}, pointer string) []failure {
+	if ref, ok := schema["$ref"].(string); ok {
+		target, err := v.resolve(ref)
+		if err != nil {
+			return []failure{{pointer, err.Error()}}
+		}
+		return v.check(target, instance, pointer)
+	}
+
+	var failures []failure
+	fail := func(format string, a ...interface{}) {
+		failures = append(failures, failure{pointer, fmt.Sprintf(format, a...)})
+	}
+
+	if t, ok := schema["type"]; ok && !matchesType(t, instance) {
+		fail("expected %s, got %s", typeList(t), typeName(instance))
+		return failures
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if reflect.DeepEqual(e, instance) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("value must be one of %s", formatValues(enum))
+		}
+	}
+
+	switch t := instance.(type) {
+	case map[string]interface{}:
+		failures = append(failures, v.checkObject(schema, t, pointer)...)
+	case []interface{}:
+		failures = append(failures, v.checkArray(schema, t, pointer)...)
+	case string:
+		n := utf8.RuneCountInString(t)
+		if max, ok := schema["maxLength"].(float64); ok && float64(n) > max {
+			fail("must be at most %v characters long", max)
+		}
+		if min, ok := schema["minLength"].(float64); ok && float64(n) < min {
+			fail("must be at least %v characters long", min)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(t) {
+				fail("must match the pattern %q", pattern)
+			}
+		}
+	case float64:
+		if min, ok := schema["minimum"].(float64); ok {
+			if exclusive, _ := schema["exclusiveMinimum"].(bool); exclusive && t <= min {
+				fail("must be greater than %v", min)
+			} else if t < min {
+				fail("must be greater than or equal to %v", min)
+			}
+		}
+		if max, ok := schema["maximum"].(float64); ok {
+			if exclusive, _ := schema["exclusiveMaximum"].(bool); exclusive && t >= max {
+				fail("must be less than %v", max)
+			} else if t > max {
+				fail("must be less than or equal to %v", max)
+			}
+		}
+	}
+
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, s := range allOf {
+			if m, ok := s.(map[string]interface{}); ok {
+				failures = append(failures, v.check(m, instance, pointer)...)
+			}
+		}
+	}
+
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		if matched, branches := v.matchBranches(anyOf, instance, pointer); matched == 0 {
+			failures = append(failures, v.bestBranch(anyOf, branches, instance, pointer, "anyOf")...)
+		}
+	}
+
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+		matched, branches := v.matchBranches(oneOf, instance, pointer)
+		switch {
+		case matched == 0:
+			failures = append(failures, v.bestBranch(oneOf, branches, instance, pointer, "oneOf")...)
+		case matched > 1:
+			fail("must match exactly one schema in oneOf, but matches %d", matched)
+		}
+	}
+
+	if not, ok := schema["not"].(map[string]interface{}); ok {
+		if len(v.check(not, instance, pointer)) == 0 {
+			fail("must not match the schema in not")
+		}
+	}
+
+	return failures
+}
+
+func (v *Validator) checkObject(schema map[string]interface{}, instance map[string]interface{}, pointer string) []failure {
+	var failures []failure
+
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, ok := instance[name]; !ok {
+				failures = append(failures, failure{pointer, fmt.Sprintf("missing required property %q", name)})
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	keys := make([]string, 0, len(instance))
+	for k := range instance {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		p := pointer + "/" + escapePointer(k)
+		if s, ok := properties[k].(map[string]interface{}); ok {
+			failures = append(failures, v.check(s, instance[k], p)...)
+			continue
+		}
+
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				failures = append(failures, failure{pointer, fmt.Sprintf("property %q is not allowed", k)})
+			}
+		case map[string]interface{}:
+			failures = append(failures, v.check(additional, instance[k], p)...)
+		}
+	}
+
+	return failures
+}
+
+func (v *Validator) checkArray(schema map[string]interface{}, instance []interface{}, pointer string) []failure {
+	var failures []failure
+
+	if min, ok := schema["minItems"].(float64); ok && float64(len(instance)) < min {
+		failures = append(failures, failure{pointer, fmt.Sprintf("must have at least %v items", min)})
+	}
+	if max, ok := schema["maxItems"].(float64); ok && float64(len(instance)) > max {
+		failures = append(failures, failure{pointer, fmt.Sprintf("must have at most %v items", max)})
+	}
+
+	for i, e := range instance {
+		p := pointer + "/" + strconv.Itoa(i)
+		switch items := schema["items"].(type) {
+		case map[string]interface{}:
+			failures = append(failures, v.check(items, e, p)...)
+		case []interface{}:
+			if i < len(items) {
+				if s, ok := items[i].(map[string]interface{}); ok {
+					failures = append(failures, v.check(s, e, p)...)
+				}
+			}
+		}
+	}
+
+	return failures
+}
+
+// matchBranches validates instance against each schema of an anyOf or
+// oneOf, returning the number of matches and the failures of each branch.
+func (v *Validator) matchBranches(schemas []interface{}, instance interface{}, pointer string) (int, [][]failure) {
+	matched := 0
+	branches := make([][]failure, len(schemas))
+	for i, s := range schemas {
+		m, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		branches[i] = v.check(m, instance, pointer)
+		if len(branches[i]) == 0 {
+			matched++
+		}
+	}
+
+	return matched, branches
+}
+
+// bestBranch explains why no branch of an anyOf or oneOf matched. When the
+// type of the instance selects a single branch, or one branch comes closest,
+// its failures are reported, as they are more useful than a summary.
+func (v *Validator) bestBranch(schemas []interface{}, branches [][]failure, instance interface{}, pointer, keyword string) []failure {
+	var candidates []int
+	var types []string
+	for i, s := range schemas {
+		m, _ := s.(map[string]interface{})
+		t, ok := v.schemaType(m)
+		if !ok || matchesType(t, instance) {
+			candidates = append(candidates, i)
+		}
+		if ok {
+			types = append(types, typeList(t))
+		}
+	}
+
+	if len(candidates) == 0 {
+		return []failure{{pointer, fmt.Sprintf("expected %s, got %s", strings.Join(types, " or "), typeName(instance))}}
+	}
+
+	// A branch that only fails below this value got the shape right, e.g.
+	// an item whose URL is invalid, rather than an item-group without items.
+	var deep []int
+	for _, i := range candidates {
+		shallow := false
+		for _, f := range branches[i] {
+			if f.pointer == pointer {
+				shallow = true
+				break
+			}
+		}
+		if !shallow {
+			deep = append(deep, i)
+		}
+	}
+	if len(deep) == 1 {
+		return branches[deep[0]]
+	}
+
+	best := candidates[0]
+	tie := false
+	for _, i := range candidates[1:] {
+		switch {
+		case len(branches[i]) < len(branches[best]):
+			best, tie = i, false
+		case len(branches[i]) == len(branches[best]):
+			tie = true
+		}
+	}
+
+	if !tie {
+		return branches[best]
+	}
+
+	reasons := make([]string, 0, len(candidates))
+	for _, i := range candidates {
+		m, _ := schemas[i].(map[string]interface{})
+		reasons = append(reasons, fmt.Sprintf("%s (%s)", v.schemaTitle(m, i), branches[i][0].message))
+	}
+
+	return []failure{{pointer, fmt.Sprintf("must match a schema in %s: %s", keyword, strings.Join(reasons, "; "))}}
+}
+
+// schemaTitle names a branch of an anyOf or oneOf by its title, following
+// references, or by its position.
+func (v *Validator) schemaTitle(schema map[string]interface{}, i int) string {
+	for n := 0; schema != nil && n < 32; n++ {
+		if title, ok := schema["title"].(string); ok {
+			return title
+		}
+		ref, ok := schema["$ref"].(string)
+		if !ok {
+			break
+		}
+		schema, _ = v.resolve(ref)
+	}
+
+	return fmt.Sprintf("schema %d", i)
+}
+
+// schemaType returns the type keyword of a schema, following references.
+func (v *Validator) schemaType(schema map[string]interface{}) (interface{}, bool) {
+	for i := 0; schema != nil && i < 32; i++ {
+		ref, ok := schema["$ref"].(string)
+		if !ok {
+			break
+		}
+		schema, _ = v.resolve(ref)
+	}
+
+	t, ok := schema["type"]
+	return t, ok
+}
+
+func (v *Validator) resolve(ref string) (map[string]interface{}, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("unsupported schema reference %q", ref)
+	}
+
+	target, ok := lookupPointer(v.root, strings.TrimPrefix(ref, "#"))
+	m, isSchema := target.(map[string]interface{})
+	if !ok || !isSchema {
+		return nil, fmt.Errorf("unresolvable schema reference %q", ref)
+	}
+
+	return m, nil
+}
+
+func matchesType(t interface{}, instance interface{}) bool {
+	switch tt := t.(type) {
+	case string:
+		return matchesTypeName(tt, instance)
+	case []interface{}:
+		for _, name := range tt {
+			if s, ok := name.(string); ok && matchesTypeName(s, instance) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+func matchesTypeName(name string, instance interface{}) bool {
+	switch name {
+	case "integer":
+		f, ok := instance.(float64)
+		return ok && f == math.Trunc(f)
+	case "number":
+		_, ok := instance.(float64)
+		return ok
+	case "any":
+		return true
+	}
+
+	return typeName(instance) == name
+}
+
+func typeName(instance interface{}) string {
+	switch instance.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+
+	return "unknown"
+}
+
+func typeList(t interface{}) string {
+	if list, ok := t.([]interface{}); ok {
+		s := make([]string, len(list))
+		for i, name := range list {
+			s[i] = fmt.Sprint(name)
+		}
+		return strings.Join(s, " or ")
+	}
+
+	return fmt.Sprint(t)
+}
+
+func formatValues(values []interface{}) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = fmt.Sprintf("%q", fmt.Sprint(v))
+	}
+
+	return strings.Join(s, ", ")
+}
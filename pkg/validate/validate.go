@@ -0,0 +1,154 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validate checks documents against JSON Schemas, such as the
+// Postman collection schema.
+package validate
+
+//go:generate go run genschema.go
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+)
+
+// Error is a single validation failure.
+type Error struct {
+	// Pointer is the JSON Pointer of the invalid value, e.g. /item/0/request.
+	Pointer string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	pointer := e.Pointer
+	if pointer == "" {
+		pointer = "(root)"
+	}
+
+	return fmt.Sprintf("line %d, column %d: %s: %s", e.Line, e.Column, pointer, e.Message)
+}
+
+// Errors is a list of validation failures.
+type Errors []*Error
+
+func (e Errors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+
+	return "invalid document:\n" + strings.Join(s, "\n")
+}
+
+// Is reports whether target is client.ErrValidation.
+func (e Errors) Is(target error) bool {
+	return target == client.ErrValidation
+}
+
+var (
+	collectionValidator     *Validator
+	collectionValidatorErr  error
+	collectionValidatorOnce sync.Once
+)
+
+// Collection validates a collection against the v2.1.0 collection schema.
+// Both the bare collection and the {"collection": ...} envelope used by the
+// Postman API are accepted. It returns nil when the collection is valid.
+func Collection(b []byte) Errors {
+	collectionValidatorOnce.Do(func() {
+		collectionValidator, collectionValidatorErr = NewValidator([]byte(collectionSchema))
+	})
+	if collectionValidatorErr != nil {
+		// The embedded schema is known to be valid.
+		panic(collectionValidatorErr)
+	}
+
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(b, &envelope); err == nil && len(envelope) == 1 && envelope["collection"] != nil {
+		return collectionValidator.validate(b, "/collection")
+	}
+
+	return collectionValidator.Validate(b)
+}
+
+// Validator validates documents against a JSON Schema. The draft-04
+// keywords used by the Postman schemas are supported. References must be
+// local to the schema, e.g. #/definitions/item.
+type Validator struct {
+	root map[string]interface{}
+}
+
+// NewValidator returns a validator for a JSON Schema.
+func NewValidator(schema []byte) (*Validator, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return nil, fmt.Errorf("invalid schema: %s", err)
+	}
+
+	return &Validator{root: root}, nil
+}
+
+// Validate validates a JSON document. Syntax errors are returned as
+// validation errors. It returns nil when the document is valid.
+func (v *Validator) Validate(b []byte) Errors {
+	return v.validate(b, "")
+}
+
+// validate validates the value at pointer in the document.
+func (v *Validator) validate(b []byte, pointer string) Errors {
+	var doc interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return Errors{syntaxError(b, err)}
+	}
+
+	instance, ok := lookupPointer(doc, pointer)
+	if !ok {
+		return Errors{{Pointer: pointer, Line: 1, Column: 1, Message: "value not found"}}
+	}
+
+	failures := v.check(v.root, instance, pointer)
+	if len(failures) == 0 {
+		return nil
+	}
+
+	positions := valuePositions(b)
+	errs := make(Errors, len(failures))
+	for i, f := range failures {
+		line, column := lineColumn(b, positions[f.pointer])
+		errs[i] = &Error{Pointer: f.pointer, Line: line, Column: column, Message: f.message}
+	}
+
+	return errs
+}
+
+func syntaxError(b []byte, err error) *Error {
+	var offset int64
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		offset = e.Offset
+	case *json.UnmarshalTypeError:
+		offset = e.Offset
+	}
+
+	line, column := lineColumn(b, int(offset))
+	return &Error{Line: line, Column: column, Message: err.Error()}
+}
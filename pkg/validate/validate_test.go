@@ -0,0 +1,110 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+	"github.com/kevinswiber/postmanctl/pkg/validate"
+)
+
+func TestCollectionValid(t *testing.T) {
+	c := `{
+  "collection": {
+    "info": {"name": "Test", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+    "item": [
+      {"name": "Folder", "item": [{"name": "Get", "request": "https://example.com"}]},
+      {"name": "Post", "request": {"method": "POST", "url": {"raw": "https://example.com"}, "body": {"mode": "raw", "raw": "{}"}}}
+    ]
+  }
+}`
+
+	if errs := validate.Collection([]byte(c)); errs != nil {
+		t.Errorf("Expected no errors, have: %s", errs)
+	}
+}
+
+func TestCollectionErrors(t *testing.T) {
+	c := `{
+  "info": {"name": "Test"},
+  "item": [
+    {"name": "Get", "request": {"url": 5}},
+    {"name": "Empty"},
+    {"name": "Folder", "item": [{"name": "Post", "request": {"header": [{"key": 1, "value": ""}]}}]}
+  ]
+}`
+
+	errs := validate.Collection([]byte(c))
+
+	expected := []validate.Error{
+		{Pointer: "/info", Line: 2, Column: 11, Message: `missing required property "schema"`},
+		{Pointer: "/item/0/request/url", Line: 4, Column: 40, Message: "expected object or string, got number"},
+		{Pointer: "/item/1", Line: 5, Column: 5},
+		{Pointer: "/item/2/item/0/request/header/0/key", Line: 6, Column: 81, Message: "expected string, got number"},
+	}
+
+	if len(errs) != len(expected) {
+		t.Fatalf("Error count is incorrect, have: %d, want: %d\n%s", len(errs), len(expected), errs)
+	}
+
+	for i, e := range expected {
+		have := errs[i]
+		if have.Pointer != e.Pointer || have.Line != e.Line || have.Column != e.Column {
+			t.Errorf("Error position is incorrect, have: %s %d:%d, want: %s %d:%d",
+				have.Pointer, have.Line, have.Column, e.Pointer, e.Line, e.Column)
+		}
+		if e.Message != "" && have.Message != e.Message {
+			t.Errorf("Error message is incorrect, have: %s, want: %s", have.Message, e.Message)
+		}
+	}
+
+	if !strings.Contains(errs[2].Message, `missing required property "request"`) ||
+		!strings.Contains(errs[2].Message, `missing required property "item"`) {
+		t.Errorf("Expected both oneOf branches to be explained, have: %s", errs[2].Message)
+	}
+}
+
+func TestCollectionSyntaxError(t *testing.T) {
+	errs := validate.Collection([]byte("{\n  \"info\": }"))
+	if len(errs) != 1 {
+		t.Fatalf("Error count is incorrect, have: %d, want: 1", len(errs))
+	}
+
+	if errs[0].Line != 2 {
+		t.Errorf("Error line is incorrect, have: %d, want: 2", errs[0].Line)
+	}
+
+	var err error = errs
+	if !errors.Is(err, client.ErrValidation) {
+		t.Errorf("Errors should match %v", client.ErrValidation)
+	}
+}
+
+func TestValidatorPointerEscaping(t *testing.T) {
+	v, err := validate.NewValidator([]byte(`{"additionalProperties": {"type": "string"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := v.Validate([]byte(`{"a/b": 1, "c~d": "ok"}`))
+	if len(errs) != 1 || errs[0].Pointer != "/a~1b" || errs[0].Column != 9 {
+		t.Errorf("Unexpected errors: %s", errs)
+	}
+}